@@ -1,6 +1,9 @@
 package api
 
 import (
+	"time"
+
+	"github.com/manala/manala/app/repository/getter"
 	"github.com/manala/manala/internal/cache"
 	"github.com/manala/manala/internal/log"
 )
@@ -23,13 +26,101 @@ func New(log *log.Log, cache *cache.Cache, opts ...Option) *API {
 type API struct {
 	log                  *log.Log
 	cache                *cache.Cache
+	version              string
 	defaultRepositoryURL string
+	repositoryAliases    map[string]string
+	offline              bool
+	cacheTTL             time.Duration
+	timeout              time.Duration
+	gitAuth              *getter.GitAuth
+	gitShallow           bool
+	gitSparse            bool
+	allowedSigners       string
+	projectEnv           string
+	secretExec           bool
 }
 
 type Option func(api *API)
 
+// WithVersion sets current manala version, checked against repositories requirements.
+func WithVersion(version string) Option {
+	return func(api *API) {
+		api.version = version
+	}
+}
+
 func WithDefaultRepositoryURL(url string) Option {
 	return func(api *API) {
 		api.defaultRepositoryURL = url
 	}
 }
+
+// WithRepositoryAliases sets repository urls, indexed by alias names.
+func WithRepositoryAliases(aliases map[string]string) Option {
+	return func(api *API) {
+		api.repositoryAliases = aliases
+	}
+}
+
+// WithOffline serves repositories strictly from cache.
+func WithOffline(offline bool) Option {
+	return func(api *API) {
+		api.offline = offline
+	}
+}
+
+// WithCacheTTL skips repositories fetching while their cache is fresh.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(api *API) {
+		api.cacheTTL = ttl
+	}
+}
+
+// WithTimeout aborts repositories fetching after a given duration.
+func WithTimeout(timeout time.Duration) Option {
+	return func(api *API) {
+		api.timeout = timeout
+	}
+}
+
+// WithGitAuth reaches private git repositories using credentials.
+func WithGitAuth(auth *getter.GitAuth) Option {
+	return func(api *API) {
+		api.gitAuth = auth
+	}
+}
+
+// WithGitShallow only fetches the last commit of git repositories refs.
+func WithGitShallow(shallow bool) Option {
+	return func(api *API) {
+		api.gitShallow = shallow
+	}
+}
+
+// WithGitSparse only extracts the needed recipe dir of git repositories.
+func WithGitSparse(sparse bool) Option {
+	return func(api *API) {
+		api.gitSparse = sparse
+	}
+}
+
+// WithAllowedSigners verifies repositories signatures against an ssh allowed signers file.
+func WithAllowedSigners(file string) Option {
+	return func(api *API) {
+		api.allowedSigners = file
+	}
+}
+
+// WithProjectEnv merges ".manala.<env>.yaml" override manifests over loaded projects vars.
+func WithProjectEnv(env string) Option {
+	return func(api *API) {
+		api.projectEnv = env
+	}
+}
+
+// WithSecretExec enables secrets resolved from shell commands output.
+func WithSecretExec(enabled bool) Option {
+	return func(api *API) {
+		api.secretExec = enabled
+	}
+}
@@ -41,14 +41,20 @@ func (api *API) NewProjectLoader(repositoryLoader *repository.Loader, recipeLoad
 		),
 		project.WithLoaderHandlers(
 			append(handlers,
-				manifest.NewLoaderHandler(api.log, repositoryLoader, recipeLoader),
+				manifest.NewLoaderHandler(api.log, repositoryLoader, recipeLoader,
+					manifest.WithMigrate(options.migrate),
+					manifest.WithLock(options.lock),
+					manifest.WithEnv(api.projectEnv),
+				),
 			)...,
 		),
 	)
 }
 
 type projectLoaderOptions struct {
-	from bool
+	from    bool
+	migrate bool
+	lock    bool
 }
 
 type ProjectLoaderOption func(options *projectLoaderOptions)
@@ -59,14 +65,41 @@ func (api *API) WithProjectLoaderFrom(from bool) ProjectLoaderOption {
 	}
 }
 
+// WithProjectLoaderMigrate applies pending recipe vars migrations to loaded projects manifests.
+func (api *API) WithProjectLoaderMigrate(migrate bool) ProjectLoaderOption {
+	return func(options *projectLoaderOptions) {
+		options.migrate = migrate
+	}
+}
+
+// WithProjectLoaderLock locks repositories refs resolved from version constraints into loaded projects manifests.
+func (api *API) WithProjectLoaderLock(lock bool) ProjectLoaderOption {
+	return func(options *projectLoaderOptions) {
+		options.lock = lock
+	}
+}
+
 func (api *API) NewProjectFinder() *manifest.Finder {
 	return manifest.NewFinder()
 }
 
+func (api *API) NewProjectMigrator(repositoryLoader *repository.Loader, recipeLoader *recipe.Loader) *manifest.Migrator {
+	return manifest.NewMigrator(api.log, repositoryLoader, recipeLoader)
+}
+
+func (api *API) NewProjectConfigurator() *manifest.Configurator {
+	return manifest.NewConfigurator(api.log)
+}
+
+func (api *API) NewProjectEditor(dir string) *manifest.Editor {
+	return manifest.NewEditor(api.log, dir)
+}
+
 func (api *API) NewProjectSyncer() *sync.Syncer {
 	return sync.NewSyncer(
 		api.log,
 		api.NewTemplateEngine(),
+		api.NewSecretResolver(),
 	)
 }
 
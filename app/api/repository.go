@@ -2,17 +2,80 @@ package api
 
 import (
 	"context"
+	"maps"
+	"slices"
 
 	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/app/repository/cache"
 	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/repository/manifest"
 	"github.com/manala/manala/app/repository/url"
 )
 
 func (api *API) NewRepositoryLoader(ctx context.Context) *repository.Loader {
-	// URL processor
-	urlProcessor := url.NewProcessor(api.log)
+	// Getter options
+	getterOpts := api.getterOptions()
+
+	return repository.NewLoader(
+		repository.WithLoaderHandlers(
+			url.NewProcessorLoaderHandler(api.log, api.newContextRepositoryURLProcessor(ctx)),
+			cache.NewLoaderHandler(api.log, cache.New(), cache.WithRecipeScope(api.gitSparse)),
+			manifest.NewLoaderHandler(api.log, api.version),
+			getter.NewOCILoaderHandler(api.log, api.cache, getterOpts...),
+			getter.NewGitLoaderHandler(api.log, api.cache, getterOpts...),
+			getter.NewS3LoaderHandler(api.log, api.cache, getterOpts...),
+			getter.NewHTTPLoaderHandler(api.log, api.cache, getterOpts...),
+			getter.NewFileLoaderHandler(api.log, getterOpts...),
+		),
+	)
+}
+
+// WithRepositoryShorthand sets context recipe name from a repository alias shorthand (acme:recipe),
+// unless already set.
+func (api *API) WithRepositoryShorthand(ctx context.Context) context.Context {
+	url, ok := app.RepositoryURL(ctx)
+	if !ok {
+		return ctx
+	}
+
+	if _, ok := app.RecipeName(ctx); ok {
+		return ctx
+	}
+
+	if name, ok := api.newRepositoryURLProcessor().Recipe(url); ok {
+		return app.WithRecipeName(ctx, name)
+	}
+
+	return ctx
+}
+
+// SearchRepositoryURLs returns urls of repositories to search recipes in:
+// context (or default) repository first, followed by aliased ones, unless context one is explicitly set.
+// Urls are meant to be passed to a repository loader, an empty one standing for context (or default) repository.
+func (api *API) SearchRepositoryURLs(ctx context.Context) []string {
+	urls := []string{""}
+
+	if _, ok := app.RepositoryURL(ctx); ok {
+		return urls
+	}
+
+	return append(urls, slices.Sorted(maps.Keys(api.repositoryAliases))...)
+}
+
+// ResolveRepositoryURL resolves a repository url aliases.
+func (api *API) ResolveRepositoryURL(url string) (string, error) {
+	return api.newRepositoryURLProcessor().Process(url)
+}
+
+// ContextRepositoryURL returns context (or default) repository url, aliases resolved, without loading it.
+func (api *API) ContextRepositoryURL(ctx context.Context) (string, error) {
+	return api.newContextRepositoryURLProcessor(ctx).Process("")
+}
+
+// newContextRepositoryURLProcessor returns an url processor aware of context (or default) repository url and ref.
+func (api *API) newContextRepositoryURLProcessor(ctx context.Context) *url.Processor {
+	urlProcessor := api.newRepositoryURLProcessor()
 	if api.defaultRepositoryURL != "" {
 		urlProcessor.Add(api.defaultRepositoryURL, -10)
 	}
@@ -25,14 +88,34 @@ func (api *API) NewRepositoryLoader(ctx context.Context) *repository.Loader {
 		urlProcessor.AddQuery("ref", ref, 20)
 	}
 
-	return repository.NewLoader(
-		repository.WithLoaderHandlers(
-			url.NewProcessorLoaderHandler(api.log, urlProcessor),
-			cache.NewLoaderHandler(api.log, cache.New()),
-			getter.NewGitLoaderHandler(api.log, api.cache),
-			getter.NewS3LoaderHandler(api.log, api.cache),
-			getter.NewHTTPLoaderHandler(api.log, api.cache),
-			getter.NewFileLoaderHandler(api.log),
-		),
-	)
+	return urlProcessor
+}
+
+func (api *API) newRepositoryURLProcessor() *url.Processor {
+	urlProcessor := url.NewProcessor(api.log)
+	for name, aliasURL := range api.repositoryAliases {
+		urlProcessor.AddAlias(name, aliasURL)
+	}
+
+	return urlProcessor
+}
+
+func (api *API) NewRepositoryVersionsLister() *getter.GitVersionsLister {
+	return getter.NewGitVersionsLister(api.log, api.getterOptions()...)
+}
+
+func (api *API) NewRepositoryCacheManager() *getter.CacheManager {
+	return getter.NewCacheManager(api.log, api.cache)
+}
+
+func (api *API) getterOptions() []getter.Option {
+	return []getter.Option{
+		getter.WithOffline(api.offline),
+		getter.WithCacheTTL(api.cacheTTL),
+		getter.WithTimeout(api.timeout),
+		getter.WithGitAuth(api.gitAuth),
+		getter.WithGitShallow(api.gitShallow),
+		getter.WithGitSparse(api.gitSparse),
+		getter.WithAllowedSigners(api.allowedSigners),
+	}
 }
@@ -0,0 +1,11 @@
+package api
+
+import "github.com/manala/manala/app/secret"
+
+func (api *API) NewSecretResolver() *secret.Resolver {
+	return secret.NewResolver(api.log,
+		secret.WithBackend("file", secret.NewFileBackend()),
+		secret.WithBackend("sops", secret.NewSopsBackend()),
+		secret.WithBackend("exec", secret.NewExecBackend(api.secretExec)),
+	)
+}
@@ -36,6 +36,25 @@ func RepositoryRef(ctx context.Context) (string, bool) {
 	return value, ok
 }
 
+type repositoryLockedRefKey struct{}
+
+// WithRepositoryLockedRef sets a repository ref, formerly resolved from a version constraint,
+// to be reused as long as it still matches the constraint.
+func WithRepositoryLockedRef(ctx context.Context, ref string) context.Context {
+	if ref == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, repositoryLockedRefKey{}, ref)
+}
+
+// RepositoryLockedRef gets the repository locked ref from the context.
+func RepositoryLockedRef(ctx context.Context) (string, bool) {
+	value, ok := ctx.Value(repositoryLockedRefKey{}).(string)
+
+	return value, ok
+}
+
 type recipeNameKey struct{}
 
 func WithRecipeName(ctx context.Context, recipeName string) context.Context {
@@ -37,7 +37,22 @@ type NotFoundRepositoryError struct{ URL string }
 func (err *NotFoundRepositoryError) Error() string   { return "repository not found" }
 func (err *NotFoundRepositoryError) Attrs() [][2]any { return [][2]any{{"url", err.URL}} }
 
+type OfflineRepositoryError struct{ URL string }
+
+func (err *OfflineRepositoryError) Error() string   { return "repository not available offline" }
+func (err *OfflineRepositoryError) Attrs() [][2]any { return [][2]any{{"url", err.URL}} }
+
 type EmptyRepositoryError struct{ Repository Repository }
 
 func (err *EmptyRepositoryError) Error() string   { return "empty repository" }
 func (err *EmptyRepositoryError) Attrs() [][2]any { return [][2]any{{"url", err.Repository.URL()}} }
+
+type UnverifiedRepositoryError struct {
+	URL    string
+	Reason string
+}
+
+func (err *UnverifiedRepositoryError) Error() string { return "unverified repository" }
+func (err *UnverifiedRepositoryError) Attrs() [][2]any {
+	return [][2]any{{"url", err.URL}, {"reason", err.Reason}}
+}
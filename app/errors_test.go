@@ -62,6 +62,15 @@ func (s *ErrorsSuite) TestRepository() {
 		}, err)
 	})
 
+	s.Run("OfflineRepositoryError", func() {
+		err := &app.OfflineRepositoryError{URL: "url"}
+
+		expectation.ExpectError(s.T(), errors.Expectation{
+			Type:  &app.OfflineRepositoryError{},
+			Attrs: [][2]any{{"url", "url"}},
+		}, err)
+	})
+
 	s.Run("EmptyRepositoryError", func() {
 		repositoryMock := &mocks.Repository{}
 		repositoryMock.
@@ -74,4 +83,13 @@ func (s *ErrorsSuite) TestRepository() {
 			Attrs: [][2]any{{"url", "url"}},
 		}, err)
 	})
+
+	s.Run("UnverifiedRepositoryError", func() {
+		err := &app.UnverifiedRepositoryError{URL: "url", Reason: "reason"}
+
+		expectation.ExpectError(s.T(), errors.Expectation{
+			Type:  &app.UnverifiedRepositoryError{},
+			Attrs: [][2]any{{"url", "url"}, {"reason", "reason"}},
+		}, err)
+	})
 }
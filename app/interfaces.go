@@ -1,6 +1,7 @@
 package app
 
 import (
+	"github.com/manala/manala/app/migration"
 	"github.com/manala/manala/app/sync"
 )
 
@@ -26,9 +27,15 @@ type Recipe interface {
 	Name() string
 	Description() string
 	Icon() string
+	Category() string
+	Deprecated() string
+	ReplacedBy() string
+	Version() string
+	Migrations() []*migration.Migration
 	Template() string
 	Partials() []string
 	Sync() []sync.Unit
+	MergeStrategies() map[string]map[string]string
 	Repository() Repository
 	Vars() map[string]any
 	Schema() map[string]any
@@ -41,6 +48,9 @@ type RecipeOption interface {
 	Name() string
 	Label() string
 	Help() string
+	Group() string
+	Pointer() string
+	Active(vars *map[string]any) (bool, error)
 }
 
 /**************/
@@ -51,4 +61,25 @@ type RecipeOption interface {
 type Repository interface {
 	URL() string
 	Dir() string
+	Ref() string
+	// Constraint is the version constraint ref was resolved from, if any
+	Constraint() string
+	Manifest() RepositoryManifest
+}
+
+// RepositoryManifest describe a repository manifest interface, describing its recipes collection.
+type RepositoryManifest interface {
+	Name() string
+	Description() string
+	Partials() []string
+	Recipes() []RepositoryManifestRecipe
+	Recipe(name string) (RepositoryManifestRecipe, bool)
+}
+
+// RepositoryManifestRecipe describe a repository manifest recipe interface.
+type RepositoryManifestRecipe interface {
+	Name() string
+	Category() string
+	Hidden() bool
+	Deprecated() string
 }
@@ -0,0 +1,116 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+	yamlmapping "github.com/manala/manala/internal/yaml/mapping"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// Migration brings project vars up to a recipe version, by applying its operations in order.
+type Migration struct {
+	Version    string
+	Operations []Operation
+}
+
+// Apply migration operations on a project manifest node.
+func (migration *Migration) Apply(log *log.Log, node *ast.MappingNode) error {
+	for _, operation := range migration.Operations {
+		applied, err := operation.Apply(node)
+		if err != nil {
+			return err
+		}
+
+		log.Debug("migrate project vars", "version", migration.Version, "operation", operation.String(), "applied", applied)
+	}
+
+	return nil
+}
+
+// Operation is a single vars migration operation, targeting vars by json pointer.
+// Only one of move, rename, delete or set is expected.
+type Operation struct {
+	Move   string `yaml:"move"`
+	Rename string `yaml:"rename"`
+	Delete string `yaml:"delete"`
+	Set    string `yaml:"set"`
+	To     string `yaml:"to"`
+	Value  any    `yaml:"value"`
+}
+
+// Validate ensures operation is one of move, rename, delete or set,
+// and keeps away from project manifest config.
+func (operation *Operation) Validate() error {
+	var pointers []string
+	for _, pointer := range []string{operation.Move, operation.Rename, operation.Delete, operation.Set} {
+		if pointer != "" {
+			pointers = append(pointers, pointer)
+		}
+	}
+
+	if len(pointers) != 1 {
+		return errors.New("expected one of move, rename, delete or set")
+	}
+
+	if operation.To != "" && operation.Move == "" && operation.Rename == "" {
+		return errors.New("to only expected along with move or rename")
+	}
+
+	if operation.Move != "" {
+		pointers = append(pointers, operation.To)
+	}
+
+	for _, pointer := range pointers {
+		if pointer == "/manala" || strings.HasPrefix(pointer, "/manala/") {
+			return errors.New("project manifest config is out of reach")
+		}
+	}
+
+	return nil
+}
+
+// Apply operation on a project manifest node, and tells whether anything changed.
+// Move, rename and delete operations targeting missing vars are skipped.
+func (operation *Operation) Apply(node *ast.MappingNode) (bool, error) {
+	var (
+		applied bool
+		err     error
+	)
+
+	switch {
+	case operation.Move != "":
+		applied, err = yamlmapping.Move(node, operation.Move, operation.To)
+	case operation.Rename != "":
+		applied, err = yamlmapping.Rename(node, operation.Rename, operation.To)
+	case operation.Delete != "":
+		_, applied = yamlmapping.Delete(node, operation.Delete)
+	default:
+		applied, err = true, yamlmapping.Set(node, operation.Set, operation.Value)
+	}
+
+	if err != nil {
+		return false, serror.New("unable to apply migration operation").
+			With("operation", operation.String()).
+			WithErr(err)
+	}
+
+	return applied, nil
+}
+
+func (operation *Operation) String() string {
+	switch {
+	case operation.Move != "":
+		return fmt.Sprintf("move %s to %s", operation.Move, operation.To)
+	case operation.Rename != "":
+		return fmt.Sprintf("rename %s to %s", operation.Rename, operation.To)
+	case operation.Delete != "":
+		return "delete " + operation.Delete
+	}
+
+	return "set " + operation.Set
+}
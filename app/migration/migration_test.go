@@ -0,0 +1,131 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/manala/manala/app/migration"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrationSuite struct{ suite.Suite }
+
+func TestMigrationSuite(t *testing.T) {
+	suite.Run(t, new(MigrationSuite))
+}
+
+func (s *MigrationSuite) TestApply() {
+	node, _ := yamlparser.ParseRaw([]byte(heredoc.Doc(`
+		manala:
+		  recipe: recipe
+
+		system:
+		  # Php version
+		  php: 8.4
+		  legacy: true
+		  nginx: ~
+	`)))
+
+	migration := &migration.Migration{
+		Version: "2.0.0",
+		Operations: []migration.Operation{
+			{Move: "/system/php", To: "/system/php/version"},
+			{Rename: "/system/nginx", To: "http"},
+			{Delete: "/system/legacy"},
+			{Delete: "/system/missing"},
+			{Set: "/system/http", Value: map[string]any{"port": 80}},
+		},
+	}
+
+	s.Require().NoError(migration.Apply(log.Discard, node))
+
+	heredoc.Equal(s.T(), `
+		manala:
+		  recipe: recipe
+
+		system:
+		  http:
+		    port: 80
+		  # Php version
+		  php:
+		    version: 8.4
+	`, node.String()+"\n")
+}
+
+func (s *MigrationSuite) TestApplyErrors() {
+	node, _ := yamlparser.ParseRaw([]byte(heredoc.Doc(`
+		foo: foo
+		bar: bar
+	`)))
+
+	migration := &migration.Migration{
+		Version: "2.0.0",
+		Operations: []migration.Operation{
+			{Rename: "/foo", To: "bar"},
+		},
+	}
+
+	err := migration.Apply(log.Discard, node)
+
+	expectation.ExpectError(s.T(), serrortest.Expectation{
+		Msg: "unable to apply migration operation",
+		Attrs: [][2]any{
+			{"operation", "rename /foo to bar"},
+		},
+		Err: expectation.ErrorMessage("key already exists"),
+	}, err)
+}
+
+func (s *MigrationSuite) TestOperationValidate() {
+	tests := []struct {
+		test      string
+		operation migration.Operation
+		expected  string
+	}{
+		{test: "Move", operation: migration.Operation{Move: "/foo", To: "/bar"}},
+		{test: "Rename", operation: migration.Operation{Rename: "/foo", To: "bar"}},
+		{test: "Delete", operation: migration.Operation{Delete: "/foo"}},
+		{test: "Set", operation: migration.Operation{Set: "/foo", Value: "foo"}},
+		{
+			test:      "None",
+			operation: migration.Operation{To: "/bar"},
+			expected:  "expected one of move, rename, delete or set",
+		},
+		{
+			test:      "Many",
+			operation: migration.Operation{Delete: "/foo", Set: "/bar"},
+			expected:  "expected one of move, rename, delete or set",
+		},
+		{
+			test:      "UnexpectedTo",
+			operation: migration.Operation{Delete: "/foo", To: "/bar"},
+			expected:  "to only expected along with move or rename",
+		},
+		{
+			test:      "Manala",
+			operation: migration.Operation{Delete: "/manala"},
+			expected:  "project manifest config is out of reach",
+		},
+		{
+			test:      "ManalaTo",
+			operation: migration.Operation{Move: "/foo", To: "/manala/foo"},
+			expected:  "project manifest config is out of reach",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			err := test.operation.Validate()
+			if test.expected == "" {
+				s.NoError(err)
+			} else {
+				s.EqualError(err, test.expected)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package project
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -33,15 +34,15 @@ func NewLoader(log *log.Log, opts ...LoaderOption) *Loader {
 	return loader
 }
 
-func (loader *Loader) Load(dir string) (app.Project, error) {
+func (loader *Loader) Load(ctx context.Context, dir string) (app.Project, error) {
 	// Prepare query
 	query := &LoaderQuery{Dir: dir}
 
 	// Start chain
-	return loader.Next(query)
+	return loader.Next(ctx, query)
 }
 
-func (loader *Loader) LoadRecursive(dir string, fn func(project app.Project) error) error {
+func (loader *Loader) LoadRecursive(ctx context.Context, dir string, fn func(project app.Project) error) error {
 	err := filepath.WalkDir(dir,
 		func(path string, entry os.DirEntry, err error) error {
 			if err != nil {
@@ -55,6 +56,11 @@ func (loader *Loader) LoadRecursive(dir string, fn func(project app.Project) err
 					WithErr(std.From(err))
 			}
 
+			// Cancellation
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
 			// Only directories
 			if !entry.IsDir() {
 				return nil
@@ -70,7 +76,7 @@ func (loader *Loader) LoadRecursive(dir string, fn func(project app.Project) err
 			}
 
 			// Load project
-			project, err := loader.Load(path)
+			project, err := loader.Load(ctx, path)
 			if err != nil {
 				if _, ok := errors.AsType[*app.NotFoundProjectError](err); ok {
 					err = nil
@@ -87,18 +93,18 @@ func (loader *Loader) LoadRecursive(dir string, fn func(project app.Project) err
 	return err
 }
 
-func (loader Loader) Next(query *LoaderQuery) (app.Project, error) {
+func (loader Loader) Next(ctx context.Context, query *LoaderQuery) (app.Project, error) {
 	if len(loader.handlers) == 0 {
-		return loader.Last(query)
+		return loader.Last(ctx, query)
 	}
 
 	handler := loader.handlers[0]
 	loader.handlers = loader.handlers[1:]
 
-	return handler.Handle(query, loader)
+	return handler.Handle(ctx, query, loader)
 }
 
-func (loader Loader) Last(query *LoaderQuery) (app.Project, error) {
+func (loader Loader) Last(ctx context.Context, query *LoaderQuery) (app.Project, error) {
 	return nil, &app.NotFoundProjectError{Dir: query.Dir}
 }
 
@@ -121,36 +127,36 @@ type LoaderQuery struct {
 }
 
 type LoaderHandler interface {
-	Handle(query *LoaderQuery, chain LoaderHandlerChain) (app.Project, error)
+	Handle(ctx context.Context, query *LoaderQuery, chain LoaderHandlerChain) (app.Project, error)
 }
 
 type LoaderHandlerMock struct {
 	mock.Mock
 }
 
-func (mock *LoaderHandlerMock) Handle(query *LoaderQuery, chain LoaderHandlerChain) (app.Project, error) {
-	args := mock.Called(query, chain)
+func (mock *LoaderHandlerMock) Handle(ctx context.Context, query *LoaderQuery, chain LoaderHandlerChain) (app.Project, error) {
+	args := mock.Called(ctx, query, chain)
 
 	return args.Get(0).(app.Project), args.Error(1)
 }
 
 type LoaderHandlerChain interface {
-	Next(query *LoaderQuery) (app.Project, error)
-	Last(query *LoaderQuery) (app.Project, error)
+	Next(ctx context.Context, query *LoaderQuery) (app.Project, error)
+	Last(ctx context.Context, query *LoaderQuery) (app.Project, error)
 }
 
 type LoaderHandlerChainMock struct {
 	mock.Mock
 }
 
-func (mock *LoaderHandlerChainMock) Next(query *LoaderQuery) (app.Project, error) {
-	args := mock.Called(query)
+func (mock *LoaderHandlerChainMock) Next(ctx context.Context, query *LoaderQuery) (app.Project, error) {
+	args := mock.Called(ctx, query)
 
 	return args.Get(0).(app.Project), args.Error(1)
 }
 
-func (mock *LoaderHandlerChainMock) Last(query *LoaderQuery) (app.Project, error) {
-	args := mock.Called(query)
+func (mock *LoaderHandlerChainMock) Last(ctx context.Context, query *LoaderQuery) (app.Project, error) {
+	args := mock.Called(ctx, query)
 
 	return args.Get(0).(app.Project), args.Error(1)
 }
@@ -27,11 +27,11 @@ func (s *LoaderSuite) TestLoad() {
 
 	handlerMock := &project.LoaderHandlerMock{}
 	handlerMock.
-		On("Handle", &project.LoaderQuery{Dir: "dir"}, mock.Anything).Return(projectMock, nil)
+		On("Handle", mock.Anything, &project.LoaderQuery{Dir: "dir"}, mock.Anything).Return(projectMock, nil)
 
 	loader := project.NewLoader(log.Discard, project.WithLoaderHandlers(handlerMock))
 
-	project, err := loader.Load("dir")
+	project, err := loader.Load(s.T().Context(), "dir")
 
 	s.Require().NoError(err)
 	s.Equal(projectMock, project)
@@ -42,7 +42,7 @@ func (s *LoaderSuite) TestLoadErrors() {
 	loader := project.NewLoader(log.Discard)
 
 	s.Run("NotFound", func() {
-		project, err := loader.Load("dir")
+		project, err := loader.Load(s.T().Context(), "dir")
 
 		s.Nil(project)
 		expectation.ExpectError(s.T(), errors.Expectation{
@@ -58,14 +58,14 @@ func (s *LoaderSuite) TestLoadRecursive() {
 
 	handlerMock := &project.LoaderHandlerMock{}
 	handlerMock.
-		On("Handle", &project.LoaderQuery{Dir: projectsDir}, mock.Anything).Return(projectMock, nil).
-		On("Handle", &project.LoaderQuery{Dir: filepath.Join(projectsDir, "bar")}, mock.Anything).Return(projectMock, nil).
-		On("Handle", &project.LoaderQuery{Dir: filepath.Join(projectsDir, "bar", "baz")}, mock.Anything).Return(projectMock, nil).
-		On("Handle", &project.LoaderQuery{Dir: filepath.Join(projectsDir, "foo")}, mock.Anything).Return(projectMock, nil)
+		On("Handle", mock.Anything, &project.LoaderQuery{Dir: projectsDir}, mock.Anything).Return(projectMock, nil).
+		On("Handle", mock.Anything, &project.LoaderQuery{Dir: filepath.Join(projectsDir, "bar")}, mock.Anything).Return(projectMock, nil).
+		On("Handle", mock.Anything, &project.LoaderQuery{Dir: filepath.Join(projectsDir, "bar", "baz")}, mock.Anything).Return(projectMock, nil).
+		On("Handle", mock.Anything, &project.LoaderQuery{Dir: filepath.Join(projectsDir, "foo")}, mock.Anything).Return(projectMock, nil)
 
 	loader := project.NewLoader(log.Discard, project.WithLoaderHandlers(handlerMock))
 
-	err := loader.LoadRecursive(projectsDir, func(project app.Project) error {
+	err := loader.LoadRecursive(s.T().Context(), projectsDir, func(project app.Project) error {
 		s.Equal(projectMock, project)
 
 		return nil
@@ -81,7 +81,7 @@ func (s *LoaderSuite) TestLoadRecursiveErrors() {
 
 		loader := project.NewLoader(log.Discard, project.WithLoaderHandlers(handlerMock))
 
-		err := loader.LoadRecursive("dir", func(_ app.Project) error {
+		err := loader.LoadRecursive(s.T().Context(), "dir", func(_ app.Project) error {
 			return nil
 		})
 
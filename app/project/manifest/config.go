@@ -3,4 +3,7 @@ package manifest
 type Config struct {
 	Recipe     string `yaml:"recipe"`
 	Repository string `yaml:"repository"`
+	Version    string `yaml:"version"`
+	// Repository ref resolved from a version constraint, locked for reproducible syncs
+	Ref string `yaml:"ref"`
 }
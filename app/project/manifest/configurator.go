@@ -0,0 +1,87 @@
+package manifest
+
+import (
+	"reflect"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/secret"
+	"github.com/manala/manala/internal/log"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+type Configurator struct {
+	log *log.Log
+}
+
+func NewConfigurator(log *log.Log) *Configurator {
+	return &Configurator{
+		log: log,
+	}
+}
+
+// Configure writes back to project manifest the recipe options values that changed in the given vars,
+// leaving the rest of its content, comments and layout untouched, and returns changed options.
+// Values are diffed against the project manifest own ones, falling back to recipe defaults, so that values only
+// coming from override manifests or interpolation never end up written, unless actually changed.
+func (configurator *Configurator) Configure(project app.Project, vars map[string]any) ([]app.RecipeOption, error) {
+	editor := NewEditor(configurator.log, project.Dir())
+	if err := editor.Load(); err != nil {
+		return nil, err
+	}
+
+	var changed []app.RecipeOption
+
+	for _, opt := range project.Recipe().Options() {
+		pointer, err := jsonpointer.New(opt.Pointer())
+		if err != nil {
+			return nil, err
+		}
+
+		current, _, _ := pointer.Get(project.Vars())
+		after, _, _ := pointer.Get(vars)
+
+		// Secrets are left to manual edition
+		if _, ok := current.(*secret.Secret); ok {
+			continue
+		}
+
+		// Left as is, whatever its origin
+		if reflect.DeepEqual(current, after) {
+			continue
+		}
+
+		// Project manifest own value, or recipe default one
+		before, found, err := editor.Get(opt.Pointer())
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			before, _, _ = pointer.Get(project.Recipe().Vars())
+		}
+
+		if !reflect.DeepEqual(before, after) {
+			changed = append(changed, opt)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	// Write changed values, leaving the rest of project manifest content untouched
+	for _, opt := range changed {
+		pointer, _ := jsonpointer.New(opt.Pointer())
+		value, _, _ := pointer.Get(vars)
+
+		if err := editor.Set(opt.Pointer(), value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := editor.Save(); err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,194 @@
+package manifest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manala/manala/app/project"
+	"github.com/manala/manala/app/project/manifest"
+	"github.com/manala/manala/app/recipe"
+	recipeManifest "github.com/manala/manala/app/recipe/manifest"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfiguratorSuite struct{ suite.Suite }
+
+func TestConfiguratorSuite(t *testing.T) {
+	suite.Run(t, new(ConfiguratorSuite))
+}
+
+func (s *ConfiguratorSuite) TestConfigure() {
+	repositoryURL, _ := filepath.Abs(filepath.FromSlash("testdata/ConfiguratorSuite/TestConfigure/repository"))
+
+	dir := s.T().TempDir()
+	file := filepath.Join(dir, ".manala.yaml")
+	s.Require().NoError(os.WriteFile(file, []byte(heredoc.Doc(`
+		---
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+
+
+		# Php
+		php:
+		  # Version
+		  version: "8.3"   # Current
+		  memory_limit: &memory_limit 256M
+	`, repositoryURL)), 0o644))
+
+	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
+		getter.NewFileLoaderHandler(log.Discard),
+	))
+	recipeLoader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
+		recipeManifest.NewLoaderHandler(log.Discard),
+	))
+	projectLoader := project.NewLoader(log.Discard, project.WithLoaderHandlers(
+		manifest.NewLoaderHandler(log.Discard, repositoryLoader, recipeLoader),
+	))
+
+	project, err := projectLoader.Load(s.T().Context(), dir)
+	s.Require().NoError(err)
+
+	configurator := manifest.NewConfigurator(log.Discard)
+
+	s.Run("Unchanged", func() {
+		changed, err := configurator.Configure(project, map[string]any{
+			"php":   map[string]any{"version": "8.3", "memory_limit": "256M"},
+			"debug": false,
+		})
+
+		s.Require().NoError(err)
+		s.Empty(changed)
+	})
+
+	s.Run("Changed", func() {
+		changed, err := configurator.Configure(project, map[string]any{
+			"php":   map[string]any{"version": "8.4", "memory_limit": "256M"},
+			"debug": true,
+		})
+
+		s.Require().NoError(err)
+		s.Require().Len(changed, 2)
+		s.Equal("Php version", changed[0].Label())
+		s.Equal("Debug", changed[1].Label())
+
+		heredoc.EqualFile(s.T(), `
+			---
+			manala:
+			  recipe: recipe
+			  repository: %[1]s
+
+
+			# Php
+			php:
+			  # Version
+			  version: "8.4"   # Current
+			  memory_limit: &memory_limit 256M
+			debug: true
+		`, file, repositoryURL)
+	})
+}
+
+func (s *ConfiguratorSuite) TestConfigureOverride() {
+	repositoryURL, _ := filepath.Abs(filepath.FromSlash("testdata/ConfiguratorSuite/TestConfigureOverride/repository"))
+
+	dir := s.T().TempDir()
+	file := filepath.Join(dir, ".manala.yaml")
+	s.Require().NoError(os.WriteFile(file, []byte(heredoc.Doc(`
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+
+		php:
+		  version: "8.3"
+	`, repositoryURL)), 0o644))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, ".manala.local.yaml"), []byte(heredoc.Doc(`
+		php:
+		  version: "8.4"
+		debug: true
+	`)), 0o644))
+
+	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
+		getter.NewFileLoaderHandler(log.Discard),
+	))
+	recipeLoader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
+		recipeManifest.NewLoaderHandler(log.Discard),
+	))
+	projectLoader := project.NewLoader(log.Discard, project.WithLoaderHandlers(
+		manifest.NewLoaderHandler(log.Discard, repositoryLoader, recipeLoader),
+	))
+
+	project, err := projectLoader.Load(s.T().Context(), dir)
+	s.Require().NoError(err)
+
+	configurator := manifest.NewConfigurator(log.Discard)
+
+	// Overridden values, either left as is, or set back to project manifest own ones, are never written
+	changed, err := configurator.Configure(project, map[string]any{
+		"php":   map[string]any{"version": "8.3", "memory_limit": "128M"},
+		"debug": true,
+	})
+
+	s.Require().NoError(err)
+	s.Empty(changed)
+
+	heredoc.EqualFile(s.T(), `
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+
+		php:
+		  version: "8.3"
+	`, file, repositoryURL)
+}
+
+func (s *ConfiguratorSuite) TestConfigureSecret() {
+	repositoryURL, _ := filepath.Abs(filepath.FromSlash("testdata/ConfiguratorSuite/TestConfigureSecret/repository"))
+
+	dir := s.T().TempDir()
+	file := filepath.Join(dir, ".manala.yaml")
+	s.Require().NoError(os.WriteFile(file, []byte(heredoc.Doc(`
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+
+		password: !secret file:.secrets/password
+	`, repositoryURL)), 0o644))
+
+	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
+		getter.NewFileLoaderHandler(log.Discard),
+	))
+	recipeLoader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
+		recipeManifest.NewLoaderHandler(log.Discard),
+	))
+	projectLoader := project.NewLoader(log.Discard, project.WithLoaderHandlers(
+		manifest.NewLoaderHandler(log.Discard, repositoryLoader, recipeLoader),
+	))
+
+	project, err := projectLoader.Load(s.T().Context(), dir)
+	s.Require().NoError(err)
+
+	configurator := manifest.NewConfigurator(log.Discard)
+
+	// Secrets are left to manual edition
+	changed, err := configurator.Configure(project, map[string]any{
+		"password": "",
+	})
+
+	s.Require().NoError(err)
+	s.Empty(changed)
+
+	heredoc.EqualFile(s.T(), `
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+
+		password: !secret file:.secrets/password
+	`, file, repositoryURL)
+}
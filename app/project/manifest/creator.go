@@ -11,6 +11,8 @@ import (
 	"github.com/manala/manala/app/template"
 	"github.com/manala/manala/internal/errors/serror"
 	"github.com/manala/manala/internal/errors/std"
+	yamlmapping "github.com/manala/manala/internal/yaml/mapping"
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
 )
 
 //go:embed template.yaml.tmpl
@@ -50,6 +52,46 @@ func (creator *Creator) Create(dir string, recipe app.Recipe, vars map[string]an
 		}
 	}
 
+	// Record recipe version, so that only newer vars migrations would apply,
+	// and lock repository ref resolved from a version constraint, for reproducible syncs
+	version := recipe.Version()
+	ref := ""
+	if repository := recipe.Repository(); repository.Constraint() != "" {
+		ref = repository.Ref()
+	}
+
+	if version != "" || ref != "" {
+		node, err := yamlparser.ParseRaw(buffer.Bytes())
+		if err != nil {
+			return nil, serror.New("unable to parse project manifest").
+				WithErr(err)
+		}
+
+		if version != "" {
+			if err := yamlmapping.Set(node, "/manala/version", version); err != nil {
+				return nil, serror.New("unable to record project manifest version").
+					WithErr(err)
+			}
+		}
+
+		if ref != "" {
+			if err := yamlmapping.Set(node, "/manala/ref", ref); err != nil {
+				return nil, serror.New("unable to lock project manifest repository ref").
+					WithErr(err)
+			}
+		}
+
+		// Patch only recorded lines, leaving template layout untouched
+		content, err := yamlmapping.Patch(buffer.Bytes(), node)
+		if err != nil {
+			return nil, serror.New("unable to patch project manifest").
+				WithErr(err)
+		}
+
+		buffer.Reset()
+		buffer.Write(content)
+	}
+
 	manifestFile := filepath.Join(dir, filename)
 
 	// Ensure directory exists
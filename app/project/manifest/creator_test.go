@@ -32,12 +32,12 @@ func (s *CreatorSuite) TestCreateErrors() {
 	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
 		getter.NewFileLoaderHandler(log.Discard),
 	))
-	repository, _ := repositoryLoader.Load(repositoryURL)
+	repository, _ := repositoryLoader.Load(s.T().Context(), repositoryURL)
 
 	recipeLoader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
 		recipeManifest.NewLoaderHandler(log.Discard),
 	))
-	recipe, _ := recipeLoader.Load(repository, recipeName)
+	recipe, _ := recipeLoader.Load(s.T().Context(), repository, recipeName)
 
 	s.Run("File", func() {
 		projectDir := filepath.FromSlash("testdata/CreatorSuite/TestCreateErrors/File/project")
@@ -66,12 +66,12 @@ func (s *CreatorSuite) TestCreate() {
 	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
 		getter.NewFileLoaderHandler(log.Discard),
 	))
-	repository, _ := repositoryLoader.Load(repositoryURL)
+	repository, _ := repositoryLoader.Load(s.T().Context(), repositoryURL)
 
 	recipeLoader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
 		recipeManifest.NewLoaderHandler(log.Discard),
 	))
-	recipe, _ := recipeLoader.Load(repository, recipeName)
+	recipe, _ := recipeLoader.Load(s.T().Context(), repository, recipeName)
 
 	vars := recipe.Vars()
 	vars["string_float_int"] = "3.0"
@@ -94,3 +94,39 @@ func (s *CreatorSuite) TestCreate() {
 		string_asterisk_value: '*'
 	`, filepath.Join(projectDir, ".manala.yaml"))
 }
+
+func (s *CreatorSuite) TestCreateVersion() {
+	repositoryURL := filepath.FromSlash("testdata/CreatorSuite/TestCreateVersion/repository")
+	recipeName := "recipe"
+
+	projectDir := s.T().TempDir()
+
+	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
+		getter.NewFileLoaderHandler(log.Discard),
+	))
+	repository, _ := repositoryLoader.Load(s.T().Context(), repositoryURL)
+
+	recipeLoader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
+		recipeManifest.NewLoaderHandler(log.Discard),
+	))
+	recipe, _ := recipeLoader.Load(s.T().Context(), repository, recipeName)
+
+	creator := manifest.NewCreator(template.NewEngine())
+	project, err := creator.Create(projectDir, recipe, recipe.Vars())
+
+	s.Require().NoError(err)
+	s.NotNil(project)
+
+	// Template layout left untouched
+	heredoc.EqualFile(s.T(), `
+		---
+		manala:
+		    recipe: recipe
+		    repository: %[1]s
+		    version: 2.0.0
+
+
+		# Foo
+		foo: bar   # Default
+	`, filepath.Join(projectDir, ".manala.yaml"), repositoryURL)
+}
@@ -0,0 +1,154 @@
+package manifest
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/errors/source"
+	"github.com/manala/manala/internal/errors/std"
+	"github.com/manala/manala/internal/log"
+	yamlerrors "github.com/manala/manala/internal/yaml/errors"
+	yamlmapping "github.com/manala/manala/internal/yaml/mapping"
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+)
+
+// Editor edits a project manifest in place, values being addressed by json pointers,
+// while preserving its comments, anchors and formatting.
+type Editor struct {
+	log     *log.Log
+	dir     string
+	file    string
+	content []byte
+	node    *ast.MappingNode
+}
+
+func NewEditor(log *log.Log, dir string) *Editor {
+	return &Editor{
+		log:  log,
+		dir:  dir,
+		file: filepath.Join(dir, filename),
+	}
+}
+
+// Load reads and parses project manifest, leaving anchors and aliases untouched.
+func (editor *Editor) Load() error {
+	content, err := os.ReadFile(editor.file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &app.NotFoundProjectError{Dir: editor.dir}
+		}
+
+		return serror.New("unable to read project manifest").
+			With("file", editor.file).
+			WithErr(std.From(err))
+	}
+
+	node, err := yamlparser.ParseRaw(content)
+	if err != nil {
+		return serror.New("unable to parse project manifest").
+			WithErr(source.From(err, editor.origin(content)))
+	}
+
+	editor.content = content
+	editor.node = node
+
+	return nil
+}
+
+// Get returns the value found at the given json pointer, aliases being resolved.
+func (editor *Editor) Get(pointer string) (any, bool, error) {
+	content := []byte(editor.node.String())
+
+	// Parse content again, resolving anchors and aliases
+	node, err := yamlparser.Parse(content)
+	if err != nil {
+		return nil, false, serror.New("unable to parse project manifest").
+			WithErr(source.From(err, editor.origin(content)))
+	}
+
+	var values map[string]any
+	if err := yaml.NodeToValue(node, &values); err != nil {
+		return nil, false, serror.New("unable to decode project manifest").
+			WithErr(source.From(yamlerrors.From(err), editor.origin(content)))
+	}
+
+	jsonPointer, err := jsonpointer.New(pointer)
+	if err != nil {
+		return nil, false, serror.New("invalid project manifest path").
+			With("path", pointer).
+			WithErr(err)
+	}
+
+	value, _, err := jsonPointer.Get(values)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	return value, true, nil
+}
+
+// Set sets a value at the given json pointer, creating missing intermediate mappings,
+// and overriding existing value.
+func (editor *Editor) Set(pointer string, value any) error {
+	editor.log.Debug("set project manifest value", "file", editor.file, "path", pointer)
+
+	if err := yamlmapping.Set(editor.node, pointer, value); err != nil {
+		return serror.New("unable to set project manifest value").
+			With("path", pointer).
+			WithErr(err)
+	}
+
+	return nil
+}
+
+// Delete removes the value found at the given json pointer.
+func (editor *Editor) Delete(pointer string) bool {
+	editor.log.Debug("delete project manifest value", "file", editor.file, "path", pointer)
+
+	_, ok := yamlmapping.Delete(editor.node, pointer)
+
+	return ok
+}
+
+// Save writes edited project manifest, only changed values lines being rewritten.
+func (editor *Editor) Save() error {
+	content, err := yamlmapping.Patch(editor.content, editor.node)
+	if err != nil {
+		return serror.New("unable to patch project manifest").
+			WithErr(source.From(err, editor.origin(editor.content)))
+	}
+
+	return editor.write(content)
+}
+
+// Revert writes back project manifest, as it was when loaded.
+func (editor *Editor) Revert() error {
+	return editor.write(editor.content)
+}
+
+func (editor *Editor) write(content []byte) error {
+	if err := os.WriteFile(editor.file, content, 0o666); err != nil {
+		return serror.New("unable to save project manifest file").
+			With("file", editor.file).
+			WithErr(std.From(err))
+	}
+
+	return nil
+}
+
+// origin prepares source error origin.
+func (editor *Editor) origin(content []byte) source.Origin {
+	return source.Origin{
+		File:     editor.file,
+		Source:   string(content),
+		Language: "yaml",
+	}
+}
@@ -0,0 +1,173 @@
+package manifest_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/project/manifest"
+	"github.com/manala/manala/app/testing/errors"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EditorSuite struct{ suite.Suite }
+
+func TestEditorSuite(t *testing.T) {
+	suite.Run(t, new(EditorSuite))
+}
+
+const editorSrc = `# Project
+manala:
+  recipe: foo # Recipe
+
+# Defaults
+defaults: &defaults
+  bar: bar
+baz:
+  <<: *defaults
+  qux: qux # Qux
+`
+
+func (s *EditorSuite) TestGet() {
+	dir := s.project(editorSrc)
+
+	editor := manifest.NewEditor(log.Discard, dir)
+	s.Require().NoError(editor.Load())
+
+	value, ok, err := editor.Get("/manala/recipe")
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal("foo", value)
+
+	value, ok, err = editor.Get("/baz/bar")
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal("bar", value)
+
+	_, ok, err = editor.Get("/missing")
+	s.Require().NoError(err)
+	s.False(ok)
+}
+
+func (s *EditorSuite) TestSet() {
+	dir := s.project(editorSrc)
+
+	editor := manifest.NewEditor(log.Discard, dir)
+	s.Require().NoError(editor.Load())
+
+	s.Require().NoError(editor.Set("/manala/recipe", "bar"))
+	s.Require().NoError(editor.Set("/baz/qux", 123))
+	s.Require().NoError(editor.Set("/quux/corge", true))
+	s.Require().NoError(editor.Save())
+
+	heredoc.EqualFile(s.T(), `
+		# Project
+		manala:
+		  recipe: bar # Recipe
+
+		# Defaults
+		defaults: &defaults
+		  bar: bar
+		baz:
+		  <<: *defaults
+		  qux: 123 # Qux
+		quux:
+		  corge: true
+	`, filepath.Join(dir, ".manala.yaml"))
+}
+
+func (s *EditorSuite) TestSetInPlace() {
+	src := heredoc.Doc(`
+		---
+		# Project
+		manala:
+		    recipe: foo    # Recipe
+
+
+		# Defaults
+		defaults: &defaults
+		    bar: bar
+		baz:
+		    <<: *defaults
+		    qux: &qux qux   # Qux
+		quux: *qux
+	`)
+
+	dir := s.project(src)
+
+	editor := manifest.NewEditor(log.Discard, dir)
+	s.Require().NoError(editor.Load())
+
+	s.Require().NoError(editor.Set("/manala/recipe", "bar"))
+	s.Require().NoError(editor.Save())
+
+	// Sole changed line
+	content, _ := os.ReadFile(filepath.Join(dir, ".manala.yaml"))
+	s.Equal(
+		strings.Replace(src, "    recipe: foo    # Recipe\n", "    recipe: bar    # Recipe\n", 1),
+		string(content),
+	)
+}
+
+func (s *EditorSuite) TestDelete() {
+	dir := s.project(editorSrc)
+
+	editor := manifest.NewEditor(log.Discard, dir)
+	s.Require().NoError(editor.Load())
+
+	s.True(editor.Delete("/baz/qux"))
+	s.False(editor.Delete("/missing"))
+	s.Require().NoError(editor.Save())
+
+	heredoc.EqualFile(s.T(), `
+		# Project
+		manala:
+		  recipe: foo # Recipe
+
+		# Defaults
+		defaults: &defaults
+		  bar: bar
+		baz:
+		  <<: *defaults
+	`, filepath.Join(dir, ".manala.yaml"))
+}
+
+func (s *EditorSuite) TestRevert() {
+	dir := s.project(editorSrc)
+
+	editor := manifest.NewEditor(log.Discard, dir)
+	s.Require().NoError(editor.Load())
+
+	s.Require().NoError(editor.Set("/manala/recipe", "bar"))
+	s.Require().NoError(editor.Save())
+	s.Require().NoError(editor.Revert())
+
+	content, _ := os.ReadFile(filepath.Join(dir, ".manala.yaml"))
+	s.Equal(editorSrc, string(content))
+}
+
+func (s *EditorSuite) TestLoadErrors() {
+	dir := s.T().TempDir()
+
+	editor := manifest.NewEditor(log.Discard, dir)
+	err := editor.Load()
+
+	expectation.ExpectError(s.T(), errors.Expectation{
+		Type:  &app.NotFoundProjectError{},
+		Attrs: [][2]any{{"dir", dir}},
+	}, err)
+}
+
+// project creates a temporary project dir, along with its manifest.
+func (s *EditorSuite) project(content string) string {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, ".manala.yaml"), []byte(content), 0o644))
+
+	return dir
+}
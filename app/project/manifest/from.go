@@ -1,6 +1,7 @@
 package manifest
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -23,7 +24,7 @@ func NewFromLoaderHandler(log *log.Log) *FromLoaderHandler {
 	}
 }
 
-func (handler *FromLoaderHandler) Handle(query *project.LoaderQuery, chain project.LoaderHandlerChain) (app.Project, error) {
+func (handler *FromLoaderHandler) Handle(ctx context.Context, query *project.LoaderQuery, chain project.LoaderHandlerChain) (app.Project, error) {
 	dir := query.Dir
 
 	handler.log.Debug("handle project manifest from", "handler", "manifest.from", "dir", dir)
@@ -58,7 +59,7 @@ func (handler *FromLoaderHandler) Handle(query *project.LoaderQuery, chain proje
 			query.Dir = path
 
 			// Load project
-			project, err = chain.Next(query)
+			project, err = chain.Next(ctx, query)
 			if err != nil {
 				if _, ok := errors.AsType[*app.NotFoundProjectError](err); ok {
 					return nil
@@ -77,7 +78,7 @@ func (handler *FromLoaderHandler) Handle(query *project.LoaderQuery, chain proje
 	if project == nil {
 		query.Dir = dir
 
-		return chain.Last(query)
+		return chain.Last(ctx, query)
 	}
 
 	return project, nil
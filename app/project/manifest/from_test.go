@@ -12,6 +12,7 @@ import (
 	"github.com/manala/manala/internal/log"
 	"github.com/manala/manala/internal/testing/expectation"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -64,9 +65,9 @@ func (s *FromSuite) handle(dir string) (app.Project, error) {
 
 	chainMock := &project.LoaderHandlerChainMock{}
 	chainMock.
-		On("Next", query).Return(projectMock, nil)
+		On("Next", mock.Anything, query).Return(projectMock, nil)
 
 	handler := manifest.NewFromLoaderHandler(log.Discard)
 
-	return handler.Handle(query, chainMock)
+	return handler.Handle(s.T().Context(), query, chainMock)
 }
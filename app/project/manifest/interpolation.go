@@ -0,0 +1,144 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	yamlerrors "github.com/manala/manala/internal/yaml/errors"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/token"
+)
+
+// interpolationRegex matches "${VAR}" and "${VAR:-default}" references, along with "$${" escapes.
+var interpolationRegex = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolateVars replaces environment variables references in vars string values, located against their node.
+// Interpolated plain scalars are decoded back, just like if their references were textually replaced,
+// so that "port: ${PORT}" still results in an integer.
+func interpolateVars(vars map[string]any, node ast.Node) (map[string]any, error) {
+	value, err := interpolateValue(vars, node, "")
+	if err != nil {
+		return nil, err
+	}
+
+	vars, _ = value.(map[string]any)
+
+	return vars, nil
+}
+
+func interpolateValue(value any, node ast.Node, pointer string) (any, error) {
+	switch value := value.(type) {
+	case map[string]any:
+		var errs []error
+
+		for _, key := range slices.Sorted(maps.Keys(value)) {
+			v, err := interpolateValue(value[key], node, pointer+"/"+jsonpointer.Escape(key))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			value[key] = v
+		}
+
+		return value, errors.Join(errs...)
+	case []any:
+		var errs []error
+
+		for i := range value {
+			v, err := interpolateValue(value[i], node, fmt.Sprintf("%s/%d", pointer, i))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			value[i] = v
+		}
+
+		return value, errors.Join(errs...)
+	case string:
+		if !strings.Contains(value, "${") {
+			return value, nil
+		}
+
+		return interpolateString(value, interpolationNode(node, pointer))
+	}
+
+	return value, nil
+}
+
+func interpolateString(value string, node ast.Node) (any, error) {
+	var tkn *token.Token
+	if node != nil {
+		tkn = node.GetToken()
+	}
+
+	var errs []error
+
+	interpolated := interpolationRegex.ReplaceAllStringFunc(value, func(match string) string {
+		// Escape
+		if match == "$${" {
+			return "${"
+		}
+
+		submatches := interpolationRegex.FindStringSubmatch(match)
+		name, hasDefault, def := submatches[1], submatches[2] != "", submatches[3]
+
+		env, ok := os.LookupEnv(name)
+		if hasDefault && env == "" {
+			return def
+		}
+		if !ok {
+			errs = append(errs, yamlerrors.New(
+				fmt.Errorf("undefined environment variable '%s'", name),
+				tkn,
+			))
+		}
+
+		return env
+	})
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// Quoted scalars remain strings
+	if tkn == nil || tkn.Type != token.StringType {
+		return interpolated, nil
+	}
+
+	// Plain scalars are decoded back, as long as they remain scalars
+	var decoded any
+	if err := yaml.Unmarshal([]byte(interpolated), &decoded); err != nil {
+		return interpolated, nil
+	}
+
+	switch decoded.(type) {
+	case map[string]any, []any:
+		return interpolated, nil
+	}
+
+	return decoded, nil
+}
+
+// interpolationNode returns the node found at the JSON pointer (RFC 6901), if any.
+func interpolationNode(node ast.Node, pointer string) ast.Node {
+	path, err := yaml.PathString(yamlpath.FromJSONPointer(pointer))
+	if err != nil {
+		return nil
+	}
+
+	n, err := path.FilterNode(node)
+	if err != nil {
+		return nil
+	}
+
+	return n
+}
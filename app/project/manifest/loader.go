@@ -1,14 +1,19 @@
 package manifest
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/migration"
 	"github.com/manala/manala/app/project"
 	"github.com/manala/manala/app/recipe"
 	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/app/secret"
 	"github.com/manala/manala/internal/errors/serror"
 	"github.com/manala/manala/internal/errors/source"
 	"github.com/manala/manala/internal/errors/std"
@@ -20,10 +25,15 @@ import (
 	yamlvalidation "github.com/manala/manala/internal/yaml/validation"
 
 	"dario.cat/mergo"
+	"github.com/Masterminds/semver/v3"
 	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
 )
 
-const filename = ".manala.yaml"
+const (
+	filename      = ".manala.yaml"
+	localFilename = ".manala.local.yaml"
+)
 
 var manifestValidator = validation.MustNewValidator(map[string]any{
 	"type": "object",
@@ -33,6 +43,8 @@ var manifestValidator = validation.MustNewValidator(map[string]any{
 			"properties": map[string]any{
 				"recipe":     map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
 				"repository": map[string]any{"type": "string", "minLength": 1, "maxLength": 256},
+				"version":    map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+				"ref":        map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
 			},
 			"additionalProperties": false,
 			"required":             []any{"recipe"},
@@ -45,17 +57,50 @@ type LoaderHandler struct {
 	log              *log.Log
 	repositoryLoader *repository.Loader
 	recipeLoader     *recipe.Loader
+	migrate          bool
+	lock             bool
+	env              string
 }
 
-func NewLoaderHandler(log *log.Log, repositoryLoader *repository.Loader, recipeLoader *recipe.Loader) *LoaderHandler {
-	return &LoaderHandler{
+func NewLoaderHandler(log *log.Log, repositoryLoader *repository.Loader, recipeLoader *recipe.Loader, opts ...LoaderHandlerOption) *LoaderHandler {
+	handler := &LoaderHandler{
 		log:              log,
 		repositoryLoader: repositoryLoader,
 		recipeLoader:     recipeLoader,
 	}
+
+	// Options
+	for _, opt := range opts {
+		opt(handler)
+	}
+
+	return handler
+}
+
+type LoaderHandlerOption func(handler *LoaderHandler)
+
+// WithMigrate applies pending recipe vars migrations to project manifests, saved once validated.
+func WithMigrate(migrate bool) LoaderHandlerOption {
+	return func(handler *LoaderHandler) {
+		handler.migrate = migrate
+	}
+}
+
+// WithLock locks repository refs resolved from version constraints into project manifests, saved once validated.
+func WithLock(lock bool) LoaderHandlerOption {
+	return func(handler *LoaderHandler) {
+		handler.lock = lock
+	}
 }
 
-func (handler *LoaderHandler) Handle(query *project.LoaderQuery, chain project.LoaderHandlerChain) (app.Project, error) {
+// WithEnv merges the ".manala.<env>.yaml" override manifest, if any, over project manifest vars.
+func WithEnv(env string) LoaderHandlerOption {
+	return func(handler *LoaderHandler) {
+		handler.env = env
+	}
+}
+
+func (handler *LoaderHandler) Handle(ctx context.Context, query *project.LoaderQuery, chain project.LoaderHandlerChain) (app.Project, error) {
 	dir := query.Dir
 	file := filepath.Join(dir, filename)
 
@@ -65,7 +110,7 @@ func (handler *LoaderHandler) Handle(query *project.LoaderQuery, chain project.L
 	if fileInfo, err := os.Stat(file); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			// Chain
-			return chain.Next(query)
+			return chain.Next(ctx, query)
 		}
 
 		return nil, serror.New("unable to stat project manifest").
@@ -97,7 +142,7 @@ func (handler *LoaderHandler) Handle(query *project.LoaderQuery, chain project.L
 	}
 
 	// Parse content
-	node, err := yamlparser.Parse(content)
+	node, err := yamlparser.Parse(content, yamlparser.WithTags(secret.Tag))
 	if err != nil {
 		return nil, serror.New("unable to parse project manifest").
 			WithErr(source.From(err, origin))
@@ -140,18 +185,48 @@ func (handler *LoaderHandler) Handle(query *project.LoaderQuery, chain project.L
 		"recipe", config.Recipe,
 	)
 
+	// Let repository know about its recipe, unless explicitly set
+	if _, ok := app.RecipeName(ctx); !ok {
+		ctx = app.WithRecipeName(ctx, config.Recipe)
+	}
+
+	// Reuse locked repository ref
+	ctx = app.WithRepositoryLockedRef(ctx, config.Ref)
+
 	// Load repository
-	repository, err := handler.repositoryLoader.Load(config.Repository)
+	repository, err := handler.repositoryLoader.Load(ctx, config.Repository)
 	if err != nil {
 		return nil, err
 	}
 
 	// Load recipe
-	project.recipe, err = handler.recipeLoader.Load(repository, config.Recipe)
+	project.recipe, err = handler.recipeLoader.Load(ctx, repository, config.Recipe)
 	if err != nil {
 		return nil, err
 	}
 
+	// Migrate vars
+	var migrated []byte
+	var migratedVersion string
+	if handler.migrate {
+		migrated, migratedVersion, err = handler.migrateVars(file, content, config.Version, project.recipe)
+		if err != nil {
+			return nil, err
+		}
+
+		if migrated != nil {
+			origin.Source = string(migrated)
+
+			// Parse migrated content
+			if node, err = yamlparser.Parse(migrated, yamlparser.WithTags(secret.Tag)); err != nil {
+				return nil, serror.New("unable to parse migrated project manifest").
+					WithErr(source.From(err, origin))
+			}
+
+			_, _ = yamlmapping.Pop(node, "manala")
+		}
+	}
+
 	// Decode vars
 	var vars map[string]any
 	if err := yaml.NodeToValue(node, &vars); err != nil {
@@ -159,23 +234,324 @@ func (handler *LoaderHandler) Handle(query *project.LoaderQuery, chain project.L
 			WithErr(source.From(yamlerrors.From(err), origin))
 	}
 
+	// Interpolate vars
+	vars, err = interpolateVars(vars, node)
+	if err != nil {
+		return nil, serror.New("unable to interpolate project manifest vars").
+			WithErr(source.From(err, origin))
+	}
+
+	// Secrets
+	vars, err = secretVars(vars, node)
+	if err != nil {
+		return nil, serror.New("invalid project manifest secrets").
+			WithErr(source.From(err, origin))
+	}
+
 	// Merge vars
 	_ = mergo.Merge(&project.vars, project.recipe.Vars())
 	_ = mergo.Merge(&project.vars, vars, mergo.WithOverride)
 
-	// Validate vars
+	// Vars sources, in merge order
+	sources := []varsSource{{node: node, origin: origin}}
+
+	// Merge override manifests vars
+	for _, overrideFile := range handler.overrideFiles(dir) {
+		overrideSource, overrideVars, err := handler.loadOverride(overrideFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if overrideSource == nil {
+			continue
+		}
+
+		_ = mergo.Merge(&project.vars, overrideVars, mergo.WithOverride)
+
+		sources = append(sources, *overrideSource)
+		project.overrides = append(project.overrides, overrideFile)
+	}
+
+	// Validate vars, secrets only being required, as their values are resolved at sync time
 	validator, err := validation.NewValidator(project.recipe.Schema())
 	if err != nil {
 		return nil, err
 	}
-	if err := validator.Validate(project.vars, yamlvalidation.WithLocator(node)); err != nil {
-		if violations, ok := errors.AsType[validation.Violations](err); ok {
+	validatedVars := secret.References(project.vars)
+	skippedSecrets := validation.WithSkippedValues(secret.Pointers(project.vars)...)
+	if err := validator.Validate(validatedVars, skippedSecrets); err != nil {
+		if _, ok := errors.AsType[validation.Violations](err); ok {
 			return nil, serror.New("invalid project manifest vars").
-				WithErr(source.From(violations, origin))
+				WithErr(locateViolations(validator, validatedVars, sources, skippedSecrets))
 		}
 		return nil, serror.New("unable to validate project manifest vars").
 			With("file", file).WithErr(err)
 	}
 
+	// Lock repository ref resolved from a version constraint, so that next loads get the very same one
+	saved := migrated
+	locked := handler.lock && repository.Constraint() != "" && repository.Ref() != config.Ref
+	if locked {
+		if saved == nil {
+			saved = content
+		}
+
+		if saved, err = handler.lockRef(file, saved, repository.Ref()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Save migrated vars and locked ref, only once validated
+	if saved != nil {
+		if err := os.WriteFile(file, saved, 0o666); err != nil {
+			return nil, serror.New("unable to save project manifest file").
+				With("file", file).
+				WithErr(std.From(err))
+		}
+	}
+
+	if migrated != nil {
+		if config.Version == "" {
+			handler.log.Info("project manifest version recorded", "file", file, "version", migratedVersion)
+		} else {
+			handler.log.Info("project manifest vars migrated", "file", file, "version", migratedVersion)
+		}
+	}
+
+	if locked {
+		handler.log.Info("project manifest repository ref locked", "file", file, "ref", repository.Ref())
+	}
+
 	return project, nil
 }
+
+// overrideFiles returns project override manifests files, in merge order: env one first, then local one.
+func (handler *LoaderHandler) overrideFiles(dir string) []string {
+	var files []string
+
+	if handler.env != "" {
+		files = append(files, filepath.Join(dir, ".manala."+handler.env+".yaml"))
+	}
+
+	return append(files, filepath.Join(dir, localFilename))
+}
+
+// loadOverride loads vars of an override manifest file, along with their source.
+// A nil source is returned if the file does not exist.
+func (handler *LoaderHandler) loadOverride(file string) (*varsSource, map[string]any, error) {
+	// Stat file
+	if fileInfo, err := os.Stat(file); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, serror.New("unable to stat project override manifest").
+			With("file", file).
+			WithErr(std.From(err))
+	} else if fileInfo.IsDir() {
+		return nil, nil, serror.New("project override manifest is a directory").
+			With("dir", file)
+	}
+
+	// Read file
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, serror.New("unable to read project override manifest").
+			With("file", file).
+			WithErr(std.From(err))
+	}
+
+	// Prepare source error origin
+	origin := source.Origin{
+		File:     file,
+		Source:   string(content),
+		Language: "yaml",
+	}
+
+	// Parse content
+	node, err := yamlparser.Parse(content, yamlparser.WithTags(secret.Tag))
+	if err != nil {
+		return nil, nil, serror.New("unable to parse project override manifest").
+			WithErr(source.From(err, origin))
+	}
+
+	// Config belongs to project manifest only
+	if _, found := yamlmapping.Pop(node, "manala"); found {
+		return nil, nil, serror.New("project override manifest must not hold config").
+			With("file", file)
+	}
+
+	// Decode vars
+	var vars map[string]any
+	if err := yaml.NodeToValue(node, &vars); err != nil {
+		return nil, nil, serror.New("unable to decode project override manifest vars").
+			WithErr(source.From(yamlerrors.From(err), origin))
+	}
+
+	// Interpolate vars
+	vars, err = interpolateVars(vars, node)
+	if err != nil {
+		return nil, nil, serror.New("unable to interpolate project override manifest vars").
+			WithErr(source.From(err, origin))
+	}
+
+	// Secrets
+	vars, err = secretVars(vars, node)
+	if err != nil {
+		return nil, nil, serror.New("invalid project override manifest secrets").
+			WithErr(source.From(err, origin))
+	}
+
+	handler.log.Debug("project override manifest loaded", "handler", "manifest",
+		"file", file,
+	)
+
+	return &varsSource{node: node, origin: origin}, vars, nil
+}
+
+// varsSource is a parsed manifest that vars were merged from.
+type varsSource struct {
+	node   ast.Node
+	origin source.Origin
+}
+
+// locateViolations reports vars violations against the source that introduced their values,
+// by validating vars once per source, from the last merged one. Violations no override locates
+// are reported against the project manifest.
+func locateViolations(validator *validation.Validator, vars any, sources []varsSource, opts ...validation.ValidateOption) error {
+	var errs []error
+
+	located := map[int]bool{}
+	for i := len(sources) - 1; i >= 0; i-- {
+		err := validator.Validate(vars, append(opts, yamlvalidation.WithLocator(sources[i].node))...)
+		violations, _ := errors.AsType[validation.Violations](err)
+
+		var sourceViolations validation.Violations
+		for j, violation := range violations {
+			if located[j] {
+				continue
+			}
+			if line, _ := violation.Position(); line == 0 && i > 0 {
+				continue
+			}
+
+			located[j] = true
+			sourceViolations = append(sourceViolations, violation)
+		}
+
+		if len(sourceViolations) > 0 {
+			errs = append(errs, source.From(sourceViolations, sources[i].origin))
+		}
+	}
+
+	// Sources merge order
+	slices.Reverse(errs)
+
+	return errors.Join(errs...)
+}
+
+// lockRef records a repository ref into the project manifest content, only its line being patched.
+func (handler *LoaderHandler) lockRef(file string, content []byte, ref string) ([]byte, error) {
+	// Parse content, leaving anchors and aliases untouched
+	node, err := yamlparser.ParseRaw(content)
+	if err != nil {
+		return nil, serror.New("unable to parse project manifest").
+			WithErr(err)
+	}
+
+	if err := yamlmapping.Set(node, "/manala/ref", ref); err != nil {
+		return nil, serror.New("unable to lock project manifest repository ref").
+			With("file", file).
+			WithErr(err)
+	}
+
+	locked, err := yamlmapping.Patch(content, node)
+	if err != nil {
+		return nil, serror.New("unable to patch project manifest").
+			With("file", file).
+			WithErr(err)
+	}
+
+	return locked, nil
+}
+
+// migrateVars applies recipe migrations newer than project version, up to recipe one, to the project manifest content,
+// and records the reached version. Projects without any recorded version predate migrations: none is applied, only the
+// recipe version being recorded as their baseline. Migrated content is returned along with its version, or nil if there
+// was nothing to migrate.
+func (handler *LoaderHandler) migrateVars(file string, content []byte, version string, recipe app.Recipe) ([]byte, string, error) {
+	var from, to *semver.Version
+
+	if version != "" {
+		var err error
+		if from, err = semver.NewVersion(version); err != nil {
+			return nil, "", serror.New("invalid project version").
+				With("file", file, "version", version)
+		}
+	}
+
+	if recipe.Version() != "" {
+		to, _ = semver.NewVersion(recipe.Version())
+	}
+
+	// Pending migrations
+	var pending []*migration.Migration
+	if from != nil {
+		for _, recipeMigration := range recipe.Migrations() {
+			migrationVersion, _ := semver.NewVersion(recipeMigration.Version)
+			if !migrationVersion.GreaterThan(from) {
+				continue
+			}
+			if to != nil && migrationVersion.GreaterThan(to) {
+				continue
+			}
+
+			pending = append(pending, recipeMigration)
+		}
+	}
+
+	// Reached version
+	var reached string
+	switch {
+	case from == nil:
+		reached = recipe.Version()
+	case len(pending) > 0:
+		reached = cmp.Or(recipe.Version(), pending[len(pending)-1].Version)
+	}
+
+	if reached == "" {
+		return nil, "", nil
+	}
+
+	// Parse content, leaving anchors and aliases untouched
+	node, err := yamlparser.ParseRaw(content)
+	if err != nil {
+		return nil, "", serror.New("unable to parse project manifest").
+			WithErr(err)
+	}
+
+	for _, pendingMigration := range pending {
+		if err := pendingMigration.Apply(handler.log, node); err != nil {
+			return nil, "", serror.New("unable to migrate project manifest vars").
+				With("file", file, "version", pendingMigration.Version).
+				WithErr(err)
+		}
+	}
+
+	// Record reached version
+	if err := yamlmapping.Set(node, "/manala/version", reached); err != nil {
+		return nil, "", serror.New("unable to record project manifest version").
+			With("file", file).
+			WithErr(err)
+	}
+
+	// Patch only changed lines
+	migrated, err := yamlmapping.Patch(content, node)
+	if err != nil {
+		return nil, "", serror.New("unable to patch project manifest").
+			With("file", file).
+			WithErr(err)
+	}
+
+	return migrated, reached, nil
+}
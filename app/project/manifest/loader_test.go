@@ -1,6 +1,8 @@
 package manifest_test
 
 import (
+	"context"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -11,12 +13,15 @@ import (
 	recipeManifest "github.com/manala/manala/app/recipe/manifest"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/secret"
+	"github.com/manala/manala/app/testing/mocks"
 	"github.com/manala/manala/internal/errors/serror/serrortest"
 	"github.com/manala/manala/internal/errors/source/sourcetest"
 	"github.com/manala/manala/internal/log"
 	"github.com/manala/manala/internal/testing/expectation"
 	"github.com/manala/manala/internal/testing/heredoc"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -37,6 +42,373 @@ func (s *LoaderSuite) TestHandle() {
 	s.Equal(map[string]any{"foo": "baz"}, project.Vars())
 }
 
+func (s *LoaderSuite) TestHandleMigrate() {
+	repositoryURL, _ := filepath.Abs(filepath.FromSlash("testdata/LoaderSuite/TestHandleMigrate/repository"))
+
+	projectDir := s.T().TempDir()
+	projectFile := filepath.Join(projectDir, ".manala.yaml")
+
+	s.Require().NoError(os.WriteFile(projectFile, []byte(heredoc.Doc(`
+		---
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+		  version: 1.0.0   # Migrated
+
+
+		# Php version
+		php: 8.4 # Latest
+	`, repositoryURL)), 0o644))
+
+	project, err := s.handle(projectDir, manifest.WithMigrate(true))
+
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"php": map[string]any{"version": 8.4},
+	}, project.Vars())
+
+	heredoc.EqualFile(s.T(), `
+		---
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+		  version: 2.0.0   # Migrated
+
+
+		# Php version
+		php:
+		  version: 8.4 # Latest
+	`, projectFile, repositoryURL)
+
+	s.Run("UpToDate", func() {
+		_, err := s.handle(projectDir, manifest.WithMigrate(true))
+
+		s.Require().NoError(err)
+	})
+
+	s.Run("Baseline", func() {
+		projectDir := s.T().TempDir()
+		projectFile := filepath.Join(projectDir, ".manala.yaml")
+
+		s.Require().NoError(os.WriteFile(projectFile, []byte(heredoc.Doc(`
+			manala:
+			  recipe: recipe
+			  repository: %[1]s
+
+			php:
+			  version: 8.4
+		`, repositoryURL)), 0o644))
+
+		project, err := s.handle(projectDir, manifest.WithMigrate(true))
+
+		s.Require().NoError(err)
+		s.Equal(map[string]any{
+			"php": map[string]any{"version": 8.4},
+		}, project.Vars())
+
+		// No migration replayed, only recipe version recorded
+		heredoc.EqualFile(s.T(), `
+			manala:
+			  recipe: recipe
+			  repository: %[1]s
+			  version: 2.0.0
+
+			php:
+			  version: 8.4
+		`, projectFile, repositoryURL)
+	})
+
+	s.Run("Invalid", func() {
+		projectDir := s.T().TempDir()
+		projectFile := filepath.Join(projectDir, ".manala.yaml")
+
+		content := heredoc.Doc(`
+			manala:
+			  recipe: recipe
+			  repository: %[1]s
+			  version: 1.0.0
+
+			php: 8.4
+			foo: bar
+		`, repositoryURL)
+
+		s.Require().NoError(os.WriteFile(projectFile, []byte(content), 0o644))
+
+		_, err := s.handle(projectDir, manifest.WithMigrate(true))
+
+		s.Require().EqualError(err, "invalid project manifest vars")
+
+		// Invalid migrated vars are never saved
+		heredoc.EqualFile(s.T(), content, projectFile)
+	})
+}
+
+func (s *LoaderSuite) TestHandleLock() {
+	repositoryDir := filepath.FromSlash("testdata/LoaderSuite/TestHandleLock/repository")
+
+	projectDir := s.T().TempDir()
+	projectFile := filepath.Join(projectDir, ".manala.yaml")
+
+	content := heredoc.Doc(`
+		---
+		manala:
+		  recipe: recipe
+		  repository: repository?ref=^2.1   # Constraint
+
+
+		foo: baz
+	`)
+
+	s.Require().NoError(os.WriteFile(projectFile, []byte(content), 0o644))
+
+	repositoryMock := &mocks.Repository{}
+	repositoryMock.
+		On("URL").Return("repository?ref=^2.1").Maybe().
+		On("Dir").Return(repositoryDir).Maybe().
+		On("Ref").Return("v2.1.1").Maybe().
+		On("Constraint").Return("^2.1").Maybe().
+		On("Manifest").Return(nil).Maybe()
+
+	lockedRef := func(ref string) any {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			locked, _ := app.RepositoryLockedRef(ctx)
+			return locked == ref
+		})
+	}
+
+	handlerMock := &repository.LoaderHandlerMock{}
+	handlerMock.
+		On("Handle", lockedRef(""), mock.Anything, mock.Anything).Return(repositoryMock, nil).Twice().
+		On("Handle", lockedRef("v2.1.1"), mock.Anything, mock.Anything).Return(repositoryMock, nil).Once()
+
+	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(handlerMock))
+	recipeLoader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
+		recipeManifest.NewLoaderHandler(log.Discard),
+	))
+
+	// Resolved ref left unlocked
+	handler := manifest.NewLoaderHandler(log.Discard, repositoryLoader, recipeLoader)
+
+	proj, err := handler.Handle(s.T().Context(), &project.LoaderQuery{Dir: projectDir}, &project.LoaderHandlerChainMock{})
+
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"foo": "baz"}, proj.Vars())
+
+	heredoc.EqualFile(s.T(), content, projectFile)
+
+	// Resolved ref gets locked
+	proj, err = manifest.NewLoaderHandler(log.Discard, repositoryLoader, recipeLoader, manifest.WithLock(true)).
+		Handle(s.T().Context(), &project.LoaderQuery{Dir: projectDir}, &project.LoaderHandlerChainMock{})
+
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"foo": "baz"}, proj.Vars())
+
+	heredoc.EqualFile(s.T(), `
+		---
+		manala:
+		  recipe: recipe
+		  repository: repository?ref=^2.1   # Constraint
+		  ref: v2.1.1
+
+
+		foo: baz
+	`, projectFile)
+
+	// Locked ref gets reused
+	_, err = handler.Handle(s.T().Context(), &project.LoaderQuery{Dir: projectDir}, &project.LoaderHandlerChainMock{})
+
+	s.Require().NoError(err)
+	handlerMock.AssertExpectations(s.T())
+}
+
+func (s *LoaderSuite) TestHandleInterpolation() {
+	projectDir := filepath.FromSlash("testdata/LoaderSuite/TestHandleInterpolation/project")
+
+	s.T().Setenv("MANALA_TEST_REGISTRY", "registry.example.com")
+	s.T().Setenv("MANALA_TEST_VERSION", "8.4")
+
+	project, err := s.handle(projectDir)
+
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"registry": "registry.example.com:5000",
+		"port":     uint64(8080),
+		"version":  "8.4",
+		"tags":     []any{"latest"},
+		"escaped":  "${MANALA_TEST_REGISTRY}",
+	}, project.Vars())
+
+	s.Run("Set", func() {
+		s.T().Setenv("MANALA_TEST_PORT", "8000")
+		s.T().Setenv("MANALA_TEST_TAG", "stable")
+
+		project, err := s.handle(projectDir)
+
+		s.Require().NoError(err)
+		s.Equal(uint64(8000), project.Vars()["port"])
+		s.Equal([]any{"stable"}, project.Vars()["tags"])
+	})
+}
+
+func (s *LoaderSuite) TestHandleSecrets() {
+	projectDir := filepath.FromSlash("testdata/LoaderSuite/TestHandleSecrets/project")
+
+	project, err := s.handle(projectDir)
+
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"password": &secret.Secret{Backend: "file", Ref: ".secrets/password"},
+		"db": map[string]any{
+			"user":     "app",
+			"password": &secret.Secret{Backend: "file", Ref: ".secrets/db"},
+		},
+		"tokens": []any{
+			&secret.Secret{Backend: "exec", Ref: "pass show project/token"},
+		},
+		// Secrets values are only validated once resolved
+		"api_key": &secret.Secret{Backend: "file", Ref: ".secrets/api_key"},
+		"pin":     &secret.Secret{Backend: "file", Ref: ".secrets/pin"},
+	}, project.Vars())
+}
+
+func (s *LoaderSuite) TestHandleOverrides() {
+	projectDir := filepath.FromSlash("testdata/LoaderSuite/TestHandleOverrides/project")
+
+	s.Run("Local", func() {
+		project, err := s.handle(projectDir)
+
+		s.Require().NoError(err)
+		s.Equal(map[string]any{
+			"foo": "baz",
+			"app": map[string]any{"port": uint64(8000), "memory": uint64(512)},
+		}, project.Vars())
+
+		watches, err := project.Watches()
+		s.Require().NoError(err)
+		s.Equal([]string{
+			filepath.Join(projectDir, ".manala.yaml"),
+			filepath.Join(projectDir, ".manala.local.yaml"),
+		}, watches)
+	})
+
+	s.Run("Env", func() {
+		project, err := s.handle(projectDir, manifest.WithEnv("prod"))
+
+		s.Require().NoError(err)
+		s.Equal(map[string]any{
+			"foo": "qux",
+			"app": map[string]any{"port": uint64(8000), "memory": uint64(2048)},
+		}, project.Vars())
+
+		watches, err := project.Watches()
+		s.Require().NoError(err)
+		s.Equal([]string{
+			filepath.Join(projectDir, ".manala.yaml"),
+			filepath.Join(projectDir, ".manala.prod.yaml"),
+			filepath.Join(projectDir, ".manala.local.yaml"),
+		}, watches)
+	})
+
+	s.Run("EnvNotFound", func() {
+		project, err := s.handle(projectDir, manifest.WithEnv("dev"))
+
+		s.Require().NoError(err)
+		s.Equal(map[string]any{
+			"foo": "baz",
+			"app": map[string]any{"port": uint64(8000), "memory": uint64(512)},
+		}, project.Vars())
+	})
+}
+
+func (s *LoaderSuite) TestHandleOverridesErrors() {
+	dir := filepath.FromSlash("testdata/LoaderSuite/TestHandleOverridesErrors")
+
+	tests := []struct {
+		test     string
+		expected expectation.ErrorExpectation
+	}{
+		{
+			test: "LocalUnparsable",
+			expected: serrortest.Expectation{
+				Msg: "unable to parse project override manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:1:1
+
+						▶ 1 │ @
+						    ├─╯ '@' is a reserved character
+					`,
+						filepath.Join(dir, "LocalUnparsable", "project", ".manala.local.yaml"),
+					)),
+				),
+			},
+		},
+		{
+			test: "LocalConfig",
+			expected: serrortest.Expectation{
+				Msg: "project override manifest must not hold config",
+				Attrs: [][2]any{
+					{"file", filepath.Join(dir, "LocalConfig", "project", ".manala.local.yaml")},
+				},
+			},
+		},
+		{
+			test: "LocalVarsInvalid",
+			expected: serrortest.Expectation{
+				Msg: "invalid project manifest vars",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:1:6
+
+						▶ 1 │ bar: bar
+						    ├──────╯ got string, want integer
+					`,
+						filepath.Join(dir, "LocalVarsInvalid", "project", ".manala.local.yaml"),
+					)),
+				),
+			},
+		},
+		{
+			test: "EnvVarsInvalid",
+			expected: serrortest.Expectation{
+				Msg: "invalid project manifest vars",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:1:6
+
+						▶ 1 │ foo: foo
+						    ├──────╯ got string, want integer
+					`,
+						filepath.Join(dir, "EnvVarsInvalid", "project", ".manala.prod.yaml"),
+					)),
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:1:6
+
+						▶ 1 │ bar: bar
+						    ├──────╯ got string, want integer
+					`,
+						filepath.Join(dir, "EnvVarsInvalid", "project", ".manala.local.yaml"),
+					)),
+				),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			project, err := s.handle(filepath.Join(dir, test.test, "project"), manifest.WithEnv("prod"))
+
+			s.Nil(project)
+
+			expectation.ExpectError(s.T(), test.expected, err)
+		})
+	}
+}
+
 func (s *LoaderSuite) TestHandleErrors() {
 	dir := filepath.FromSlash("testdata/LoaderSuite/TestHandleErrors")
 
@@ -367,6 +739,47 @@ func (s *LoaderSuite) TestHandleErrors() {
 				),
 			},
 		},
+		{
+			test: "VarsUndefined",
+			expected: serrortest.Expectation{
+				Msg: "unable to interpolate project manifest vars",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:5:6
+
+						  2 │   recipe: recipe
+						  3 │   repository: testdata/LoaderSuite/TestHandleErrors/VarsUndefined/repository
+						  4 │
+						▶ 5 │ foo: ${MANALA_TEST_UNDEFINED}
+						    ├──────╯ undefined environment variable 'MANALA_TEST_UNDEFINED'
+						  6 │ bar: ${MANALA_TEST_UNDEFINED:-bar}
+					`,
+						filepath.Join(dir, "VarsUndefined", "project", ".manala.yaml"),
+					)),
+				),
+			},
+		},
+		{
+			test: "VarsSecretInvalid",
+			expected: serrortest.Expectation{
+				Msg: "invalid project manifest secrets",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:5:11
+
+						  2 │   recipe: recipe
+						  3 │   repository: testdata/LoaderSuite/TestHandleErrors/VarsSecretInvalid/repository
+						  4 │
+						▶ 5 │ password: !secret password
+						    ├───────────╯ secret reference must be of the form <backend>:<ref>
+					`,
+						filepath.Join(dir, "VarsSecretInvalid", "project", ".manala.yaml"),
+					)),
+				),
+			},
+		},
 		{
 			test: "VarsAdditionalProperty",
 			expected: serrortest.Expectation{
@@ -400,7 +813,7 @@ func (s *LoaderSuite) TestHandleErrors() {
 	}
 }
 
-func (s *LoaderSuite) handle(dir string) (app.Project, error) {
+func (s *LoaderSuite) handle(dir string, opts ...manifest.LoaderHandlerOption) (app.Project, error) {
 	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
 		getter.NewFileLoaderHandler(log.Discard),
 	))
@@ -410,7 +823,7 @@ func (s *LoaderSuite) handle(dir string) (app.Project, error) {
 
 	chainMock := &project.LoaderHandlerChainMock{}
 
-	handler := manifest.NewLoaderHandler(log.Discard, repositoryLoader, recipeLoader)
+	handler := manifest.NewLoaderHandler(log.Discard, repositoryLoader, recipeLoader, opts...)
 
-	return handler.Handle(&project.LoaderQuery{Dir: dir}, chainMock)
+	return handler.Handle(s.T().Context(), &project.LoaderQuery{Dir: dir}, chainMock)
 }
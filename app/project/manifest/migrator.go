@@ -0,0 +1,81 @@
+package manifest
+
+import (
+	"context"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/recipe"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+)
+
+type Migrator struct {
+	log              *log.Log
+	repositoryLoader *repository.Loader
+	recipeLoader     *recipe.Loader
+}
+
+func NewMigrator(log *log.Log, repositoryLoader *repository.Loader, recipeLoader *recipe.Loader) *Migrator {
+	return &Migrator{
+		log:              log,
+		repositoryLoader: repositoryLoader,
+		recipeLoader:     recipeLoader,
+	}
+}
+
+// Migrate rewrites project manifest recipe to the replacement of its deprecated one,
+// following replacements chains, and returns the final replacement recipe.
+// A nil recipe is returned if there is nothing to migrate.
+func (migrator *Migrator) Migrate(ctx context.Context, project app.Project) (app.Recipe, error) {
+	rcp := project.Recipe()
+	if rcp.ReplacedBy() == "" {
+		return nil, nil
+	}
+
+	seen := map[string]bool{rcp.Name(): true}
+
+	for rcp.ReplacedBy() != "" {
+		name := rcp.ReplacedBy()
+		if seen[name] {
+			return nil, serror.New("recipe replacements cycle").
+				With("recipe", name)
+		}
+
+		seen[name] = true
+
+		migrator.log.Debug("follow recipe replacement", "recipe", rcp.Name(), "replaced_by", name)
+
+		// Load repository again, letting it know about replacement recipe
+		repository, err := migrator.repositoryLoader.Load(
+			app.WithRecipeName(ctx, name),
+			rcp.Repository().URL(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rcp, err = migrator.recipeLoader.Load(ctx, repository, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Rewrite project manifest recipe, leaving the rest of its content untouched
+	editor := NewEditor(migrator.log, project.Dir())
+	if err := editor.Load(); err != nil {
+		return nil, err
+	}
+
+	migrator.log.Debug("migrate project manifest", "dir", project.Dir(), "recipe", project.Recipe().Name(), "replaced_by", rcp.Name())
+
+	if err := editor.Set("/manala/recipe", rcp.Name()); err != nil {
+		return nil, err
+	}
+
+	if err := editor.Save(); err != nil {
+		return nil, err
+	}
+
+	return rcp, nil
+}
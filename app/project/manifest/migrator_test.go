@@ -0,0 +1,140 @@
+package manifest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/project"
+	"github.com/manala/manala/app/project/manifest"
+	"github.com/manala/manala/app/recipe"
+	recipeManifest "github.com/manala/manala/app/recipe/manifest"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/testing/errors"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MigratorSuite struct{ suite.Suite }
+
+func TestMigratorSuite(t *testing.T) {
+	suite.Run(t, new(MigratorSuite))
+}
+
+func (s *MigratorSuite) TestMigrate() {
+	repositoryURL, _ := filepath.Abs(filepath.FromSlash("testdata/MigratorSuite/TestMigrate/repository"))
+
+	s.Run("Replaced", func() {
+		projectDir := s.project(heredoc.Doc(`
+			# Project
+			manala:
+			  recipe: foo # Recipe
+			  repository: %[1]s
+
+			foo: bar
+		`, repositoryURL))
+
+		recipe, err := s.migrate(projectDir)
+
+		s.Require().NoError(err)
+		s.Equal("baz", recipe.Name())
+
+		content, _ := os.ReadFile(filepath.Join(projectDir, ".manala.yaml"))
+		heredoc.Equal(s.T(), `
+			# Project
+			manala:
+			  recipe: baz # Recipe
+			  repository: %[1]s
+
+			foo: bar
+		`, content, repositoryURL)
+	})
+
+	s.Run("UpToDate", func() {
+		projectDir := s.project(heredoc.Doc(`
+			manala:
+			  recipe: baz
+			  repository: %[1]s
+		`, repositoryURL))
+
+		recipe, err := s.migrate(projectDir)
+
+		s.Require().NoError(err)
+		s.Nil(recipe)
+	})
+}
+
+func (s *MigratorSuite) TestMigrateErrors() {
+	repositoryURL, _ := filepath.Abs(filepath.FromSlash("testdata/MigratorSuite/TestMigrateErrors/repository"))
+
+	s.Run("Cycle", func() {
+		projectDir := s.project(heredoc.Doc(`
+			manala:
+			  recipe: cycle_foo
+			  repository: %[1]s
+		`, repositoryURL))
+
+		recipe, err := s.migrate(projectDir)
+
+		s.Nil(recipe)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg: "recipe replacements cycle",
+			Attrs: [][2]any{
+				{"recipe", "cycle_foo"},
+			},
+		}, err)
+	})
+
+	s.Run("NotFound", func() {
+		projectDir := s.project(heredoc.Doc(`
+			manala:
+			  recipe: missing
+			  repository: %[1]s
+		`, repositoryURL))
+
+		recipe, err := s.migrate(projectDir)
+
+		s.Nil(recipe)
+		expectation.ExpectError(s.T(), errors.Expectation{
+			Type: &app.NotFoundRecipeError{},
+			Attrs: [][2]any{
+				{"repository", repositoryURL},
+				{"name", "qux"},
+			},
+		}, err)
+	})
+}
+
+// project creates a temporary project dir, along with its manifest.
+func (s *MigratorSuite) project(content string) string {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, ".manala.yaml"), []byte(content), 0o644))
+
+	return dir
+}
+
+func (s *MigratorSuite) migrate(dir string) (app.Recipe, error) {
+	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
+		getter.NewFileLoaderHandler(log.Discard),
+	))
+	recipeLoader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
+		recipeManifest.NewLoaderHandler(log.Discard),
+	))
+
+	projectLoader := project.NewLoader(log.Discard, project.WithLoaderHandlers(
+		manifest.NewLoaderHandler(log.Discard, repositoryLoader, recipeLoader),
+	))
+
+	project, err := projectLoader.Load(s.T().Context(), dir)
+	s.Require().NoError(err)
+
+	migrator := manifest.NewMigrator(log.Discard, repositoryLoader, recipeLoader)
+
+	return migrator.Migrate(s.T().Context(), project)
+}
@@ -10,6 +10,8 @@ type Project struct {
 	dir    string
 	recipe app.Recipe
 	vars   map[string]any
+	// Loaded override manifests files, in merge order
+	overrides []string
 }
 
 func (project *Project) Dir() string {
@@ -25,7 +27,7 @@ func (project *Project) Vars() map[string]any {
 }
 
 func (project *Project) Watches() ([]string, error) {
-	return []string{
+	return append([]string{
 		filepath.Join(project.Dir(), filename),
-	}, nil
+	}, project.overrides...), nil
 }
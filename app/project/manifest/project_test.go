@@ -27,6 +27,9 @@ func (s *ProjectSuite) Test() {
 		dir:    dir,
 		recipe: recipeMock,
 		vars:   vars,
+		overrides: []string{
+			filepath.Join(dir, ".manala.local.yaml"),
+		},
 	}
 
 	s.Equal(dir, project.Dir())
@@ -35,5 +38,8 @@ func (s *ProjectSuite) Test() {
 
 	watches, err := project.Watches()
 	s.Require().NoError(err)
-	s.Equal([]string{filepath.Join(dir, ".manala.yaml")}, watches)
+	s.Equal([]string{
+		filepath.Join(dir, ".manala.yaml"),
+		filepath.Join(dir, ".manala.local.yaml"),
+	}, watches)
 }
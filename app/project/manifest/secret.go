@@ -0,0 +1,111 @@
+package manifest
+
+import (
+	"errors"
+
+	"github.com/manala/manala/app/secret"
+	yamlerrors "github.com/manala/manala/internal/yaml/errors"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// secretVars replaces "!secret" tagged vars values by their secrets, walking vars along with their node.
+func secretVars(vars map[string]any, node ast.Node) (map[string]any, error) {
+	value, err := secretValue(vars, node)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, _ = value.(map[string]any)
+
+	return vars, nil
+}
+
+func secretValue(value any, node ast.Node) (any, error) {
+	switch node := node.(type) {
+	case *ast.TagNode:
+		if node.Start.Value != secret.Tag {
+			return secretValue(value, node.Value)
+		}
+
+		reference, ok := value.(string)
+		if !ok {
+			return nil, yamlerrors.New(
+				errors.New("secret reference must be a string"),
+				node.GetToken(),
+			)
+		}
+
+		s, err := secret.Parse(reference)
+		if err != nil {
+			return nil, yamlerrors.New(err, node.GetToken())
+		}
+
+		return s, nil
+	case *ast.MappingNode:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return value, nil
+		}
+
+		var errs []error
+
+		for _, valueNode := range node.Values {
+			key, ok := mappingKey(valueNode.Key)
+			if !ok {
+				continue
+			}
+
+			v, found := m[key]
+			if !found {
+				continue
+			}
+
+			v, err := secretValue(v, valueNode.Value)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			m[key] = v
+		}
+
+		return m, errors.Join(errs...)
+	case *ast.SequenceNode:
+		s, ok := value.([]any)
+		if !ok {
+			return value, nil
+		}
+
+		var errs []error
+
+		for i, valueNode := range node.Values {
+			if i >= len(s) {
+				break
+			}
+
+			v, err := secretValue(s[i], valueNode)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			s[i] = v
+		}
+
+		return s, errors.Join(errs...)
+	}
+
+	return value, nil
+}
+
+// mappingKey returns the string key of a mapping value node.
+func mappingKey(node ast.MapKeyNode) (string, bool) {
+	if n, ok := node.(*ast.MappingKeyNode); ok {
+		node, _ = n.Value.(ast.MapKeyNode)
+	}
+
+	if n, ok := node.(*ast.StringNode); ok {
+		return n.Value, true
+	}
+
+	return "", false
+}
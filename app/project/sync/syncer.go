@@ -1,7 +1,10 @@
 package sync
 
 import (
+	"context"
+
 	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/secret"
 	"github.com/manala/manala/app/template"
 	"github.com/manala/manala/internal/log"
 	"github.com/manala/manala/internal/sync"
@@ -10,19 +13,27 @@ import (
 type Syncer struct {
 	syncer         *sync.Syncer
 	templateEngine *template.Engine
+	secretResolver *secret.Resolver
 }
 
-func NewSyncer(log *log.Log, templateEngine *template.Engine) *Syncer {
+func NewSyncer(log *log.Log, templateEngine *template.Engine, secretResolver *secret.Resolver) *Syncer {
 	return &Syncer{
 		syncer:         sync.NewSyncer(log),
 		templateEngine: templateEngine,
+		secretResolver: secretResolver,
 	}
 }
 
-func (syncer *Syncer) Sync(project app.Project) error {
+func (syncer *Syncer) Sync(ctx context.Context, project app.Project) error {
+	// Resolve secrets, only for the sake of templates
+	vars, err := syncer.secretResolver.Resolve(ctx, project.Dir(), project.Vars())
+	if err != nil {
+		return err
+	}
+
 	// Template executor
 	templateExecutor, err := syncer.templateEngine.Executor(
-		project.Vars(),
+		vars,
 		project.Recipe(),
 		project.Dir(),
 	)
@@ -38,6 +49,7 @@ func (syncer *Syncer) Sync(project app.Project) error {
 			project.Dir(),
 			unit.Destination,
 			templateExecutor,
+			sync.WithMergeStrategies(project.Recipe().MergeStrategies()),
 		); err != nil {
 			return err
 		}
@@ -12,6 +12,7 @@ import (
 	recipeManifest "github.com/manala/manala/app/recipe/manifest"
 	"github.com/manala/manala/app/repository"
 	repositoryGetter "github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/secret"
 	"github.com/manala/manala/app/template"
 	"github.com/manala/manala/internal/log"
 	"github.com/manala/manala/internal/testing/heredoc"
@@ -43,14 +44,50 @@ func (s *SyncerSuite) TestSync() {
 		),
 	)
 
-	project, err := projectLoader.Load(projectDir)
+	project, err := projectLoader.Load(s.T().Context(), projectDir)
 	s.Require().NoError(err)
 
-	syncer := sync.NewSyncer(log.Discard, template.NewEngine())
-	err = syncer.Sync(project)
+	syncer := sync.NewSyncer(log.Discard, template.NewEngine(), secret.NewResolver(log.Discard))
+	err = syncer.Sync(s.T().Context(), project)
 
 	s.Require().NoError(err)
 	heredoc.EqualFile(s.T(), `
 		File
 	`, filepath.Join(projectDir, "file.txt"))
 }
+
+func (s *SyncerSuite) TestSyncSecrets() {
+	projectDir := filepath.FromSlash("testdata/SyncerSuite/TestSyncSecrets/project")
+
+	_ = os.RemoveAll(filepath.Join(projectDir, ".env"))
+
+	projectLoader := project.NewLoader(log.Discard,
+		project.WithLoaderHandlers(
+			projectManifest.NewLoaderHandler(log.Discard,
+				repository.NewLoader(repository.WithLoaderHandlers(
+					repositoryGetter.NewFileLoaderHandler(log.Discard),
+				)),
+				recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
+					recipeManifest.NewLoaderHandler(log.Discard),
+				)),
+			),
+		),
+	)
+
+	project, err := projectLoader.Load(s.T().Context(), projectDir)
+	s.Require().NoError(err)
+
+	// Secrets are only resolved at sync time
+	s.Equal(&secret.Secret{Backend: "file", Ref: ".secrets/db"}, project.Vars()["db"].(map[string]any)["password"])
+
+	syncer := sync.NewSyncer(log.Discard, template.NewEngine(), secret.NewResolver(log.Discard,
+		secret.WithBackend("file", secret.NewFileBackend()),
+	))
+	err = syncer.Sync(s.T().Context(), project)
+
+	s.Require().NoError(err)
+	heredoc.EqualFile(s.T(), `
+		DB_USER=app
+		DB_PASSWORD=s3cr3t
+	`, filepath.Join(projectDir, ".env"))
+}
@@ -0,0 +1,45 @@
+package recipe
+
+import (
+	"slices"
+
+	"github.com/manala/manala/app"
+)
+
+// Category groups recipes sharing the same category.
+type Category struct {
+	Name    string
+	Recipes []app.Recipe
+}
+
+// Categorize groups recipes by category, in order of first appearance.
+// Uncategorized recipes come last, in a category without name.
+func Categorize(recipes []app.Recipe) []*Category {
+	var (
+		categories    []*Category
+		uncategorized []app.Recipe
+	)
+
+	for _, recipe := range recipes {
+		name := recipe.Category()
+		if name == "" {
+			uncategorized = append(uncategorized, recipe)
+
+			continue
+		}
+
+		index := slices.IndexFunc(categories, func(category *Category) bool { return category.Name == name })
+		if index < 0 {
+			categories = append(categories, &Category{Name: name})
+			index = len(categories) - 1
+		}
+
+		categories[index].Recipes = append(categories[index].Recipes, recipe)
+	}
+
+	if len(uncategorized) > 0 {
+		categories = append(categories, &Category{Recipes: uncategorized})
+	}
+
+	return categories
+}
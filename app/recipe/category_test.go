@@ -0,0 +1,53 @@
+package recipe_test
+
+import (
+	"testing"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/recipe"
+	"github.com/manala/manala/app/testing/mocks"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CategorySuite struct{ suite.Suite }
+
+func TestCategorySuite(t *testing.T) {
+	suite.Run(t, new(CategorySuite))
+}
+
+func (s *CategorySuite) TestCategorize() {
+	fooMock := &mocks.Recipe{}
+	fooMock.
+		On("Category").Return("php")
+
+	barMock := &mocks.Recipe{}
+	barMock.
+		On("Category").Return("")
+
+	bazMock := &mocks.Recipe{}
+	bazMock.
+		On("Category").Return("node")
+
+	quxMock := &mocks.Recipe{}
+	quxMock.
+		On("Category").Return("php")
+
+	s.Run("Categories", func() {
+		s.Equal([]*recipe.Category{
+			{Name: "php", Recipes: []app.Recipe{fooMock, quxMock}},
+			{Name: "node", Recipes: []app.Recipe{bazMock}},
+			{Recipes: []app.Recipe{barMock}},
+		}, recipe.Categorize([]app.Recipe{fooMock, barMock, bazMock, quxMock}))
+	})
+
+	s.Run("Uncategorized", func() {
+		s.Equal([]*recipe.Category{
+			{Recipes: []app.Recipe{barMock}},
+		}, recipe.Categorize([]app.Recipe{barMock}))
+	})
+
+	s.Run("Empty", func() {
+		s.Empty(recipe.Categorize(nil))
+	})
+}
@@ -0,0 +1,214 @@
+package doc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/errors/std"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// GenMarkdown writes a recipe vars reference, as markdown, from its inferred schema, options and default values.
+func GenMarkdown(recipe app.Recipe, w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("## " + recipe.Name() + "\n\n")
+
+	if description := recipe.Description(); description != "" {
+		b.WriteString(description + "\n\n")
+	}
+
+	if deprecated := recipe.Deprecated(); deprecated != "" {
+		if replacedBy := recipe.ReplacedBy(); replacedBy != "" {
+			deprecated += ", replaced by " + replacedBy
+		}
+		b.WriteString("**Deprecated**: " + deprecated + "\n\n")
+	}
+
+	b.WriteString("### Variables\n\n")
+
+	// Index options by their pointer
+	options := map[string]app.RecipeOption{}
+	for _, option := range recipe.Options() {
+		options[option.Pointer()] = option
+	}
+
+	rows := varRows(recipe.Schema(), recipe.Vars(), nil, options)
+
+	if len(rows) == 0 {
+		b.WriteString("No variables.\n")
+	} else {
+		b.WriteString("| Variable | Type | Default | Option | Description |\n")
+		b.WriteString("|----------|------|---------|--------|-------------|\n")
+		for _, row := range rows {
+			b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return serror.New("unable to write recipe doc").
+			With("recipe", recipe.Name()).
+			WithErr(err)
+	}
+
+	return nil
+}
+
+// GenMarkdownTree writes a markdown page for each recipe, into dir.
+func GenMarkdownTree(recipes []app.Recipe, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return serror.New("unable to create recipes docs directory").
+			With("dir", dir).
+			WithErr(std.From(err))
+	}
+
+	for _, recipe := range recipes {
+		if err := genMarkdownFile(recipe, dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func genMarkdownFile(recipe app.Recipe, dir string) (err error) {
+	// Recipe name must not reach outside dir
+	name := recipe.Name() + ".md"
+	if !filepath.IsLocal(name) || filepath.Base(name) != name {
+		return serror.New("invalid recipe doc file name").
+			With("recipe", recipe.Name())
+	}
+
+	file := filepath.Join(dir, name)
+
+	f, err := os.Create(file)
+	if err != nil {
+		return serror.New("unable to create recipe doc file").
+			With("file", file).
+			WithErr(std.From(err))
+	}
+
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = serror.New("unable to close recipe doc file").
+				With("file", file).
+				WithErr(std.From(closeErr))
+		}
+	}()
+
+	return GenMarkdown(recipe, f)
+}
+
+// varRows returns a table row for each schema leaf property, along with its type, default value, option and
+// description, in vars dotted path order.
+func varRows(schema map[string]any, vars map[string]any, path []string, options map[string]app.RecipeOption) [][]string {
+	var rows [][]string
+
+	properties, _ := schema["properties"].(map[string]any)
+
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		property, _ := properties[name].(map[string]any)
+		propertyPath := append(slices.Clone(path), name)
+
+		// Walk down objects properties
+		if subProperties, _ := property["properties"].(map[string]any); len(subProperties) > 0 {
+			rows = append(rows, varRows(property, vars, propertyPath, options)...)
+			continue
+		}
+
+		var propertyPointer string
+		for _, token := range propertyPath {
+			propertyPointer += "/" + jsonpointer.Escape(token)
+		}
+
+		// Option
+		var option string
+		if opt, ok := options[propertyPointer]; ok {
+			option = opt.Label()
+			if help := opt.Help(); help != "" {
+				option += " — " + help
+			}
+		}
+
+		description, _ := property["description"].(string)
+
+		rows = append(rows, []string{
+			cell("`" + strings.Join(propertyPath, ".") + "`"),
+			cell(varType(property)),
+			cell(varDefault(vars, propertyPointer)),
+			cell(option),
+			cell(description),
+		})
+	}
+
+	return rows
+}
+
+// varType returns a var schema type, or enum values.
+func varType(property map[string]any) string {
+	if enum, ok := property["enum"].([]any); ok {
+		values := make([]string, len(enum))
+		for i, value := range enum {
+			values[i] = "`" + varValue(value) + "`"
+		}
+		return "one of " + strings.Join(values, ", ")
+	}
+
+	switch typ := property["type"].(type) {
+	case string:
+		if typ == "array" {
+			if items, ok := property["items"].(map[string]any); ok {
+				if _, ok := items["enum"]; ok {
+					return "array of " + varType(items)
+				}
+			}
+		}
+		return typ
+	case []any:
+		types := make([]string, len(typ))
+		for i, t := range typ {
+			types[i] = fmt.Sprint(t)
+		}
+		return strings.Join(types, ", ")
+	}
+
+	return ""
+}
+
+// varDefault returns a var default value, if any.
+func varDefault(vars map[string]any, pointer string) string {
+	p, err := jsonpointer.New(pointer)
+	if err != nil {
+		return ""
+	}
+
+	value, _, err := p.Get(vars)
+	if err != nil {
+		return ""
+	}
+
+	return "`" + varValue(value) + "`"
+}
+
+func varValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+// cell escapes markdown table cell content.
+func cell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", "<br>").Replace(s)
+}
@@ -0,0 +1,118 @@
+package doc_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/recipe"
+	"github.com/manala/manala/app/recipe/doc"
+	recipeManifest "github.com/manala/manala/app/recipe/manifest"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DocSuite struct{ suite.Suite }
+
+func TestDocSuite(t *testing.T) {
+	suite.Run(t, new(DocSuite))
+}
+
+func (s *DocSuite) TestGenMarkdown() {
+	recipes := s.load(filepath.FromSlash("testdata/DocSuite/TestGenMarkdown/repository"))
+
+	var b strings.Builder
+	err := doc.GenMarkdown(recipes[0], &b)
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), "\n"+
+		"## recipe\n"+
+		"\n"+
+		"Recipe\n"+
+		"\n"+
+		"**Deprecated**: Use other instead, replaced by other\n"+
+		"\n"+
+		"### Variables\n"+
+		"\n"+
+		"| Variable | Type | Default | Option | Description |\n"+
+		"|----------|------|---------|--------|-------------|\n"+
+		"| `app.extensions` | array of one of `\"intl\"`, `\"redis\"` | `[\"intl\"]` | Extensions — Php extensions |  |\n"+
+		"| `app.memory_limit` | string | `\"128M\"` |  | Memory limit,<br>as in 128M \\| 1G |\n"+
+		"| `database` | one of `null`, `\"mysql\"` | `null` | Database |  |\n"+
+		"| `port` | integer, string | `8080` |  |  |\n",
+		b.String(),
+	)
+}
+
+func (s *DocSuite) TestGenMarkdownTree() {
+	recipes := s.load(filepath.FromSlash("testdata/DocSuite/TestGenMarkdownTree/repository"))
+
+	dir := filepath.Join(s.T().TempDir(), "recipes")
+
+	err := doc.GenMarkdownTree(recipes, dir)
+
+	s.Require().NoError(err)
+	heredoc.EqualFile(s.T(), `
+		## bar
+
+		Bar
+
+		### Variables
+
+		No variables.
+	`, filepath.Join(dir, "bar.md"))
+	heredoc.EqualFile(s.T(), "\n"+
+		"## foo\n"+
+		"\n"+
+		"Foo\n"+
+		"\n"+
+		"### Variables\n"+
+		"\n"+
+		"| Variable | Type | Default | Option | Description |\n"+
+		"|----------|------|---------|--------|-------------|\n"+
+		"| `foo` | string | `\"bar\"` |  |  |\n",
+		filepath.Join(dir, "foo.md"),
+	)
+}
+
+func (s *DocSuite) TestGenMarkdownTreeErrors() {
+	recipes := s.load(filepath.FromSlash("testdata/DocSuite/TestGenMarkdownTree/repository"))
+
+	s.Run("Dir", func() {
+		dir := filepath.Join(s.T().TempDir(), "file")
+		s.Require().NoError(os.WriteFile(dir, nil, 0o644))
+
+		err := doc.GenMarkdownTree(recipes, dir)
+
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "unable to create recipes docs directory",
+			Attrs: [][2]any{{"dir", dir}},
+			Err:   expectation.ErrorMessage("not a directory"),
+		}, err)
+	})
+}
+
+func (s *DocSuite) load(repositoryURL string) []app.Recipe {
+	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
+		getter.NewFileLoaderHandler(log.Discard),
+	))
+	recipeLoader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(
+		recipeManifest.NewLoaderHandler(log.Discard),
+	))
+
+	repository, err := repositoryLoader.Load(s.T().Context(), repositoryURL)
+	s.Require().NoError(err)
+
+	recipes, err := recipeLoader.LoadAll(s.T().Context(), repository)
+	s.Require().NoError(err)
+
+	return recipes
+}
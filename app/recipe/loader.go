@@ -1,8 +1,11 @@
 package recipe
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"os"
+	"slices"
 	"sort"
 
 	"github.com/manala/manala/app"
@@ -33,15 +36,34 @@ func NewLoader(log *log.Log, opts ...LoaderOption) *Loader {
 	return loader
 }
 
-func (loader *Loader) Load(repository app.Repository, name string) (app.Recipe, error) {
+func (loader *Loader) Load(ctx context.Context, repository app.Repository, name string) (app.Recipe, error) {
+	recipe, err := loader.load(ctx, repository, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deprecation notice
+	if deprecated := recipe.Deprecated(); deprecated != "" {
+		args := []any{"recipe", recipe.Name(), "reason", deprecated}
+		if replacedBy := recipe.ReplacedBy(); replacedBy != "" {
+			args = append(args, "replaced_by", replacedBy)
+		}
+
+		loader.log.Warn("deprecated recipe", args...)
+	}
+
+	return recipe, nil
+}
+
+func (loader *Loader) load(ctx context.Context, repository app.Repository, name string) (app.Recipe, error) {
 	// Prepare query
 	query := &LoaderQuery{Repository: repository, Name: name}
 
 	// Start chain
-	return loader.Next(query)
+	return loader.Next(ctx, query)
 }
 
-func (loader *Loader) LoadAll(repository app.Repository) ([]app.Recipe, error) {
+func (loader *Loader) LoadAll(ctx context.Context, repository app.Repository) ([]app.Recipe, error) {
 	dir, err := os.Open(repository.Dir())
 	if err != nil {
 		return nil, serror.New("file system error").
@@ -61,9 +83,16 @@ func (loader *Loader) LoadAll(repository app.Repository) ([]app.Recipe, error) {
 	// Sort alphabetically
 	sort.Slice(files, func(a, b int) bool { return files[a].Name() < files[b].Name() })
 
+	manifest := repository.Manifest()
+
 	recipes := make([]app.Recipe, 0)
 
 	for _, file := range files {
+		// Cancellation
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		if !file.IsDir() {
 			continue
 		}
@@ -77,7 +106,17 @@ func (loader *Loader) LoadAll(repository app.Repository) ([]app.Recipe, error) {
 			}
 		}
 
-		recipe, err := loader.Load(repository, file.Name())
+		// Hidden by repository manifest
+		if manifest != nil {
+			if entry, ok := manifest.Recipe(file.Name()); ok && entry.Hidden() {
+				loader.log.Debug("hide recipe", "name", file.Name())
+
+				continue
+			}
+		}
+
+		// Deprecated recipes are marked when listed, rather than warned
+		recipe, err := loader.load(ctx, repository, file.Name())
 		if err != nil {
 			if _, ok := errors.AsType[*app.NotFoundRecipeError](err); ok {
 				continue
@@ -93,21 +132,45 @@ func (loader *Loader) LoadAll(repository app.Repository) ([]app.Recipe, error) {
 		return nil, &app.EmptyRepositoryError{Repository: repository}
 	}
 
+	// Repository manifest ordering, unlisted recipes coming last
+	if manifest != nil {
+		order := map[string]int{}
+		for i, entry := range manifest.Recipes() {
+			order[entry.Name()] = i + 1
+		}
+
+		slices.SortStableFunc(recipes, func(a, b app.Recipe) int {
+			orderA, okA := order[a.Name()]
+			orderB, okB := order[b.Name()]
+
+			switch {
+			case okA && okB:
+				return cmp.Compare(orderA, orderB)
+			case okA:
+				return -1
+			case okB:
+				return 1
+			}
+
+			return 0
+		})
+	}
+
 	return recipes, nil
 }
 
-func (loader Loader) Next(query *LoaderQuery) (app.Recipe, error) {
+func (loader Loader) Next(ctx context.Context, query *LoaderQuery) (app.Recipe, error) {
 	if len(loader.handlers) == 0 {
-		return loader.Last(query)
+		return loader.Last(ctx, query)
 	}
 
 	handler := loader.handlers[0]
 	loader.handlers = loader.handlers[1:]
 
-	return handler.Handle(query, loader)
+	return handler.Handle(ctx, query, loader)
 }
 
-func (loader Loader) Last(query *LoaderQuery) (app.Recipe, error) {
+func (loader Loader) Last(ctx context.Context, query *LoaderQuery) (app.Recipe, error) {
 	return nil, &app.NotFoundRecipeError{Repository: query.Repository, Name: query.Name}
 }
 
@@ -131,36 +194,36 @@ type LoaderQuery struct {
 }
 
 type LoaderHandler interface {
-	Handle(query *LoaderQuery, chain LoaderHandlerChain) (app.Recipe, error)
+	Handle(ctx context.Context, query *LoaderQuery, chain LoaderHandlerChain) (app.Recipe, error)
 }
 
 type LoaderHandlerMock struct {
 	mock.Mock
 }
 
-func (mock *LoaderHandlerMock) Handle(query *LoaderQuery, chain LoaderHandlerChain) (app.Recipe, error) {
-	args := mock.Called(query, chain)
+func (mock *LoaderHandlerMock) Handle(ctx context.Context, query *LoaderQuery, chain LoaderHandlerChain) (app.Recipe, error) {
+	args := mock.Called(ctx, query, chain)
 
 	return args.Get(0).(app.Recipe), args.Error(1)
 }
 
 type LoaderHandlerChain interface {
-	Next(query *LoaderQuery) (app.Recipe, error)
-	Last(query *LoaderQuery) (app.Recipe, error)
+	Next(ctx context.Context, query *LoaderQuery) (app.Recipe, error)
+	Last(ctx context.Context, query *LoaderQuery) (app.Recipe, error)
 }
 
 type LoaderHandlerChainMock struct {
 	mock.Mock
 }
 
-func (mock *LoaderHandlerChainMock) Next(query *LoaderQuery) (app.Recipe, error) {
-	args := mock.Called(query)
+func (mock *LoaderHandlerChainMock) Next(ctx context.Context, query *LoaderQuery) (app.Recipe, error) {
+	args := mock.Called(ctx, query)
 
 	return args.Get(0).(app.Recipe), args.Error(1)
 }
 
-func (mock *LoaderHandlerChainMock) Last(query *LoaderQuery) (app.Recipe, error) {
-	args := mock.Called(query)
+func (mock *LoaderHandlerChainMock) Last(ctx context.Context, query *LoaderQuery) (app.Recipe, error) {
+	args := mock.Called(ctx, query)
 
 	return args.Get(0).(app.Recipe), args.Error(1)
 }
@@ -1,6 +1,8 @@
 package recipe_test
 
 import (
+	"bytes"
+	"context"
 	"path/filepath"
 	"testing"
 
@@ -8,10 +10,13 @@ import (
 	"github.com/manala/manala/app/recipe"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/repository/manifest"
 	"github.com/manala/manala/app/testing/errors"
 	"github.com/manala/manala/app/testing/mocks"
 	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
 	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -26,20 +31,47 @@ func TestLoaderSuite(t *testing.T) {
 func (s *LoaderSuite) TestLoad() {
 	repositoryMock := &mocks.Repository{}
 	recipeMock := &mocks.Recipe{}
+	recipeMock.
+		On("Deprecated").Return("")
 
 	handlerMock := &recipe.LoaderHandlerMock{}
 	handlerMock.
-		On("Handle", &recipe.LoaderQuery{Repository: repositoryMock, Name: "name"}, mock.Anything).Return(recipeMock, nil)
+		On("Handle", mock.Anything, &recipe.LoaderQuery{Repository: repositoryMock, Name: "name"}, mock.Anything).Return(recipeMock, nil)
 
 	loader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(handlerMock))
 
-	recipe, err := loader.Load(repositoryMock, "name")
+	recipe, err := loader.Load(s.T().Context(), repositoryMock, "name")
 
 	s.Require().NoError(err)
 	s.Equal(recipeMock, recipe)
 	handlerMock.AssertExpectations(s.T())
 }
 
+func (s *LoaderSuite) TestLoadDeprecated() {
+	repositoryMock := &mocks.Repository{}
+	recipeMock := &mocks.Recipe{}
+	recipeMock.
+		On("Name").Return("name").
+		On("Deprecated").Return("use bar instead").
+		On("ReplacedBy").Return("bar")
+
+	handlerMock := &recipe.LoaderHandlerMock{}
+	handlerMock.
+		On("Handle", mock.Anything, &recipe.LoaderQuery{Repository: repositoryMock, Name: "name"}, mock.Anything).Return(recipeMock, nil)
+
+	stderr := &bytes.Buffer{}
+
+	loader := recipe.NewLoader(log.New(output.NewDetached(stderr)), recipe.WithLoaderHandlers(handlerMock))
+
+	recipe, err := loader.Load(s.T().Context(), repositoryMock, "name")
+
+	s.Require().NoError(err)
+	s.Equal(recipeMock, recipe)
+	s.Equal(heredoc.Doc(`
+		 ▲ deprecated recipe                recipe=name reason=use bar instead replaced_by=bar
+	`), stderr.String())
+}
+
 func (s *LoaderSuite) TestLoadErrors() {
 	loader := recipe.NewLoader(log.Discard)
 
@@ -48,7 +80,7 @@ func (s *LoaderSuite) TestLoadErrors() {
 		repositoryMock.
 			On("URL").Return("url")
 
-		recipe, err := loader.Load(repositoryMock, "name")
+		recipe, err := loader.Load(s.T().Context(), repositoryMock, "name")
 
 		s.Nil(recipe)
 		expectation.ExpectError(s.T(), errors.Expectation{
@@ -64,18 +96,18 @@ func (s *LoaderSuite) TestLoadAll() {
 	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
 		getter.NewFileLoaderHandler(log.Discard),
 	))
-	repository, _ := repositoryLoader.Load(repositoryURL)
+	repository, _ := repositoryLoader.Load(s.T().Context(), repositoryURL)
 
 	recipeMock := &mocks.Recipe{}
 
 	handlerMock := &recipe.LoaderHandlerMock{}
 	handlerMock.
-		On("Handle", &recipe.LoaderQuery{Repository: repository, Name: "foo"}, mock.Anything).Return(recipeMock, nil).
-		On("Handle", &recipe.LoaderQuery{Repository: repository, Name: "bar"}, mock.Anything).Return(recipeMock, nil)
+		On("Handle", mock.Anything, &recipe.LoaderQuery{Repository: repository, Name: "foo"}, mock.Anything).Return(recipeMock, nil).
+		On("Handle", mock.Anything, &recipe.LoaderQuery{Repository: repository, Name: "bar"}, mock.Anything).Return(recipeMock, nil)
 
 	loader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(handlerMock))
 
-	recipes, err := loader.LoadAll(repository)
+	recipes, err := loader.LoadAll(s.T().Context(), repository)
 
 	s.Require().NoError(err)
 	s.Equal([]app.Recipe{
@@ -85,6 +117,63 @@ func (s *LoaderSuite) TestLoadAll() {
 	handlerMock.AssertExpectations(s.T())
 }
 
+func (s *LoaderSuite) TestLoadAllManifest() {
+	repositoryURL := filepath.FromSlash("testdata/LoaderSuite/TestLoadAllManifest/repository")
+
+	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
+		manifest.NewLoaderHandler(log.Discard, ""),
+		getter.NewFileLoaderHandler(log.Discard),
+	))
+	repository, _ := repositoryLoader.Load(s.T().Context(), repositoryURL)
+
+	recipeMocks := map[string]*mocks.Recipe{}
+
+	handlerMock := &recipe.LoaderHandlerMock{}
+	for _, name := range []string{"bar", "foo", "qux"} {
+		recipeMocks[name] = &mocks.Recipe{}
+		recipeMocks[name].
+			On("Name").Return(name)
+
+		handlerMock.
+			On("Handle", mock.Anything, &recipe.LoaderQuery{Repository: repository, Name: name}, mock.Anything).Return(recipeMocks[name], nil)
+	}
+
+	loader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(handlerMock))
+
+	recipes, err := loader.LoadAll(s.T().Context(), repository)
+
+	// Listed recipes first, hidden ones left aside
+	s.Require().NoError(err)
+	s.Equal([]app.Recipe{
+		recipeMocks["foo"],
+		recipeMocks["bar"],
+		recipeMocks["qux"],
+	}, recipes)
+	handlerMock.AssertExpectations(s.T())
+}
+
+func (s *LoaderSuite) TestLoadAllCancel() {
+	repositoryURL := filepath.FromSlash("testdata/LoaderSuite/TestLoadAll/repository")
+
+	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
+		getter.NewFileLoaderHandler(log.Discard),
+	))
+	repository, _ := repositoryLoader.Load(s.T().Context(), repositoryURL)
+
+	handlerMock := &recipe.LoaderHandlerMock{}
+
+	loader := recipe.NewLoader(log.Discard, recipe.WithLoaderHandlers(handlerMock))
+
+	ctx, cancel := context.WithCancel(s.T().Context())
+	cancel()
+
+	recipes, err := loader.LoadAll(ctx, repository)
+
+	s.Nil(recipes)
+	s.ErrorIs(err, context.Canceled)
+	handlerMock.AssertExpectations(s.T())
+}
+
 func (s *LoaderSuite) TestLoadAllErrors() {
 	loader := recipe.NewLoader(log.Discard)
 
@@ -94,9 +183,9 @@ func (s *LoaderSuite) TestLoadAllErrors() {
 		repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
 			getter.NewFileLoaderHandler(log.Discard),
 		))
-		repository, _ := repositoryLoader.Load(repositoryURL)
+		repository, _ := repositoryLoader.Load(s.T().Context(), repositoryURL)
 
-		recipes, err := loader.LoadAll(repository)
+		recipes, err := loader.LoadAll(s.T().Context(), repository)
 
 		s.Empty(recipes)
 		expectation.ExpectError(s.T(), errors.Expectation{
@@ -1,13 +1,19 @@
 package manifest
 
 import (
+	"github.com/manala/manala/app/migration"
 	"github.com/manala/manala/app/sync"
 )
 
 type Config struct {
-	Description string      `yaml:"description"`
-	Icon        string      `yaml:"icon"`
-	Template    string      `yaml:"template"`
-	Partials    []string    `yaml:"partials"`
-	Sync        []sync.Unit `yaml:"sync"`
+	Description string                           `yaml:"description"`
+	Icon        string                           `yaml:"icon"`
+	Deprecated  string                           `yaml:"deprecated"`
+	ReplacedBy  string                           `yaml:"replaced_by"`
+	Version     string                           `yaml:"version"`
+	Template    string                           `yaml:"template"`
+	Partials    []string                         `yaml:"partials"`
+	Sync        []sync.Unit                      `yaml:"sync"`
+	Merge       map[string]map[string]string     `yaml:"merge"`
+	Migrations  map[string][]migration.Operation `yaml:"migrations"`
 }
@@ -117,13 +117,24 @@ func (i *Inferrer) infer(node ast.MapNode) (map[string]any, error) {
 				if _, ok := optionValue["type"]; !ok {
 					if _, ok := property["enum"]; ok {
 						optionValue["type"] = option.ENUM
-					} else if property["type"] == "string" {
-						optionValue["type"] = option.STRING
 					} else {
-						return yamlannotation.NewError(
-							errors.New("unable to auto-detect option type"),
-							body.Start(),
-						)
+						switch property["type"] {
+						case "string":
+							optionValue["type"] = option.STRING
+						case "boolean":
+							optionValue["type"] = option.BOOLEAN
+						case "integer":
+							optionValue["type"] = option.INTEGER
+						case "number":
+							optionValue["type"] = option.NUMBER
+						case "array":
+							optionValue["type"] = option.ARRAY
+						default:
+							return yamlannotation.NewError(
+								errors.New("unable to auto-detect option type"),
+								body.Start(),
+							)
+						}
 					}
 				}
 
@@ -147,6 +158,42 @@ func (i *Inferrer) infer(node ast.MapNode) (map[string]any, error) {
 						return err
 					}
 					opt = o
+				case option.BOOLEAN:
+					o, err := option.NewBoolean(property, node.GetPath())
+					if err != nil {
+						return yamlannotation.NewError(err, body.Start())
+					}
+					if err := o.UnmarshalJSON(value); err != nil {
+						return err
+					}
+					opt = o
+				case option.INTEGER:
+					o, err := option.NewInteger(property, node.GetPath())
+					if err != nil {
+						return yamlannotation.NewError(err, body.Start())
+					}
+					if err := o.UnmarshalJSON(value); err != nil {
+						return err
+					}
+					opt = o
+				case option.NUMBER:
+					o, err := option.NewNumber(property, node.GetPath())
+					if err != nil {
+						return yamlannotation.NewError(err, body.Start())
+					}
+					if err := o.UnmarshalJSON(value); err != nil {
+						return err
+					}
+					opt = o
+				case option.ARRAY:
+					o, err := option.NewArray(property, node.GetPath())
+					if err != nil {
+						return yamlannotation.NewError(err, body.Start())
+					}
+					if err := o.UnmarshalJSON(value); err != nil {
+						return err
+					}
+					opt = o
 				default:
 					return yamlannotation.NewError(
 						fmt.Errorf("unexpected \"%s\" option type", optionValue["type"]),
@@ -412,6 +412,80 @@ func (s *InferrerSuite) TestOptions() {
 				},
 			},
 		},
+		{
+			test: "Group",
+			src: heredoc.Doc(`
+				# @option {"label": "Foo", "group": "Bar"}
+				foo: bar
+			`),
+			expected: option.Expectations{
+				{
+					Type:  &option.String{},
+					Label: "Foo",
+					Name:  "foo",
+					Group: "Bar",
+				},
+			},
+		},
+		{
+			test: "BooleanTypeImplicit",
+			src: heredoc.Doc(`
+				# @option {"label": "Foo"}
+				foo: true
+			`),
+			expected: option.Expectations{
+				{
+					Type:  &option.Boolean{},
+					Label: "Foo",
+					Name:  "foo",
+				},
+			},
+		},
+		{
+			test: "IntegerTypeImplicit",
+			src: heredoc.Doc(`
+				# @option {"label": "Foo"}
+				# @schema {"minimum": 1, "maximum": 10}
+				foo: 5
+			`),
+			expected: option.Expectations{
+				{
+					Type:  &option.Integer{},
+					Label: "Foo",
+					Name:  "foo",
+				},
+			},
+		},
+		{
+			test: "NumberTypeImplicit",
+			src: heredoc.Doc(`
+				# @option {"label": "Foo"}
+				foo: 1.5
+			`),
+			expected: option.Expectations{
+				{
+					Type:  &option.Number{},
+					Label: "Foo",
+					Name:  "foo",
+				},
+			},
+		},
+		{
+			test: "ArrayTypeImplicit",
+			src: heredoc.Doc(`
+				# @option {"label": "Foo"}
+				# @schema {"items": {"enum": ["bar", 123]}}
+				foo: []
+			`),
+			expected: option.Expectations{
+				{
+					Type:   &option.Array{},
+					Label:  "Foo",
+					Name:   "foo",
+					Values: []any{"bar", int64(123)},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -433,6 +507,49 @@ func (s *InferrerSuite) TestOptions() {
 	}
 }
 
+func (s *InferrerSuite) TestOptionsCondition() {
+	node, err := yamlparser.Parse([]byte(heredoc.Doc(`
+		# @option {"label": "Database"}
+		# @schema {"enum": [null, "mysql", "postgresql"]}
+		database: ~
+		# @option {"label": "Database version", "when": "eq .database \"mysql\""}
+		# @schema {"enum": ["8.0", "8.4"]}
+		database_version: "8.0"
+	`)))
+	s.Require().NoError(err)
+
+	var options []app.RecipeOption
+
+	inf := manifest.Inferrer{
+		Schema:  &map[string]any{},
+		Options: &options,
+	}
+	s.Require().NoError(inf.Infer(node))
+	s.Require().Len(options, 2)
+
+	tests := []struct {
+		test     string
+		vars     map[string]any
+		expected bool
+	}{
+		{test: "Active", vars: map[string]any{"database": "mysql"}, expected: true},
+		{test: "Inactive", vars: map[string]any{"database": nil}, expected: false},
+		{test: "Missing", vars: map[string]any{}, expected: false},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			active, err := options[0].Active(&test.vars)
+			s.Require().NoError(err)
+			s.True(active)
+
+			active, err = options[1].Active(&test.vars)
+			s.Require().NoError(err)
+			s.Equal(test.expected, active)
+		})
+	}
+}
+
 func (s *InferrerSuite) TestOptionErrors() {
 	tests := []struct {
 		test     string
@@ -479,7 +596,7 @@ func (s *InferrerSuite) TestOptionErrors() {
 					validationtest.ViolationExpectation{
 						Location: "/type",
 						Position: [2]int{1, 38},
-						Err:      expectation.ErrorMessage("value must be one of 'string', 'enum'"),
+						Err:      expectation.ErrorMessage("value must be one of 'string', 'enum', 'boolean', 'integer', 'number', 'array'"),
 					},
 				),
 			},
@@ -496,7 +613,7 @@ func (s *InferrerSuite) TestOptionErrors() {
 					validationtest.ViolationExpectation{
 						Location: "/type",
 						Position: [2]int{1, 38},
-						Err:      expectation.ErrorMessage("value must be one of 'string', 'enum'"),
+						Err:      expectation.ErrorMessage("value must be one of 'string', 'enum', 'boolean', 'integer', 'number', 'array'"),
 					},
 				),
 			},
@@ -610,6 +727,45 @@ func (s *InferrerSuite) TestOptionErrors() {
 				},
 			},
 		},
+		{
+			test: "InvalidCondition",
+			src: heredoc.Doc(`
+				# @option {"label": "Label", "when": "eq (.foo"}
+				foo: bar
+			`),
+			expected: yamlerrorstest.Expectation{
+				Position: [2]int{1, 1},
+				Err:      expectation.ErrorMessage("invalid recipe option condition: unclosed left paren"),
+			},
+		},
+		{
+			test: "InvalidIntegerWrongType",
+			src: heredoc.Doc(`
+				# @option {"label": "Label", "type": "integer"}
+				foo: bar
+			`),
+			expected: yamlerrorstest.Expectation{
+				Position: [2]int{1, 1},
+				Err: yamlannotationtest.ErrorExpectation{
+					Position: [2]int{1, 11},
+					Err:      expectation.ErrorMessage("invalid recipe option integer type"),
+				},
+			},
+		},
+		{
+			test: "InvalidArrayMissingValues",
+			src: heredoc.Doc(`
+				# @option {"label": "Label"}
+				foo: []
+			`),
+			expected: yamlerrorstest.Expectation{
+				Position: [2]int{1, 1},
+				Err: yamlannotationtest.ErrorExpectation{
+					Position: [2]int{1, 11},
+					Err:      expectation.ErrorMessage("invalid recipe option array items enum"),
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -1,27 +1,35 @@
 package manifest
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/migration"
 	"github.com/manala/manala/app/recipe"
 	"github.com/manala/manala/internal/errors/serror"
 	"github.com/manala/manala/internal/errors/source"
 	"github.com/manala/manala/internal/errors/std"
 	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/sync"
 	"github.com/manala/manala/internal/validation"
 	yamlerrors "github.com/manala/manala/internal/yaml/errors"
 	yamlmapping "github.com/manala/manala/internal/yaml/mapping"
 	yamlparser "github.com/manala/manala/internal/yaml/parser"
 	yamlvalidation "github.com/manala/manala/internal/yaml/validation"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/goccy/go-yaml"
 )
 
 const filename = ".manala.yaml"
 
+// migrationPointerSchema validates json pointers targeted by migrations operations.
+var migrationPointerSchema = map[string]any{"type": "string", "pattern": "^/[^/]", "maxLength": 256}
+
 var manifestValidator = validation.MustNewValidator(map[string]any{
 	"type": "object",
 	"properties": map[string]any{
@@ -30,6 +38,9 @@ var manifestValidator = validation.MustNewValidator(map[string]any{
 			"properties": map[string]any{
 				"description": map[string]any{"type": "string", "minLength": 1, "maxLength": 256},
 				"icon":        map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+				"deprecated":  map[string]any{"type": "string", "minLength": 1, "maxLength": 256},
+				"replaced_by": map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+				"version":     map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
 				"template":    map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
 				"partials": map[string]any{
 					"type":  "array",
@@ -39,9 +50,44 @@ var manifestValidator = validation.MustNewValidator(map[string]any{
 					"type":  "array",
 					"items": map[string]any{"type": "string", "minLength": 1, "maxLength": 256},
 				},
+				"merge": map[string]any{
+					"type": "object",
+					"additionalProperties": map[string]any{
+						"type": "object",
+						"additionalProperties": map[string]any{
+							"enum": []any{sync.MergeRecipeWins, sync.MergeProjectWins, sync.MergeAppend},
+						},
+					},
+				},
+				"migrations": map[string]any{
+					"type": "object",
+					"additionalProperties": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"move":   migrationPointerSchema,
+								"rename": migrationPointerSchema,
+								"delete": migrationPointerSchema,
+								"set":    migrationPointerSchema,
+								"to":     map[string]any{"type": "string", "minLength": 1, "maxLength": 256},
+								"value":  map[string]any{},
+							},
+							"additionalProperties": false,
+							"minProperties":        1,
+							"maxProperties":        2,
+							"dependentRequired": map[string]any{
+								"move":   []any{"to"},
+								"rename": []any{"to"},
+								"set":    []any{"value"},
+							},
+						},
+					},
+				},
 			},
 			"additionalProperties": false,
 			"required":             []any{"description"},
+			"dependentRequired":    map[string]any{"replaced_by": []any{"deprecated"}},
 		},
 	},
 	"required": []any{"manala"},
@@ -57,7 +103,7 @@ func NewLoaderHandler(log *log.Log) *LoaderHandler {
 	}
 }
 
-func (handler *LoaderHandler) Handle(query *recipe.LoaderQuery, chain recipe.LoaderHandlerChain) (app.Recipe, error) {
+func (handler *LoaderHandler) Handle(ctx context.Context, query *recipe.LoaderQuery, chain recipe.LoaderHandlerChain) (app.Recipe, error) {
 	dir := filepath.Join(query.Repository.Dir(), query.Name)
 	file := filepath.Join(dir, filename)
 
@@ -67,7 +113,7 @@ func (handler *LoaderHandler) Handle(query *recipe.LoaderQuery, chain recipe.Loa
 	if fileInfo, err := os.Stat(file); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			// Chain
-			return chain.Next(query)
+			return chain.Next(ctx, query)
 		}
 
 		return nil, serror.New("unable to stat recipe manifest").
@@ -140,6 +186,18 @@ func (handler *LoaderHandler) Handle(query *recipe.LoaderQuery, chain recipe.Loa
 
 	handler.log.Debug("recipe manifest loaded", "handler", "manifest", "file", file)
 
+	// Version & migrations
+	if recipe.config.Version != "" {
+		if _, err := semver.NewVersion(recipe.config.Version); err != nil {
+			return nil, serror.New("invalid recipe version").
+				With("file", file, "version", recipe.config.Version)
+		}
+	}
+
+	if recipe.migrations, err = handler.migrations(file, recipe.config.Migrations); err != nil {
+		return nil, err
+	}
+
 	// Decode vars
 	if err := yaml.NodeToValue(node, &recipe.vars); err != nil {
 		return nil, serror.New("unable to decode recipe manifest vars").
@@ -158,3 +216,38 @@ func (handler *LoaderHandler) Handle(query *recipe.LoaderQuery, chain recipe.Loa
 
 	return recipe, nil
 }
+
+// migrations returns recipe migrations, sorted by versions.
+func (handler *LoaderHandler) migrations(file string, config map[string][]migration.Operation) ([]*migration.Migration, error) {
+	migrations := make([]*migration.Migration, 0, len(config))
+	versions := map[*migration.Migration]*semver.Version{}
+
+	for version, operations := range config {
+		semVersion, err := semver.NewVersion(version)
+		if err != nil {
+			return nil, serror.New("invalid recipe migration version").
+				With("file", file, "version", version)
+		}
+
+		for i, operation := range operations {
+			if err := operation.Validate(); err != nil {
+				return nil, serror.New("invalid recipe migration operation").
+					With("file", file, "version", version, "index", i).
+					WithErr(err)
+			}
+		}
+
+		versionMigration := &migration.Migration{
+			Version:    version,
+			Operations: operations,
+		}
+		migrations = append(migrations, versionMigration)
+		versions[versionMigration] = semVersion
+	}
+
+	slices.SortFunc(migrations, func(a, b *migration.Migration) int {
+		return versions[a].Compare(versions[b])
+	})
+
+	return migrations, nil
+}
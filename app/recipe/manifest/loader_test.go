@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/migration"
 	"github.com/manala/manala/app/recipe"
 	"github.com/manala/manala/app/recipe/manifest"
 	"github.com/manala/manala/app/recipe/option"
@@ -37,6 +38,19 @@ func (s *LoaderSuite) TestHandle() {
 	s.Equal("recipe", recipe.Name())
 	s.Equal("description", recipe.Description())
 	s.Equal("icon", recipe.Icon())
+	s.Equal("deprecated", recipe.Deprecated())
+	s.Equal("replacement", recipe.ReplacedBy())
+	s.Equal("2.0.0", recipe.Version())
+	s.Equal([]*migration.Migration{
+		{Version: "1.10.0", Operations: []migration.Operation{
+			{Move: "/bar", To: "/baz/bar"},
+			{Rename: "/baz", To: "qux"},
+			{Delete: "/qux"},
+		}},
+		{Version: "2.0.0", Operations: []migration.Operation{
+			{Set: "/foo"},
+		}},
+	}, recipe.Migrations())
 	s.Equal(filepath.Join(repositoryURL, "recipe", "template"), recipe.Template())
 	s.Equal([]string{
 		filepath.Join(repositoryURL, "recipe", "partial.tmpl"),
@@ -50,6 +64,12 @@ func (s *LoaderSuite) TestHandle() {
 		{Source: "src_file", Destination: "dst_file"},
 		{Source: "src_dir/file", Destination: "dst_dir/file"},
 	}, recipe.Sync())
+	s.Equal(map[string]map[string]string{
+		"file.yaml": {
+			"/foo":     "project-wins",
+			"/foo/bar": "append",
+		},
+	}, recipe.MergeStrategies())
 	s.Equal(repositoryURL, recipe.Repository().URL())
 	s.Equal(map[string]any{"foo": nil, "bar": "baz"}, recipe.Vars())
 	s.Equal(map[string]any{
@@ -436,6 +456,66 @@ func (s *LoaderSuite) TestHandleErrors() {
 				),
 			},
 		},
+		// Config - Deprecated
+		{
+			test: "ConfigDeprecatedEmpty",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:3:15
+
+						  1 │ manala:
+						  2 │   description: description
+						▶ 3 │   deprecated: ""
+						    ├───────────────╯ minLength: got 0, want 1
+					`,
+						filepath.Join(dir, "ConfigDeprecatedEmpty", "repository", "recipe", ".manala.yaml"),
+					)),
+				),
+			},
+		},
+		// Config - Replaced by
+		{
+			test: "ConfigReplacedByEmpty",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:4:16
+
+						  1 │ manala:
+						  2 │   description: description
+						  3 │   deprecated: deprecated
+						▶ 4 │   replaced_by: ""
+						    ├────────────────╯ minLength: got 0, want 1
+					`,
+						filepath.Join(dir, "ConfigReplacedByEmpty", "repository", "recipe", ".manala.yaml"),
+					)),
+				),
+			},
+		},
+		{
+			test: "ConfigReplacedByWithoutDeprecated",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:1:1
+
+						▶ 1 │ manala:
+						    ├─╯ properties 'deprecated' required, if 'replaced_by' exists
+						  2 │   description: description
+						  3 │   replaced_by: replacement
+					`,
+						filepath.Join(dir, "ConfigReplacedByWithoutDeprecated", "repository", "recipe", ".manala.yaml"),
+					)),
+				),
+			},
+		},
 		// Config - Partials
 		{
 			test: "ConfigPartialsNotArray",
@@ -598,6 +678,112 @@ func (s *LoaderSuite) TestHandleErrors() {
 				),
 			},
 		},
+		// Config - Merge
+		{
+			test: "ConfigMergeNotMap",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:3:10
+
+						  1 │ manala:
+						  2 │   description: description
+						▶ 3 │   merge: foo
+						    ├──────────╯ got string, want object
+					`,
+						filepath.Join(dir, "ConfigMergeNotMap", "repository", "recipe", ".manala.yaml"),
+					)),
+				),
+			},
+		},
+		{
+			test: "ConfigMergeStrategyInvalid",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:5:13
+
+						  2 │   description: description
+						  3 │   merge:
+						  4 │     file.yaml:
+						▶ 5 │       /foo: foo
+						    ├─────────────╯ value must be one of 'recipe-wins', 'project-wins', 'append'
+					`,
+						filepath.Join(dir, "ConfigMergeStrategyInvalid", "repository", "recipe", ".manala.yaml"),
+					)),
+				),
+			},
+		},
+		// Config - Version
+		{
+			test: "ConfigVersionInvalid",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe version",
+				Attrs: [][2]any{
+					{"file", filepath.Join(dir, "ConfigVersionInvalid", "repository", "recipe", ".manala.yaml")},
+					{"version", "foo"},
+				},
+			},
+		},
+		// Config - Migrations
+		{
+			test: "ConfigMigrationsVersionInvalid",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe migration version",
+				Attrs: [][2]any{
+					{"file", filepath.Join(dir, "ConfigMigrationsVersionInvalid", "repository", "recipe", ".manala.yaml")},
+					{"version", "foo"},
+				},
+			},
+		},
+		{
+			test: "ConfigMigrationsOperationInvalid",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe migration operation",
+				Attrs: [][2]any{
+					{"file", filepath.Join(dir, "ConfigMigrationsOperationInvalid", "repository", "recipe", ".manala.yaml")},
+					{"version", "1.0.0"},
+					{"index", 0},
+				},
+				Err: expectation.ErrorMessage("to only expected along with move or rename"),
+			},
+		},
+		{
+			test: "ConfigMigrationsOperationManala",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe migration operation",
+				Attrs: [][2]any{
+					{"file", filepath.Join(dir, "ConfigMigrationsOperationManala", "repository", "recipe", ".manala.yaml")},
+					{"version", "1.0.0"},
+					{"index", 0},
+				},
+				Err: expectation.ErrorMessage("project manifest config is out of reach"),
+			},
+		},
+		{
+			test: "ConfigMigrationsOperationSetWithoutValue",
+			expected: serrortest.Expectation{
+				Msg: "invalid recipe manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:5:12
+
+						  2 │   description: description
+						  3 │   migrations:
+						  4 │     1.0.0:
+						▶ 5 │       - set: /foo
+						    ├────────────╯ properties 'value' required, if 'set' exists
+					`,
+						filepath.Join(dir, "ConfigMigrationsOperationSetWithoutValue", "repository", "recipe", ".manala.yaml"),
+					)),
+				),
+			},
+		},
 		{
 			test: "AnnotationUnparsableSingleLine",
 			expected: serrortest.Expectation{
@@ -699,10 +885,10 @@ func (s *LoaderSuite) handle(repositoryURL string) (app.Recipe, error) {
 	repositoryLoader := repository.NewLoader(repository.WithLoaderHandlers(
 		getter.NewFileLoaderHandler(log.Discard),
 	))
-	repository, _ := repositoryLoader.Load(repositoryURL)
+	repository, _ := repositoryLoader.Load(s.T().Context(), repositoryURL)
 
 	chainMock := &recipe.LoaderHandlerChainMock{}
 
 	handler := manifest.NewLoaderHandler(log.Discard)
-	return handler.Handle(&recipe.LoaderQuery{Repository: repository, Name: "recipe"}, chainMock)
+	return handler.Handle(s.T().Context(), &recipe.LoaderQuery{Repository: repository, Name: "recipe"}, chainMock)
 }
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/migration"
 	"github.com/manala/manala/app/sync"
 )
 
@@ -17,6 +18,7 @@ type Recipe struct {
 	vars       map[string]any
 	schema     map[string]any
 	options    []app.RecipeOption
+	migrations []*migration.Migration
 }
 
 func (recipe *Recipe) Dir() string {
@@ -35,6 +37,39 @@ func (recipe *Recipe) Icon() string {
 	return recipe.config.Icon
 }
 
+func (recipe *Recipe) Category() string {
+	if repositoryRecipe, ok := recipe.repositoryRecipe(); ok {
+		return repositoryRecipe.Category()
+	}
+
+	return ""
+}
+
+func (recipe *Recipe) Deprecated() string {
+	// Recipe own deprecation prevails over repository manifest one
+	if recipe.config.Deprecated != "" {
+		return recipe.config.Deprecated
+	}
+
+	if repositoryRecipe, ok := recipe.repositoryRecipe(); ok {
+		return repositoryRecipe.Deprecated()
+	}
+
+	return ""
+}
+
+func (recipe *Recipe) ReplacedBy() string {
+	return recipe.config.ReplacedBy
+}
+
+func (recipe *Recipe) Version() string {
+	return recipe.config.Version
+}
+
+func (recipe *Recipe) Migrations() []*migration.Migration {
+	return recipe.migrations
+}
+
 func (recipe *Recipe) Template() string {
 	if recipe.config.Template != "" {
 		return filepath.Join(recipe.Dir(), recipe.config.Template)
@@ -45,12 +80,17 @@ func (recipe *Recipe) Template() string {
 func (recipe *Recipe) Partials() []string {
 	var partials []string
 
+	// Repository shared partials come first, so that recipe ones could override them
+	if manifest := recipe.repository.Manifest(); manifest != nil {
+		partials = append(partials, manifest.Partials()...)
+	}
+
 	for _, partial := range recipe.config.Partials {
 		partials = append(partials, filepath.Join(recipe.Dir(), partial))
 	}
 
 	// Legacy: if no partials defined, check for _helpers.tmpl
-	if len(partials) == 0 {
+	if len(recipe.config.Partials) == 0 {
 		helpers := filepath.Join(recipe.Dir(), "_helpers.tmpl")
 		if _, err := os.Stat(helpers); err == nil {
 			partials = append(partials, helpers)
@@ -64,6 +104,10 @@ func (recipe *Recipe) Sync() []sync.Unit {
 	return recipe.config.Sync
 }
 
+func (recipe *Recipe) MergeStrategies() map[string]map[string]string {
+	return recipe.config.Merge
+}
+
 func (recipe *Recipe) Repository() app.Repository {
 	return recipe.repository
 }
@@ -98,3 +142,13 @@ func (recipe *Recipe) Watches() ([]string, error) {
 
 	return dirs, nil
 }
+
+// repositoryRecipe returns the recipe entry of its repository manifest, if any.
+func (recipe *Recipe) repositoryRecipe() (app.RepositoryManifestRecipe, bool) {
+	manifest := recipe.repository.Manifest()
+	if manifest == nil {
+		return nil, false
+	}
+
+	return manifest.Recipe(recipe.name)
+}
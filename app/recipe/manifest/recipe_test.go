@@ -30,6 +30,8 @@ func (s *RecipeSuite) Test() {
 		Sync:        []sync.Unit{{}},
 	}
 	repositoryMock := &mocks.Repository{}
+	repositoryMock.
+		On("Manifest").Return(nil)
 	vars := map[string]any{"foo": "bar"}
 	schema := map[string]any{"bar": "baz"}
 	options := []app.RecipeOption{&option.String{}}
@@ -48,6 +50,9 @@ func (s *RecipeSuite) Test() {
 	s.Equal(name, recipe.Name())
 	s.Equal(config.Description, recipe.Description())
 	s.Equal(config.Icon, recipe.Icon())
+	s.Empty(recipe.Category())
+	s.Empty(recipe.Deprecated())
+	s.Empty(recipe.ReplacedBy())
 	s.Equal([]string{filepath.Join(dir, configPartial)}, recipe.Partials())
 	s.Equal(config.Sync, recipe.Sync())
 	s.Equal(repositoryMock, recipe.Repository())
@@ -64,6 +69,77 @@ func (s *RecipeSuite) Test() {
 	}, watches)
 }
 
+func (s *RecipeSuite) TestRepositoryManifest() {
+	manifestRecipeMock := &mocks.RepositoryManifestRecipe{}
+	manifestRecipeMock.
+		On("Category").Return("category").
+		On("Deprecated").Return("deprecated")
+
+	manifestMock := &mocks.RepositoryManifest{}
+	manifestMock.
+		On("Recipe", "name").Return(manifestRecipeMock, true).
+		On("Partials").Return([]string{"repository_partial"})
+
+	repositoryMock := &mocks.Repository{}
+	repositoryMock.
+		On("Manifest").Return(manifestMock)
+
+	recipe := &Recipe{
+		dir:  "dir",
+		name: "name",
+		config: &Config{
+			Partials: []string{"partial"},
+		},
+		repository: repositoryMock,
+	}
+
+	s.Equal("category", recipe.Category())
+	s.Equal("deprecated", recipe.Deprecated())
+	s.Equal([]string{
+		"repository_partial",
+		filepath.Join("dir", "partial"),
+	}, recipe.Partials())
+}
+
+func (s *RecipeSuite) TestDeprecated() {
+	manifestRecipeMock := &mocks.RepositoryManifestRecipe{}
+	manifestRecipeMock.
+		On("Deprecated").Return("repository deprecated")
+
+	manifestMock := &mocks.RepositoryManifest{}
+	manifestMock.
+		On("Recipe", "name").Return(manifestRecipeMock, true)
+
+	repositoryMock := &mocks.Repository{}
+	repositoryMock.
+		On("Manifest").Return(manifestMock)
+
+	s.Run("Repository", func() {
+		recipe := &Recipe{
+			name:       "name",
+			config:     &Config{},
+			repository: repositoryMock,
+		}
+
+		s.Equal("repository deprecated", recipe.Deprecated())
+		s.Empty(recipe.ReplacedBy())
+	})
+
+	s.Run("Recipe", func() {
+		recipe := &Recipe{
+			name: "name",
+			config: &Config{
+				Deprecated: "recipe deprecated",
+				ReplacedBy: "replacement",
+			},
+			repository: repositoryMock,
+		}
+
+		s.Equal("recipe deprecated", recipe.Deprecated())
+		s.Equal("replacement", recipe.ReplacedBy())
+	})
+}
+
 func (s *RecipeSuite) TestTemplate() {
 	s.Run("WithConfigTemplate", func() {
 		dir := "dir"
@@ -93,9 +169,13 @@ func (s *RecipeSuite) TestLegacyPartials() {
 		config := &Config{
 			Partials: []string{"foo", "bar"},
 		}
+		repositoryMock := &mocks.Repository{}
+		repositoryMock.
+			On("Manifest").Return(nil)
 		recipe := &Recipe{
-			dir:    dir,
-			config: config,
+			dir:        dir,
+			config:     config,
+			repository: repositoryMock,
 		}
 		s.Equal([]string{
 			filepath.Join(dir, "foo"),
@@ -105,9 +185,13 @@ func (s *RecipeSuite) TestLegacyPartials() {
 	s.Run("WithoutConfigPartials", func() {
 		dir := filepath.FromSlash("testdata/RecipeSuite/TestLegacyPartials/repository/recipe")
 		config := &Config{}
+		repositoryMock := &mocks.Repository{}
+		repositoryMock.
+			On("Manifest").Return(nil)
 		recipe := &Recipe{
-			dir:    dir,
-			config: config,
+			dir:        dir,
+			config:     config,
+			repository: repositoryMock,
 		}
 		s.Equal([]string{
 			filepath.Join(dir, "_helpers.tmpl"),
@@ -1,6 +1,8 @@
 package name
 
 import (
+	"context"
+
 	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/recipe"
 	"github.com/manala/manala/internal/log"
@@ -18,12 +20,12 @@ func NewProcessorLoaderHandler(log *log.Log, processor *Processor) *ProcessorLoa
 	}
 }
 
-func (handler *ProcessorLoaderHandler) Handle(query *recipe.LoaderQuery, chain recipe.LoaderHandlerChain) (app.Recipe, error) {
+func (handler *ProcessorLoaderHandler) Handle(ctx context.Context, query *recipe.LoaderQuery, chain recipe.LoaderHandlerChain) (app.Recipe, error) {
 	handler.log.Debug("handle recipe name", "handler", "name.processor", "name", query.Name)
 
 	// Process query name
 	query.Name = handler.processor.Process(query.Name)
 
 	// Chain
-	return chain.Next(query)
+	return chain.Next(ctx, query)
 }
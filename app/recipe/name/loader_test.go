@@ -8,6 +8,7 @@ import (
 	"github.com/manala/manala/app/testing/mocks"
 	"github.com/manala/manala/internal/log"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -28,9 +29,9 @@ func (s *LoaderSuite) TestProcessorHandler() {
 
 	chainMock := &recipe.LoaderHandlerChainMock{}
 	chainMock.
-		On("Next", &recipe.LoaderQuery{Repository: repositoryMock, Name: "name"}).Return(recipeMock, nil)
+		On("Next", mock.Anything, &recipe.LoaderQuery{Repository: repositoryMock, Name: "name"}).Return(recipeMock, nil)
 
-	recipe, err := handler.Handle(&recipe.LoaderQuery{Repository: repositoryMock, Name: ""}, chainMock)
+	recipe, err := handler.Handle(s.T().Context(), &recipe.LoaderQuery{Repository: repositoryMock, Name: ""}, chainMock)
 
 	s.Require().NoError(err)
 	s.Equal(recipeMock, recipe)
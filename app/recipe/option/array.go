@@ -0,0 +1,111 @@
+package option
+
+import (
+	"errors"
+
+	jsondecoder "github.com/manala/manala/internal/json/decoder"
+	jsonnumber "github.com/manala/manala/internal/json/number"
+	"github.com/manala/manala/internal/validation"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/gosimple/slug"
+)
+
+const ARRAY = "array"
+
+type Array struct {
+	option    option
+	values    []any
+	pointer   jsonpointer.Pointer
+	validator *validation.Validator
+}
+
+func NewArray(sch map[string]any, path string) (*Array, error) {
+	// Schema type *MUST* be array
+	if t, ok := sch["type"]; !ok || t != "array" {
+		return nil, errors.New("invalid recipe option array type")
+	}
+
+	// Schema items *MUST* contains enum
+	items, _ := sch["items"].(map[string]any)
+	enum, ok := items["enum"].([]any)
+	if !ok {
+		return nil, errors.New("invalid recipe option array items enum")
+	}
+
+	if len(enum) == 0 {
+		return nil, errors.New("empty recipe option array items enum")
+	}
+
+	o := &Array{}
+
+	// Values
+	o.values = make([]any, len(enum))
+	for i := range enum {
+		if value, ok := jsonnumber.NumberType(enum[i]); ok {
+			o.values[i] = value.Normalize()
+		} else {
+			o.values[i] = enum[i]
+		}
+	}
+
+	// Pointer
+	var err error
+	if o.pointer, err = jsonpointer.New(yamlpath.ToJSONPointer(path)); err != nil {
+		return nil, err
+	}
+
+	// Validator
+	if o.validator, err = validation.NewValidator(sch); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (o *Array) UnmarshalJSON(bytes []byte) error {
+	// Decode as generic option
+	if err := jsondecoder.Decode(bytes, &o.option); err != nil {
+		return err
+	}
+
+	return o.option.compile()
+}
+
+func (o *Array) Name() string {
+	if o.option.Name == "" {
+		o.option.Name = slug.Make(o.option.Label)
+	}
+	return o.option.Name
+}
+
+func (o *Array) Label() string { return o.option.Label }
+func (o *Array) Help() string  { return o.option.Help }
+func (o *Array) Group() string { return o.option.Group }
+
+func (o *Array) Active(vars *map[string]any) (bool, error) { return o.option.active(vars) }
+
+func (o *Array) Values() []any { return o.values }
+
+func (o *Array) Pointer() string { return o.pointer.String() }
+
+func (o *Array) Get(data *map[string]any) ([]any, error) {
+	value, _, err := o.pointer.Get(data)
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := value.([]any); ok {
+		return value, nil
+	}
+	return nil, nil
+}
+
+func (o *Array) Set(data *map[string]any, v []any) error {
+	_, err := o.pointer.Set(data, v)
+	return err
+}
+
+func (o *Array) Validate(v []any) error {
+	return validate(o.validator, v)
+}
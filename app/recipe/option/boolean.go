@@ -0,0 +1,86 @@
+package option
+
+import (
+	"errors"
+
+	jsondecoder "github.com/manala/manala/internal/json/decoder"
+	"github.com/manala/manala/internal/validation"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/gosimple/slug"
+)
+
+const BOOLEAN = "boolean"
+
+type Boolean struct {
+	option    option
+	pointer   jsonpointer.Pointer
+	validator *validation.Validator
+}
+
+func NewBoolean(sch map[string]any, path string) (*Boolean, error) {
+	// Schema type *MUST* be boolean
+	if t, ok := sch["type"]; !ok || t != "boolean" {
+		return nil, errors.New("invalid recipe option boolean type")
+	}
+
+	o := &Boolean{}
+
+	// Pointer
+	var err error
+	if o.pointer, err = jsonpointer.New(yamlpath.ToJSONPointer(path)); err != nil {
+		return nil, err
+	}
+
+	// Validator
+	if o.validator, err = validation.NewValidator(sch); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (o *Boolean) UnmarshalJSON(bytes []byte) error {
+	// Decode as generic option
+	if err := jsondecoder.Decode(bytes, &o.option); err != nil {
+		return err
+	}
+
+	return o.option.compile()
+}
+
+func (o *Boolean) Name() string {
+	if o.option.Name == "" {
+		o.option.Name = slug.Make(o.option.Label)
+	}
+	return o.option.Name
+}
+
+func (o *Boolean) Label() string { return o.option.Label }
+func (o *Boolean) Help() string  { return o.option.Help }
+func (o *Boolean) Group() string { return o.option.Group }
+
+func (o *Boolean) Active(vars *map[string]any) (bool, error) { return o.option.active(vars) }
+
+func (o *Boolean) Pointer() string { return o.pointer.String() }
+
+func (o *Boolean) Get(data *map[string]any) (bool, error) {
+	value, _, err := o.pointer.Get(data)
+	if err != nil {
+		return false, err
+	}
+	if value, ok := value.(bool); ok {
+		return value, nil
+	}
+	return false, nil
+}
+
+func (o *Boolean) Set(data *map[string]any, v bool) error {
+	_, err := o.pointer.Set(data, v)
+	return err
+}
+
+func (o *Boolean) Validate(v bool) error {
+	return validate(o.validator, v)
+}
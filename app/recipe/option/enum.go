@@ -5,6 +5,7 @@ import (
 
 	jsondecoder "github.com/manala/manala/internal/json/decoder"
 	jsonnumber "github.com/manala/manala/internal/json/number"
+	"github.com/manala/manala/internal/validation"
 	yamlpath "github.com/manala/manala/internal/yaml/path"
 
 	"github.com/go-openapi/jsonpointer"
@@ -14,9 +15,10 @@ import (
 const ENUM = "enum"
 
 type Enum struct {
-	option  option
-	values  []any
-	pointer jsonpointer.Pointer
+	option    option
+	values    []any
+	pointer   jsonpointer.Pointer
+	validator *validation.Validator
 }
 
 func NewEnum(sch map[string]any, path string) (*Enum, error) {
@@ -48,6 +50,11 @@ func NewEnum(sch map[string]any, path string) (*Enum, error) {
 		return nil, err
 	}
 
+	// Validator
+	if o.validator, err = validation.NewValidator(map[string]any{"enum": enum}); err != nil {
+		return nil, err
+	}
+
 	return o, nil
 }
 
@@ -57,7 +64,7 @@ func (o *Enum) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 
-	return nil
+	return o.option.compile()
 }
 
 func (o *Enum) Name() string {
@@ -69,9 +76,14 @@ func (o *Enum) Name() string {
 
 func (o *Enum) Label() string { return o.option.Label }
 func (o *Enum) Help() string  { return o.option.Help }
+func (o *Enum) Group() string { return o.option.Group }
+
+func (o *Enum) Active(vars *map[string]any) (bool, error) { return o.option.active(vars) }
 
 func (o *Enum) Values() []any { return o.values }
 
+func (o *Enum) Pointer() string { return o.pointer.String() }
+
 func (o *Enum) Get(data *map[string]any) (any, error) {
 	value, _, err := o.pointer.Get(data)
 	return value, err
@@ -81,3 +93,7 @@ func (o *Enum) Set(data *map[string]any, v any) error {
 	_, err := o.pointer.Set(data, v)
 	return err
 }
+
+func (o *Enum) Validate(v any) error {
+	return validate(o.validator, v)
+}
@@ -13,9 +13,10 @@ type Expectation struct {
 	Type  any
 	Label string
 	Name  string
+	Group string
 	// String
 	MaxLength int
-	// Enum
+	// Enum & Array
 	Values []any
 }
 
@@ -26,6 +27,7 @@ func (a Expectation) Expect(t *testing.T, opt app.RecipeOption) {
 
 	assert.Equal(t, a.Label, opt.Label(), "label not equal")
 	assert.Equal(t, a.Name, opt.Name(), "name not equal")
+	assert.Equal(t, a.Group, opt.Group(), "group not equal")
 
 	// String
 	if opt, ok := opt.(*String); ok {
@@ -36,6 +38,11 @@ func (a Expectation) Expect(t *testing.T, opt app.RecipeOption) {
 	if opt, ok := opt.(*Enum); ok {
 		assert.Equal(t, a.Values, opt.Values(), "values not equals")
 	}
+
+	// Array
+	if opt, ok := opt.(*Array); ok {
+		assert.Equal(t, a.Values, opt.Values(), "values not equals")
+	}
 }
 
 func ExpectOption(t *testing.T, expectation Expectation, opt app.RecipeOption) {
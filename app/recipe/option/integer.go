@@ -0,0 +1,80 @@
+package option
+
+import (
+	"errors"
+
+	jsondecoder "github.com/manala/manala/internal/json/decoder"
+	"github.com/manala/manala/internal/validation"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/gosimple/slug"
+)
+
+const INTEGER = "integer"
+
+type Integer struct {
+	option    option
+	pointer   jsonpointer.Pointer
+	validator *validation.Validator
+}
+
+func NewInteger(sch map[string]any, path string) (*Integer, error) {
+	// Schema type *MUST* be integer
+	if t, ok := sch["type"]; !ok || t != "integer" {
+		return nil, errors.New("invalid recipe option integer type")
+	}
+
+	o := &Integer{}
+
+	// Pointer
+	var err error
+	if o.pointer, err = jsonpointer.New(yamlpath.ToJSONPointer(path)); err != nil {
+		return nil, err
+	}
+
+	// Validator, honoring schema minimum and maximum
+	if o.validator, err = validation.NewValidator(sch); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (o *Integer) UnmarshalJSON(bytes []byte) error {
+	// Decode as generic option
+	if err := jsondecoder.Decode(bytes, &o.option); err != nil {
+		return err
+	}
+
+	return o.option.compile()
+}
+
+func (o *Integer) Name() string {
+	if o.option.Name == "" {
+		o.option.Name = slug.Make(o.option.Label)
+	}
+	return o.option.Name
+}
+
+func (o *Integer) Label() string { return o.option.Label }
+func (o *Integer) Help() string  { return o.option.Help }
+func (o *Integer) Group() string { return o.option.Group }
+
+func (o *Integer) Active(vars *map[string]any) (bool, error) { return o.option.active(vars) }
+
+func (o *Integer) Pointer() string { return o.pointer.String() }
+
+func (o *Integer) Get(data *map[string]any) (any, error) {
+	value, _, err := o.pointer.Get(data)
+	return value, err
+}
+
+func (o *Integer) Set(data *map[string]any, v any) error {
+	_, err := o.pointer.Set(data, v)
+	return err
+}
+
+func (o *Integer) Validate(v any) error {
+	return validate(o.validator, v)
+}
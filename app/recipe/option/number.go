@@ -0,0 +1,80 @@
+package option
+
+import (
+	"errors"
+
+	jsondecoder "github.com/manala/manala/internal/json/decoder"
+	"github.com/manala/manala/internal/validation"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/gosimple/slug"
+)
+
+const NUMBER = "number"
+
+type Number struct {
+	option    option
+	pointer   jsonpointer.Pointer
+	validator *validation.Validator
+}
+
+func NewNumber(sch map[string]any, path string) (*Number, error) {
+	// Schema type *MUST* be number
+	if t, ok := sch["type"]; !ok || t != "number" {
+		return nil, errors.New("invalid recipe option number type")
+	}
+
+	o := &Number{}
+
+	// Pointer
+	var err error
+	if o.pointer, err = jsonpointer.New(yamlpath.ToJSONPointer(path)); err != nil {
+		return nil, err
+	}
+
+	// Validator, honoring schema minimum and maximum
+	if o.validator, err = validation.NewValidator(sch); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (o *Number) UnmarshalJSON(bytes []byte) error {
+	// Decode as generic option
+	if err := jsondecoder.Decode(bytes, &o.option); err != nil {
+		return err
+	}
+
+	return o.option.compile()
+}
+
+func (o *Number) Name() string {
+	if o.option.Name == "" {
+		o.option.Name = slug.Make(o.option.Label)
+	}
+	return o.option.Name
+}
+
+func (o *Number) Label() string { return o.option.Label }
+func (o *Number) Help() string  { return o.option.Help }
+func (o *Number) Group() string { return o.option.Group }
+
+func (o *Number) Active(vars *map[string]any) (bool, error) { return o.option.active(vars) }
+
+func (o *Number) Pointer() string { return o.pointer.String() }
+
+func (o *Number) Get(data *map[string]any) (any, error) {
+	value, _, err := o.pointer.Get(data)
+	return value, err
+}
+
+func (o *Number) Set(data *map[string]any, v any) error {
+	_, err := o.pointer.Set(data, v)
+	return err
+}
+
+func (o *Number) Validate(v any) error {
+	return validate(o.validator, v)
+}
@@ -1,16 +1,24 @@
 package option
 
 import (
+	"errors"
+	"strings"
+	"text/template"
+
 	"github.com/manala/manala/internal/validation"
+
+	"github.com/Masterminds/sprig/v3"
 )
 
 var Validator = validation.MustNewValidator(map[string]any{
 	"type": "object",
 	"properties": map[string]any{
-		"type":  map[string]any{"enum": []any{STRING, ENUM}},
+		"type":  map[string]any{"enum": []any{STRING, ENUM, BOOLEAN, INTEGER, NUMBER, ARRAY}},
 		"name":  map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
 		"label": map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
 		"help":  map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+		"group": map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+		"when":  map[string]any{"type": "string", "minLength": 1, "maxLength": 256},
 	},
 	"required":             []any{"label"},
 	"additionalProperties": false,
@@ -20,4 +28,50 @@ type option struct {
 	Name  string `yaml:"name"`
 	Label string `yaml:"label"`
 	Help  string `yaml:"help"`
+	Group string `yaml:"group"`
+	When  string `yaml:"when"`
+	when  *template.Template
+}
+
+// compile compiles option "when" condition, if any, as a template pipeline.
+func (o *option) compile() error {
+	if o.When == "" {
+		return nil
+	}
+
+	var err error
+	if o.when, err = template.New("when").
+		Funcs(sprig.TxtFuncMap()).
+		Parse("{{ if " + o.When + " }}true{{ end }}"); err != nil {
+		return errors.New("invalid recipe option condition: " + strings.TrimPrefix(err.Error(), "template: when:1: "))
+	}
+
+	return nil
+}
+
+// active evaluates option "when" condition against vars, an option without condition being always active.
+func (o *option) active(vars *map[string]any) (bool, error) {
+	if o.when == nil {
+		return true, nil
+	}
+
+	var buffer strings.Builder
+	if err := o.when.Execute(&buffer, *vars); err != nil {
+		return false, errors.New("unable to evaluate recipe option condition: " + strings.TrimPrefix(err.Error(), "template: when:1:"))
+	}
+
+	return buffer.String() == "true", nil
+}
+
+// validate validates a value, returning its first violation, if any.
+func validate(validator *validation.Validator, v any) error {
+	if err := validator.Validate(v); err != nil {
+		if violations, ok := errors.AsType[validation.Violations](err); ok {
+			if violation, ok := violations.First(); ok {
+				return violation
+			}
+		}
+		return err
+	}
+	return nil
 }
@@ -54,7 +54,7 @@ func (o *String) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 
-	return nil
+	return o.option.compile()
 }
 
 func (o *String) Name() string {
@@ -66,9 +66,14 @@ func (o *String) Name() string {
 
 func (o *String) Label() string { return o.option.Label }
 func (o *String) Help() string  { return o.option.Help }
+func (o *String) Group() string { return o.option.Group }
+
+func (o *String) Active(vars *map[string]any) (bool, error) { return o.option.active(vars) }
 
 func (o *String) MaxLength() int { return o.maxLength }
 
+func (o *String) Pointer() string { return o.pointer.String() }
+
 func (o *String) Get(data *map[string]any) (string, error) {
 	value, _, err := o.pointer.Get(data)
 	if err != nil {
@@ -86,13 +91,5 @@ func (o *String) Set(data *map[string]any, v string) error {
 }
 
 func (o *String) Validate(v string) error {
-	if err := o.validator.Validate(v); err != nil {
-		if violations, ok := errors.AsType[validation.Violations](err); ok {
-			if violation, ok := violations.First(); ok {
-				return violation
-			}
-		}
-		return err
-	}
-	return nil
+	return validate(o.validator, v)
 }
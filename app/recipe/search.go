@@ -0,0 +1,27 @@
+package recipe
+
+import (
+	"strings"
+
+	"github.com/manala/manala/app"
+)
+
+// Search recipes whose name or description contains keyword, case-insensitively.
+// An empty keyword matches all recipes.
+func Search(recipes []app.Recipe, keyword string) []app.Recipe {
+	if keyword == "" {
+		return recipes
+	}
+
+	keyword = strings.ToLower(keyword)
+
+	var found []app.Recipe
+	for _, recipe := range recipes {
+		if strings.Contains(strings.ToLower(recipe.Name()), keyword) ||
+			strings.Contains(strings.ToLower(recipe.Description()), keyword) {
+			found = append(found, recipe)
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,69 @@
+package recipe_test
+
+import (
+	"testing"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/recipe"
+	"github.com/manala/manala/app/testing/mocks"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SearchSuite struct{ suite.Suite }
+
+func TestSearchSuite(t *testing.T) {
+	suite.Run(t, new(SearchSuite))
+}
+
+func (s *SearchSuite) TestSearch() {
+	fooMock := &mocks.Recipe{}
+	fooMock.
+		On("Name").Return("foo").
+		On("Description").Return("Php application")
+
+	barMock := &mocks.Recipe{}
+	barMock.
+		On("Name").Return("php-bar").
+		On("Description").Return("Bar")
+
+	bazMock := &mocks.Recipe{}
+	bazMock.
+		On("Name").Return("baz").
+		On("Description").Return("Baz")
+
+	recipes := []app.Recipe{fooMock, barMock, bazMock}
+
+	tests := []struct {
+		test     string
+		keyword  string
+		expected []app.Recipe
+	}{
+		{
+			test:     "Empty",
+			keyword:  "",
+			expected: []app.Recipe{fooMock, barMock, bazMock},
+		},
+		{
+			test:     "NameOrDescription",
+			keyword:  "PHP",
+			expected: []app.Recipe{fooMock, barMock},
+		},
+		{
+			test:     "Name",
+			keyword:  "baz",
+			expected: []app.Recipe{bazMock},
+		},
+		{
+			test:     "None",
+			keyword:  "qux",
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			s.Equal(test.expected, recipe.Search(recipes, test.keyword))
+		})
+	}
+}
@@ -1,39 +1,66 @@
 package cache
 
 import (
+	"context"
+
 	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/internal/log"
 )
 
 type LoaderHandler struct {
-	log   *log.Log
-	cache *Cache
+	log         *log.Log
+	cache       *Cache
+	recipeScope bool
 }
 
-func NewLoaderHandler(log *log.Log, cache *Cache) *LoaderHandler {
-	return &LoaderHandler{
+func NewLoaderHandler(log *log.Log, cache *Cache, opts ...LoaderHandlerOption) *LoaderHandler {
+	handler := &LoaderHandler{
 		log:   log,
 		cache: cache,
 	}
+
+	// Options
+	for _, opt := range opts {
+		opt(handler)
+	}
+
+	return handler
+}
+
+type LoaderHandlerOption func(handler *LoaderHandler)
+
+// WithRecipeScope scopes cached repositories by context recipe name,
+// as sparse repositories only hold their recipe.
+func WithRecipeScope(scope bool) LoaderHandlerOption {
+	return func(handler *LoaderHandler) {
+		handler.recipeScope = scope
+	}
 }
 
-func (handler *LoaderHandler) Handle(query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
+func (handler *LoaderHandler) Handle(ctx context.Context, query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
 	handler.log.Debug("handle repository cache", "handler", "cache", "url", query.URL)
 
+	key := query.URL
+	if handler.recipeScope {
+		if name, ok := app.RecipeName(ctx); ok {
+			key += "#" + name
+		}
+	}
+
 	// Check if repository already in cache
-	if repository, ok := handler.cache.Get(query.URL); ok {
+	if repository, ok := handler.cache.Get(key); ok {
 		handler.log.Debug("hit repository cache", "handler", "cache", "url", query.URL)
 
 		return repository, nil
 	}
 
 	// Chain
-	repository, err := chain.Next(query)
+	repository, err := chain.Next(ctx, query)
 
 	// Cache repository
 	if repository != nil && err == nil {
-		handler.cache.Set(query.URL, repository)
+		handler.cache.Set(key, repository)
 	}
 
 	return repository, err
@@ -3,11 +3,13 @@ package cache_test
 import (
 	"testing"
 
+	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/app/repository/cache"
 	"github.com/manala/manala/app/testing/mocks"
 	"github.com/manala/manala/internal/log"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -28,9 +30,9 @@ func (s *LoaderSuite) TestHandler() {
 
 	// First call should chain to next handler
 	chainMockCall := chainMock.
-		On("Next", handlerQueryFoo).Return(repositoryMock, nil)
+		On("Next", mock.Anything, handlerQueryFoo).Return(repositoryMock, nil)
 
-	repository, err := handler.Handle(handlerQueryFoo, chainMock)
+	repository, err := handler.Handle(s.T().Context(), handlerQueryFoo, chainMock)
 
 	s.Require().NoError(err)
 	s.Equal(repositoryMock, repository)
@@ -39,7 +41,7 @@ func (s *LoaderSuite) TestHandler() {
 	// Second call with same name should extract from cache, and not chain to next handler
 	chainMockCall.Unset()
 
-	repository, err = handler.Handle(handlerQueryFoo, chainMock)
+	repository, err = handler.Handle(s.T().Context(), handlerQueryFoo, chainMock)
 
 	s.Require().NoError(err)
 	s.Equal(repositoryMock, repository)
@@ -47,11 +49,45 @@ func (s *LoaderSuite) TestHandler() {
 
 	// Third call with different name should chain to next handler
 	chainMock.
-		On("Next", handlerQueryBar).Return(repositoryMock, nil)
+		On("Next", mock.Anything, handlerQueryBar).Return(repositoryMock, nil)
 
-	repository, err = handler.Handle(handlerQueryBar, chainMock)
+	repository, err = handler.Handle(s.T().Context(), handlerQueryBar, chainMock)
 
 	s.Require().NoError(err)
 	s.Equal(repositoryMock, repository)
 	chainMock.AssertExpectations(s.T())
 }
+
+func (s *LoaderSuite) TestHandlerRecipeScope() {
+	handler := cache.NewLoaderHandler(log.Discard, cache.New(), cache.WithRecipeScope(true))
+	handlerQuery := &repository.LoaderQuery{URL: "url"}
+
+	repositoryFooMock := &mocks.Repository{}
+	repositoryBarMock := &mocks.Repository{}
+
+	ctxFoo := app.WithRecipeName(s.T().Context(), "foo")
+	ctxBar := app.WithRecipeName(s.T().Context(), "bar")
+
+	chainMock := &repository.LoaderHandlerChainMock{}
+	chainMock.
+		On("Next", ctxFoo, handlerQuery).Return(repositoryFooMock, nil).Once().
+		On("Next", ctxBar, handlerQuery).Return(repositoryBarMock, nil).Once()
+
+	// Same url, different recipes should both chain to next handler
+	repository, err := handler.Handle(ctxFoo, handlerQuery, chainMock)
+
+	s.Require().NoError(err)
+	s.Equal(repositoryFooMock, repository)
+
+	repository, err = handler.Handle(ctxBar, handlerQuery, chainMock)
+
+	s.Require().NoError(err)
+	s.Equal(repositoryBarMock, repository)
+
+	// Same url, same recipe should extract from cache
+	repository, err = handler.Handle(ctxFoo, handlerQuery, chainMock)
+
+	s.Require().NoError(err)
+	s.Same(repositoryFooMock, repository)
+	chainMock.AssertExpectations(s.T())
+}
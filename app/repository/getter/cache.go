@@ -0,0 +1,509 @@
+package getter
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+
+	"github.com/hashicorp/go-getter/v2"
+)
+
+// Metadata describes a repository cache entry.
+// It is stored next to the entry dir, as a json file.
+type Metadata struct {
+	URL        string    `json:"url"`
+	Ref        string    `json:"ref,omitempty"`
+	Constraint string    `json:"constraint,omitempty"`
+	Recipe     string    `json:"recipe,omitempty"`
+	FetchedAt  time.Time `json:"fetched_at"`
+	Size       int64     `json:"size"`
+}
+
+// ReadMetadata reads the metadata of a cache entry dir.
+func ReadMetadata(dir string) (*Metadata, error) {
+	content, err := os.ReadFile(dir + ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{}
+	if err := json.Unmarshal(content, metadata); err != nil {
+		return nil, serror.New("invalid repository cache metadata").
+			With("file", dir+".json").
+			WithErr(err)
+	}
+
+	return metadata, nil
+}
+
+// WriteMetadata writes the metadata of a cache entry dir.
+func WriteMetadata(dir string, metadata *Metadata) error {
+	content, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(dir+".json", content, 0o644); err != nil {
+		return serror.New("unable to write repository cache metadata").
+			With("file", dir+".json").
+			WithErr(err)
+	}
+
+	return nil
+}
+
+type Option func(options *getterOptions)
+
+// WithOffline serves repositories strictly from cache, never reaching network.
+func WithOffline(offline bool) Option {
+	return func(options *getterOptions) {
+		options.offline = offline
+	}
+}
+
+// WithCacheTTL skips repositories fetching as long as their cache entries are fresher than ttl.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(options *getterOptions) {
+		options.cacheTTL = ttl
+	}
+}
+
+// WithTimeout aborts repositories fetching after a given duration.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *getterOptions) {
+		options.timeout = timeout
+	}
+}
+
+// WithGitAuth reaches private git repositories using credentials.
+func WithGitAuth(auth *GitAuth) Option {
+	return func(options *getterOptions) {
+		options.gitAuth = auth
+	}
+}
+
+// WithGitShallow only fetches the last commit of git repositories refs.
+func WithGitShallow(shallow bool) Option {
+	return func(options *getterOptions) {
+		options.gitShallow = shallow
+	}
+}
+
+// WithGitSparse only extracts the recipe dir of git repositories, when known by context.
+func WithGitSparse(sparse bool) Option {
+	return func(options *getterOptions) {
+		options.gitSparse = sparse
+	}
+}
+
+// WithAllowedSigners verifies repositories signatures against an ssh allowed signers file.
+// Archives (http, s3) come along with a detached signature (archive.tar.gz.sig), and git refs are signed tags.
+func WithAllowedSigners(file string) Option {
+	return func(options *getterOptions) {
+		options.allowedSigners = file
+	}
+}
+
+type getterOptions struct {
+	offline        bool
+	cacheTTL       time.Duration
+	timeout        time.Duration
+	gitAuth        *GitAuth
+	gitShallow     bool
+	gitSparse      bool
+	allowedSigners string
+}
+
+func newOptions(opts ...Option) *getterOptions {
+	options := &getterOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// withTimeout derives a context from ctx, honoring timeout option.
+func (options *getterOptions) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if options.timeout > 0 {
+		return context.WithTimeout(ctx, options.timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// fetch gets a request into its cache entry dir, verifying its checksum and signature when required.
+// On cancellation or timeout, the partially fetched entry is dropped, leaving the cache consistent.
+func (options *getterOptions) fetch(ctx context.Context, log *log.Log, client *getter.Client, request *getter.Request) (*getter.GetResult, error) {
+	ctx, cancel := options.withTimeout(ctx)
+	defer cancel()
+
+	url, dir := request.Src, request.Dst
+
+	// Entry dir being fetched in place, drop its metadata up front,
+	// so that a failed fetch never leaves a broken entry to be served from cache
+	if err := os.Remove(dir + ".json"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, serror.New("unable to remove cache entry metadata").
+			With("file", dir+".json").
+			WithErr(err)
+	}
+
+	// Signature
+	var verifier *ArchiveVerifier
+	if options.allowedSigners != "" {
+		var err error
+		if verifier, err = NewArchiveVerifier(ctx, log, client, url, options.allowedSigners); err != nil {
+			if err := aborted(ctx, url, dir); err != nil {
+				return nil, err
+			}
+
+			return nil, err
+		}
+		defer verifier.Close()
+
+		client = verifier.Client(ctx, client)
+	}
+
+	response, err := client.Get(ctx, request)
+	if err != nil {
+		if IsNotDetected(err) {
+			return nil, err
+		}
+
+		if err := aborted(ctx, url, dir); err != nil {
+			return nil, err
+		}
+
+		if _, ok := errors.AsType[*app.UnverifiedRepositoryError](err); ok {
+			return nil, err
+		}
+
+		if _, ok := errors.AsType[*getter.ChecksumError](err); ok {
+			return nil, &app.UnverifiedRepositoryError{URL: url, Reason: "checksum mismatch"}
+		}
+
+		return nil, ErrorFrom(err)
+	}
+
+	// Only archives could be verified against a signature
+	if verifier != nil && !verifier.Verified() {
+		_ = os.RemoveAll(dir)
+
+		return nil, &app.UnverifiedRepositoryError{URL: url, Reason: "not a signed archive"}
+	}
+
+	return response, nil
+}
+
+// fetchGit fetches a git request into its cache entry dir, the same way fetch does.
+// Entry dir being swapped only once fully extracted, a failed fetch leaves the former entry consistent.
+func (options *getterOptions) fetchGit(ctx context.Context, fetcher *GitFetcher, url string, request *GitFetchRequest, dir string) error {
+	ctx, cancel := options.withTimeout(ctx)
+	defer cancel()
+
+	if err := fetcher.Fetch(ctx, request, dir); err != nil {
+		if err := aborted(ctx, url, dir); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// aborted drops a partially fetched cache entry dir, once fetching cancelled or timed out.
+func aborted(ctx context.Context, url string, dir string) error {
+	ctxErr := ctx.Err()
+	if ctxErr == nil {
+		return nil
+	}
+
+	_ = os.RemoveAll(dir)
+	_ = os.Remove(dir + ".json")
+
+	return serror.New("repository fetching aborted").
+		With("url", url).
+		WithErr(ctxErr)
+}
+
+// fromCache tries to serve a repository from its cache entry dir, without fetching it.
+// Entries restricted to a recipe (sparse) are only served to requests for that very recipe.
+func (options *getterOptions) fromCache(log *log.Log, url string, dir string, recipe string) (*Repository, bool, error) {
+	if !options.offline && options.cacheTTL <= 0 {
+		return nil, false, nil
+	}
+
+	metadata, err := ReadMetadata(dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, false, err
+		}
+
+		if options.offline {
+			return nil, false, &app.OfflineRepositoryError{URL: url}
+		}
+
+		return nil, false, nil
+	}
+
+	if metadata.Recipe != recipe {
+		if options.offline {
+			return nil, false, &app.OfflineRepositoryError{URL: url}
+		}
+
+		return nil, false, nil
+	}
+
+	if !options.offline && time.Since(metadata.FetchedAt) >= options.cacheTTL {
+		return nil, false, nil
+	}
+
+	log.Debug("serve repository from cache", "url", url, "fetched_at", metadata.FetchedAt)
+
+	repository := NewRepository(url, dir)
+	repository.ref = metadata.Ref
+	repository.constraint = metadata.Constraint
+	repository.recipe = metadata.Recipe
+
+	return repository, true, nil
+}
+
+// toCache records a freshly fetched repository cache entry.
+func toCache(repository *Repository) error {
+	size, err := dirSize(repository.Dir())
+	if err != nil {
+		return err
+	}
+
+	return WriteMetadata(repository.Dir(), &Metadata{
+		URL:        repository.URL(),
+		Ref:        repository.Ref(),
+		Constraint: repository.Constraint(),
+		Recipe:     repository.recipe,
+		FetchedAt:  time.Now(),
+		Size:       size,
+	})
+}
+
+// CacheEntry is a repository cache entry.
+type CacheEntry struct {
+	Dir string
+	*Metadata
+}
+
+// CacheManager inspects and prunes repositories cache entries.
+type CacheManager struct {
+	log    *log.Log
+	cache  *cache.Cache
+	stores *cache.Cache
+}
+
+func NewCacheManager(log *log.Log, cache *cache.Cache) *CacheManager {
+	return &CacheManager{
+		log:    log,
+		cache:  cache.WithDir("repositories"),
+		stores: cache.WithDir("git"),
+	}
+}
+
+// List cache entries, ordered by url.
+func (manager *CacheManager) List() ([]*CacheEntry, error) {
+	cacheDir, err := manager.cache.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := os.ReadDir(cacheDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, serror.New("unable to read cache dir").
+			With("dir", cacheDir).
+			WithErr(err)
+	}
+
+	var entries []*CacheEntry
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(cacheDir, file.Name())
+
+		metadata, err := ReadMetadata(dir)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+
+			// Legacy entry, fetched without metadata
+			metadata, err = legacyMetadata(dir, file)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		entries = append(entries, &CacheEntry{
+			Dir:      dir,
+			Metadata: metadata,
+		})
+	}
+
+	slices.SortFunc(entries, func(a, b *CacheEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.URL, b.URL),
+			cmp.Compare(a.Dir, b.Dir),
+		)
+	})
+
+	return entries, nil
+}
+
+// Clean removes cache entries fetched for longer than a given duration (all of them on zero), and returns them.
+func (manager *CacheManager) Clean(olderThan time.Duration) ([]*CacheEntry, error) {
+	entries, err := manager.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var cleaned []*CacheEntry
+
+	for _, entry := range entries {
+		if olderThan > 0 && time.Since(entry.FetchedAt) < olderThan {
+			continue
+		}
+
+		manager.log.Debug("clean repository cache entry", "url", entry.URL, "dir", entry.Dir)
+
+		if err := os.RemoveAll(entry.Dir); err != nil {
+			return nil, serror.New("unable to remove cache entry").
+				With("dir", entry.Dir).
+				WithErr(err)
+		}
+
+		if err := os.Remove(entry.Dir + ".json"); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, serror.New("unable to remove cache entry metadata").
+				With("file", entry.Dir+".json").
+				WithErr(err)
+		}
+
+		cleaned = append(cleaned, entry)
+	}
+
+	// Git stores, shared across entries, aged by their last fetch
+	if err := manager.cleanStores(olderThan); err != nil {
+		return nil, err
+	}
+
+	return cleaned, nil
+}
+
+// cleanStores removes git stores fetched for longer than a given duration (all of them on zero).
+func (manager *CacheManager) cleanStores(olderThan time.Duration) error {
+	storesDir, err := manager.stores.Dir()
+	if err != nil {
+		return err
+	}
+
+	files, err := os.ReadDir(storesDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return serror.New("unable to read git stores dir").
+			With("dir", storesDir).
+			WithErr(err)
+	}
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		store := filepath.Join(storesDir, file.Name())
+
+		if olderThan > 0 {
+			info, err := file.Info()
+			if err != nil {
+				return serror.New("unable to stat git store").
+					With("dir", store).
+					WithErr(err)
+			}
+
+			if time.Since(info.ModTime()) < olderThan {
+				continue
+			}
+		}
+
+		manager.log.Debug("clean repository git store", "dir", store)
+
+		if err := os.RemoveAll(store); err != nil {
+			return serror.New("unable to remove git store").
+				With("dir", store).
+				WithErr(err)
+		}
+	}
+
+	return nil
+}
+
+func legacyMetadata(dir string, file fs.DirEntry) (*Metadata, error) {
+	info, err := file.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := dirSize(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metadata{
+		FetchedAt: info.ModTime(),
+		Size:      size,
+	}, nil
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.Type().IsRegular() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+
+			size += info.Size()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, serror.New("unable to compute dir size").
+			With("dir", dir).
+			WithErr(err)
+	}
+
+	return size, nil
+}
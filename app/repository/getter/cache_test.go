@@ -0,0 +1,109 @@
+package getter_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/log"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheSuite struct{ suite.Suite }
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
+
+func (s *CacheSuite) TestMetadata() {
+	dir := filepath.Join(s.T().TempDir(), "entry")
+
+	metadata := &getter.Metadata{
+		URL:       "url",
+		Ref:       "ref",
+		FetchedAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Size:      123,
+	}
+
+	s.Require().NoError(getter.WriteMetadata(dir, metadata))
+	s.FileExists(dir + ".json")
+
+	actual, err := getter.ReadMetadata(dir)
+
+	s.Require().NoError(err)
+	s.Equal(metadata, actual)
+}
+
+func (s *CacheSuite) TestManagerList() {
+	cacheDir := s.T().TempDir()
+	repositoriesDir := filepath.Join(cacheDir, "repositories")
+
+	// Entry
+	s.Require().NoError(os.MkdirAll(filepath.Join(repositoriesDir, "entry"), 0o755))
+	s.Require().NoError(getter.WriteMetadata(filepath.Join(repositoriesDir, "entry"), &getter.Metadata{
+		URL:       "url",
+		FetchedAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Size:      123,
+	}))
+
+	// Legacy entry, without metadata
+	s.Require().NoError(os.MkdirAll(filepath.Join(repositoriesDir, "legacy"), 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(repositoriesDir, "legacy", "README"), []byte("Hello World!"), 0o644))
+
+	manager := getter.NewCacheManager(log.Discard, cache.New(cacheDir))
+
+	entries, err := manager.List()
+
+	s.Require().NoError(err)
+	s.Require().Len(entries, 2)
+
+	s.Equal(filepath.Join(repositoriesDir, "legacy"), entries[0].Dir)
+	s.Empty(entries[0].URL)
+	s.WithinDuration(time.Now(), entries[0].FetchedAt, time.Minute)
+	s.Equal(int64(12), entries[0].Size)
+
+	s.Equal(filepath.Join(repositoriesDir, "entry"), entries[1].Dir)
+	s.Equal("url", entries[1].URL)
+	s.Equal(int64(123), entries[1].Size)
+}
+
+func (s *CacheSuite) TestManagerListNotExisting() {
+	manager := getter.NewCacheManager(log.Discard, cache.New(filepath.Join(s.T().TempDir(), "cache")))
+
+	entries, err := manager.List()
+
+	s.Require().NoError(err)
+	s.Empty(entries)
+}
+
+func (s *CacheSuite) TestManagerCleanStores() {
+	cacheDir := s.T().TempDir()
+	storesDir := filepath.Join(cacheDir, "git")
+
+	s.Require().NoError(os.MkdirAll(filepath.Join(storesDir, "store"), 0o755))
+	s.Require().NoError(os.MkdirAll(filepath.Join(storesDir, "old"), 0o755))
+
+	old := time.Now().Add(-2 * time.Hour)
+	s.Require().NoError(os.Chtimes(filepath.Join(storesDir, "old"), old, old))
+
+	manager := getter.NewCacheManager(log.Discard, cache.New(cacheDir))
+
+	s.Run("OlderThan", func() {
+		_, err := manager.Clean(time.Hour)
+
+		s.Require().NoError(err)
+		s.DirExists(filepath.Join(storesDir, "store"))
+		s.NoDirExists(filepath.Join(storesDir, "old"))
+	})
+
+	s.Run("All", func() {
+		_, err := manager.Clean(0)
+
+		s.Require().NoError(err)
+		s.NoDirExists(filepath.Join(storesDir, "store"))
+	})
+}
@@ -0,0 +1,19 @@
+package getter
+
+import (
+	"github.com/hashicorp/go-getter/v2"
+)
+
+// detect if request is handled by one of client getters, without altering it.
+func detect(client *getter.Client, request *getter.Request) bool {
+	for _, g := range client.Getters {
+		if ok, _ := g.Detect(&getter.Request{
+			Src:    request.Src,
+			Forced: request.Forced,
+		}); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -16,11 +16,12 @@ import (
 )
 
 type FileLoaderHandler struct {
-	log    *log.Log
-	client *getter.Client
+	log     *log.Log
+	client  *getter.Client
+	options *getterOptions
 }
 
-func NewFileLoaderHandler(log *log.Log) *FileLoaderHandler {
+func NewFileLoaderHandler(log *log.Log, opts ...Option) *FileLoaderHandler {
 	return &FileLoaderHandler{
 		log: log,
 		client: &getter.Client{
@@ -31,10 +32,11 @@ func NewFileLoaderHandler(log *log.Log) *FileLoaderHandler {
 			},
 			Decompressors: getter.Decompressors,
 		},
+		options: newOptions(opts...),
 	}
 }
 
-func (handler *FileLoaderHandler) Handle(query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
+func (handler *FileLoaderHandler) Handle(ctx context.Context, query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
 	handler.log.Debug("handle repository", "handler", "getter.file", "url", query.URL)
 
 	// Request
@@ -50,7 +52,7 @@ func (handler *FileLoaderHandler) Handle(query *repository.LoaderQuery, chain re
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			// Chain
-			return chain.Next(query)
+			return chain.Next(ctx, query)
 		}
 
 		return nil, serror.New("file system error").
@@ -58,7 +60,12 @@ func (handler *FileLoaderHandler) Handle(query *repository.LoaderQuery, chain re
 			WithErr(std.From(err))
 	} else if !stat.IsDir() {
 		// Chain
-		return chain.Next(query)
+		return chain.Next(ctx, query)
+	}
+
+	// Local repositories could not be verified
+	if handler.options.allowedSigners != "" {
+		return nil, &app.UnverifiedRepositoryError{URL: query.URL, Reason: "unverifiable source"}
 	}
 
 	// Set pwd if relative
@@ -70,11 +77,11 @@ func (handler *FileLoaderHandler) Handle(query *repository.LoaderQuery, chain re
 		}
 	}
 
-	response, err := handler.client.Get(context.Background(), request)
+	response, err := handler.client.Get(ctx, request)
 	if err != nil {
 		if IsNotDetected(err) {
 			// Chain
-			return chain.Next(query)
+			return chain.Next(ctx, query)
 		}
 
 		return nil, ErrorFrom(err)
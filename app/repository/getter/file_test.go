@@ -4,9 +4,12 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/testing/errors"
 	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -24,7 +27,7 @@ func (s *FileSuite) TestLoaderHandler() {
 		chainMock := &repository.LoaderHandlerChainMock{}
 
 		handler := getter.NewFileLoaderHandler(log.Discard)
-		repository, err := handler.Handle(&repository.LoaderQuery{URL: url}, chainMock)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
 
 		s.Require().NoError(err)
 		s.Equal(url, repository.URL())
@@ -38,10 +41,25 @@ func (s *FileSuite) TestLoaderHandler() {
 		chainMock := &repository.LoaderHandlerChainMock{}
 
 		handler := getter.NewFileLoaderHandler(log.Discard)
-		repository, err := handler.Handle(&repository.LoaderQuery{URL: url}, chainMock)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
 
 		s.Require().NoError(err)
 		s.Equal(url, repository.URL())
 		chainMock.AssertExpectations(s.T())
 	})
 }
+
+func (s *FileSuite) TestLoaderHandlerErrors() {
+	s.Run("Unverifiable", func() {
+		url := filepath.FromSlash("testdata/FileSuite/repository")
+
+		handler := getter.NewFileLoaderHandler(log.Discard, getter.WithAllowedSigners("allowed_signers"))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(repository)
+		expectation.ExpectError(s.T(), errors.Expectation{
+			Type:  &app.UnverifiedRepositoryError{},
+			Attrs: [][2]any{{"url", url}, {"reason", "unverifiable source"}},
+		}, err)
+	})
+}
@@ -2,30 +2,37 @@ package getter
 
 import (
 	"context"
-	"time"
+	netURL "net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror"
 	"github.com/manala/manala/internal/log"
 	"github.com/manala/manala/internal/validation"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/hashicorp/go-getter/v2"
 )
 
 type GitLoaderHandler struct {
-	log    *log.Log
-	cache  *cache.Cache
-	client *getter.Client
+	log      *log.Log
+	cache    *cache.Cache
+	client   *getter.Client
+	fetcher  *GitFetcher
+	versions *GitVersionsLister
+	options  *getterOptions
 }
 
-func NewGitLoaderHandler(log *log.Log, cache *cache.Cache) *GitLoaderHandler {
+func NewGitLoaderHandler(log *log.Log, cache *cache.Cache, opts ...Option) *GitLoaderHandler {
 	return &GitLoaderHandler{
 		log:   log,
 		cache: cache.WithDir("repositories"),
+		// Only used for detection, fetching being handled by git fetcher
 		client: &getter.Client{
-			// Prevent copying or writing files through symlinks
-			DisableSymlinks: true,
 			Getters: []getter.Getter{
 				&getter.GitGetter{
 					Detectors: []getter.Detector{
@@ -34,30 +41,21 @@ func NewGitLoaderHandler(log *log.Log, cache *cache.Cache) *GitLoaderHandler {
 						&getter.BitBucketDetector{},
 						&getter.GitLabDetector{},
 					},
-					Timeout: 30 * time.Second,
 				},
 			},
-			Decompressors: getter.Decompressors,
 		},
+		fetcher:  NewGitFetcher(log, cache),
+		versions: NewGitVersionsLister(log, opts...),
+		options:  newOptions(opts...),
 	}
 }
 
-func (handler *GitLoaderHandler) Handle(query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
+func (handler *GitLoaderHandler) Handle(ctx context.Context, query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
 	handler.log.Debug("handle repository", "handler", "getter.git", "url", query.URL)
 
-	// Cache dir
-	cacheDir, err := handler.cache.
-		WithHashDir(query.URL).
-		Dir()
-	if err != nil {
-		return nil, err
-	}
-
 	// Request
 	request := &getter.Request{
-		Src:     query.URL,
-		Dst:     cacheDir,
-		GetMode: getter.ModeDir,
+		Src: query.URL,
 	}
 
 	// Legacy: ensure backward compatibility by forcing git repo format ()
@@ -65,15 +63,139 @@ func (handler *GitLoaderHandler) Handle(query *repository.LoaderQuery, chain rep
 		request.Forced = "git"
 	}
 
-	response, err := handler.client.Get(context.Background(), request)
+	if !detect(handler.client, request) {
+		// Chain
+		return chain.Next(ctx, query)
+	}
+
+	// Sparse recipe
+	var recipe string
+	if handler.options.gitSparse {
+		recipe, _ = app.RecipeName(ctx)
+	}
+
+	// Ref
+	src, rawQuery, _ := strings.Cut(request.Src, "?")
+	values, _ := netURL.ParseQuery(rawQuery)
+	ref := values.Get("ref")
+
+	// Only support go-getter legacy queries actually handled
+	for key := range values {
+		switch key {
+		case "ref", "depth", "sshkey":
+		default:
+			return nil, serror.New("unsupported git repository query").
+				With("url", query.URL, "query", key)
+		}
+	}
+
+	// Version constraint, pinned to a locked ref as long as it still matches
+	constraint, isConstraint := VersionConstraint(ref)
+	var lockedRef string
+	if isConstraint {
+		if locked, ok := app.RepositoryLockedRef(ctx); ok {
+			if version, err := semver.NewVersion(locked); err == nil && constraint.Check(version) {
+				lockedRef = locked
+			}
+		}
+	}
+
+	// Cache dir, dedicated to locked ref, and to recipe when sparse
+	key := query.URL
+	if lockedRef != "" {
+		key += "@" + lockedRef
+	}
+	if recipe != "" {
+		key += "#" + recipe
+	}
+
+	cacheDir, err := handler.cache.
+		WithHashDir(key).
+		Dir()
 	if err != nil {
-		if IsNotDetected(err) {
-			// Chain
-			return chain.Next(query)
+		return nil, err
+	}
+
+	// Serve from cache
+	repository, ok, err := handler.options.fromCache(handler.log, query.URL, cacheDir, recipe)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return repository, nil
+	}
+
+	// Resolve ref version constraint against repository tags, unless locked
+	if lockedRef != "" {
+		handler.log.Debug("lock repository ref", "constraint", ref, "ref", lockedRef)
+
+		ref = lockedRef
+	} else if isConstraint {
+		version, err := handler.versions.Resolve(ctx, request.Src, constraint)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil, ErrorFrom(err)
+		handler.log.Debug("resolve repository ref", "constraint", ref, "ref", version.Original())
+
+		ref = version.Original()
+	}
+
+	// Remote & subdir
+	remote, err := handler.versions.remote(request.Src)
+	if err != nil {
+		return nil, err
+	}
+
+	_, subdir := getter.SourceDirSubdir(src)
+	if subdir != "" && !filepath.IsLocal(subdir) {
+		return nil, serror.New("subdir out of repository")
+	}
+
+	// Legacy: go-getter ssh key query, taking precedence over auth one
+	sshKeyEnv, cleanup, err := sshKeyEnv(handler.log, values.Get("sshkey"))
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	fetchRequest := &GitFetchRequest{
+		Remote:         remote,
+		Ref:            ref,
+		Subdir:         subdir,
+		Env:            append(handler.options.gitAuth.env(handler.log, remote), sshKeyEnv...),
+		AllowedSigners: handler.options.allowedSigners,
+	}
+
+	// Shallow
+	if handler.options.gitShallow {
+		fetchRequest.Depth = 1
+	}
+
+	// Legacy: go-getter depth query
+	if depth, err := strconv.Atoi(values.Get("depth")); err == nil {
+		fetchRequest.Depth = depth
+	}
+
+	// Sparse
+	if recipe != "" {
+		fetchRequest.Paths = []string{recipe}
+	}
+
+	if err := handler.options.fetchGit(ctx, handler.fetcher, query.URL, fetchRequest, cacheDir); err != nil {
+		return nil, err
+	}
+
+	repository = NewRepository(query.URL, cacheDir)
+	repository.ref = ref
+	if isConstraint {
+		repository.constraint = values.Get("ref")
+	}
+	repository.recipe = recipe
+
+	if err := toCache(repository); err != nil {
+		return nil, err
 	}
 
-	return NewRepository(query.URL, response.Dst), nil
+	return repository, nil
 }
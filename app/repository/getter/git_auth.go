@@ -0,0 +1,151 @@
+package getter
+
+import (
+	"encoding/base64"
+	netURL "net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+)
+
+// GitCredentials are used to reach private git repositories.
+type GitCredentials struct {
+	// SSHKey is a private ssh key path, used for ssh remotes.
+	SSHKey string
+	// Token is used for https remotes, as basic auth password.
+	Token string
+	// Username goes along with token, "x-access-token" by default.
+	Username string
+}
+
+// GitAuth holds git credentials, by host.
+// Default credentials apply to hosts without any dedicated ones.
+type GitAuth struct {
+	Default GitCredentials
+	Hosts   map[string]GitCredentials
+}
+
+// credentials returns the credentials of a host.
+func (auth *GitAuth) credentials(host string) GitCredentials {
+	if credentials, ok := auth.Hosts[host]; ok {
+		return credentials
+	}
+
+	return auth.Default
+}
+
+// env returns the git environment variables conveying remote credentials.
+// Secrets never end up in remote url, so that they could neither leak into
+// logs and errors, nor be persisted in cached repositories git config.
+func (auth *GitAuth) env(log *log.Log, remote string) []string {
+	if auth == nil {
+		return nil
+	}
+
+	url, err := netURL.Parse(remote)
+	if err != nil || url.Host == "" {
+		return nil
+	}
+
+	credentials := auth.credentials(url.Hostname())
+
+	var env []string
+
+	switch url.Scheme {
+	case "ssh":
+		if credentials.SSHKey == "" {
+			return nil
+		}
+
+		log.Debug("use git ssh key", "host", url.Hostname(), "ssh_key", credentials.SSHKey)
+
+		command := os.Getenv("GIT_SSH_COMMAND")
+		if command == "" {
+			command = "ssh"
+		}
+
+		env = append(env,
+			"GIT_SSH_COMMAND="+command+" -i "+shellQuote(credentials.SSHKey)+" -o IdentitiesOnly=yes",
+		)
+	case "http", "https":
+		if credentials.Token == "" {
+			return nil
+		}
+
+		log.Debug("use git token", "host", url.Hostname())
+
+		username := credentials.Username
+		if username == "" {
+			username = "x-access-token"
+		}
+
+		// Append to any already defined git config environment variables
+		count, _ := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+		index := strconv.Itoa(count)
+
+		env = append(env,
+			"GIT_CONFIG_COUNT="+strconv.Itoa(count+1),
+			"GIT_CONFIG_KEY_"+index+"=http."+url.Scheme+"://"+url.Host+"/.extraHeader",
+			"GIT_CONFIG_VALUE_"+index+"=Authorization: Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+credentials.Token)),
+			// Never prompt for credentials
+			"GIT_TERMINAL_PROMPT=0",
+		)
+	}
+
+	return env
+}
+
+// sshKeyEnv returns the git environment variables conveying a go-getter legacy "sshkey" query, made of a base64
+// encoded private ssh key. The key is written into a temporary file, to be removed using the cleanup function.
+func sshKeyEnv(log *log.Log, encoded string) ([]string, func(), error) {
+	if encoded == "" {
+		return nil, func() {}, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, nil, serror.New("invalid git repository ssh key").
+			WithErr(err)
+	}
+
+	file, err := os.CreateTemp("", "manala-sshkey-*")
+	if err != nil {
+		return nil, nil, serror.New("unable to write git repository ssh key").
+			WithErr(err)
+	}
+
+	cleanup := func() { _ = os.Remove(file.Name()) }
+
+	_, err = file.Write(key)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), 0o600)
+	}
+	if err != nil {
+		cleanup()
+
+		return nil, nil, serror.New("unable to write git repository ssh key").
+			WithErr(err)
+	}
+
+	log.Debug("use git ssh key query")
+
+	command := os.Getenv("GIT_SSH_COMMAND")
+	if command == "" {
+		command = "ssh"
+	}
+
+	return []string{
+		"GIT_SSH_COMMAND=" + command + " -i " + shellQuote(file.Name()) + " -o IdentitiesOnly=yes",
+	}, cleanup, nil
+}
+
+// shellQuote quotes a value for a posix shell, as git runs ssh command through one.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,378 @@
+package getter
+
+import (
+	"archive/tar"
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+)
+
+// GitFetchRequest describes a git repository ref to fetch into a dir.
+type GitFetchRequest struct {
+	// Remote git repository
+	Remote string
+	// Ref to fetch, remote default branch if empty
+	Ref string
+	// Subdir of the repository to extract, whole repository if empty
+	Subdir string
+	// Paths, relative to subdir, to restrict extraction to (sparse checkout)
+	Paths []string
+	// Depth of fetched history, whole history if zero (shallow fetch)
+	Depth int
+	// Env holds additional git environment variables
+	Env []string
+	// AllowedSigners is an ssh allowed signers file, ref being required to be a tag signed by one of them
+	AllowedSigners string
+}
+
+// Validate request remote and ref, so that none of them could be taken as git options.
+func (request *GitFetchRequest) Validate() error {
+	if request.Remote == "" || strings.HasPrefix(request.Remote, "-") {
+		return serror.New("invalid git repository remote").
+			With("remote", request.Remote)
+	}
+
+	if strings.HasPrefix(request.Ref, "-") {
+		return serror.New("invalid git repository ref").
+			With("ref", request.Ref)
+	}
+
+	return nil
+}
+
+// GitFetcher fetches git repositories refs into dirs.
+// Repositories are cloned once into bare stores, shared across refs, and only incrementally fetched afterward.
+type GitFetcher struct {
+	log   *log.Log
+	cache *cache.Cache
+}
+
+func NewGitFetcher(log *log.Log, cache *cache.Cache) *GitFetcher {
+	return &GitFetcher{
+		log:   log,
+		cache: cache.WithDir("git"),
+	}
+}
+
+// Fetch a repository ref, and extract it into dir, replacing any previous content.
+// On failure, dir is left untouched.
+func (fetcher *GitFetcher) Fetch(ctx context.Context, request *GitFetchRequest, dir string) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	// Store
+	store, err := fetcher.cache.
+		WithHashDir(request.Remote).
+		Dir()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(store); errors.Is(err, os.ErrNotExist) {
+		fetcher.log.Debug("init repository git store", "remote", request.Remote, "store", store)
+
+		if _, err := fetcher.git(ctx, nil, "", "init", "--quiet", "--bare", store); err != nil {
+			return err
+		}
+	}
+
+	// Fetch
+	fetcher.log.Debug("fetch repository git ref", "remote", request.Remote, "ref", request.Ref, "depth", request.Depth)
+
+	commit := request.Ref
+
+	args := []string{"fetch", "--quiet", "--force"}
+	if request.Depth > 0 {
+		// Shallow fetch of the sole ref
+		args = append(args, "--no-tags", "--depth", strconv.Itoa(request.Depth), "--", request.Remote, cmp.Or(request.Ref, "HEAD"))
+		commit = "FETCH_HEAD"
+	} else {
+		args = append(args, "--tags", "--", request.Remote,
+			"+HEAD:refs/remotes/origin/HEAD",
+			"+refs/heads/*:refs/heads/*",
+		)
+		if commit == "" {
+			commit = "refs/remotes/origin/HEAD"
+		}
+	}
+
+	if _, err := fetcher.git(ctx, request.Env, store, args...); err != nil {
+		return err
+	}
+
+	// Record store last fetch, aging it for cache cleaning
+	now := time.Now()
+	if err := os.Chtimes(store, now, now); err != nil {
+		return serror.New("unable to touch repository git store").
+			With("store", store).
+			WithErr(err)
+	}
+
+	// Signed tag
+	if request.AllowedSigners != "" {
+		if err := fetcher.verify(ctx, store, request); err != nil {
+			return err
+		}
+	}
+
+	// Resolve commit
+	output, err := fetcher.git(ctx, nil, store, "rev-parse", "--verify", "--quiet", commit+"^{commit}")
+	if err != nil {
+		return serror.New("unable to resolve repository ref").
+			With("ref", request.Ref)
+	}
+	commit = strings.TrimSpace(string(output))
+
+	// Tree
+	tree := commit
+	if request.Subdir != "" {
+		tree += ":" + request.Subdir
+	}
+
+	// Sparse paths, missing ones left aside
+	var paths []string
+	for _, p := range request.Paths {
+		if _, err := fetcher.git(ctx, nil, store, "cat-file", "-e", commit+":"+path.Join(request.Subdir, p)); err == nil {
+			paths = append(paths, p)
+		}
+	}
+
+	// Nothing to extract
+	if len(request.Paths) > 0 && len(paths) == 0 {
+		return extract(dir, func(string) error { return nil })
+	}
+
+	fetcher.log.Debug("extract repository git tree", "tree", tree, "paths", paths, "dir", dir)
+
+	output, err = fetcher.git(ctx, nil, store, append([]string{"archive", "--format=tar", tree, "--"}, paths...)...)
+	if err != nil {
+		return err
+	}
+
+	return extract(dir, func(tmp string) error {
+		return untar(bytes.NewReader(output), tmp)
+	})
+}
+
+// verify that a fetched ref is a tag, signed by one of the allowed signers.
+func (fetcher *GitFetcher) verify(ctx context.Context, store string, request *GitFetchRequest) error {
+	if request.Ref == "" {
+		return &app.UnverifiedRepositoryError{URL: request.Remote, Reason: "unsigned ref"}
+	}
+
+	tag := "refs/tags/" + request.Ref
+	if request.Depth > 0 {
+		tag = "FETCH_HEAD"
+	}
+
+	output, err := fetcher.git(ctx, nil, store, "rev-parse", "--verify", "--quiet", tag+"^{tag}")
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+
+		return &app.UnverifiedRepositoryError{URL: request.Remote, Reason: "unsigned ref"}
+	}
+	tag = strings.TrimSpace(string(output))
+
+	fetcher.log.Debug("verify repository git tag signature", "ref", request.Ref, "tag", tag, "allowed_signers", request.AllowedSigners)
+
+	if _, err := fetcher.git(ctx, nil, store,
+		"-c", "gpg.ssh.allowedSignersFile="+request.AllowedSigners,
+		"verify-tag", tag,
+	); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+
+		fetcher.log.Debug("git tag signature failure", "error", err)
+
+		return &app.UnverifiedRepositoryError{URL: request.Remote, Reason: "invalid signature"}
+	}
+
+	return nil
+}
+
+// git runs a git command, optionally in a git dir.
+func (fetcher *GitFetcher) git(ctx context.Context, env []string, gitDir string, args ...string) ([]byte, error) {
+	if gitDir != "" {
+		args = append([]string{"--git-dir", gitDir}, args...)
+	}
+
+	command := exec.CommandContext(ctx, "git", args...)
+	command.Env = append(os.Environ(), env...)
+
+	stderr := &bytes.Buffer{}
+	command.Stderr = stderr
+
+	output, err := command.Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		code := -1
+		if err, ok := errors.AsType[*exec.ExitError](err); ok {
+			code = err.ExitCode()
+		}
+
+		return nil, serror.New("command error").
+			With("command", "git "+args[0], "code", code).
+			WithDump(strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
+
+// extract fills a temporary dir, next to dir, using fn, and only then swaps it into place,
+// so that dir is left untouched on failure.
+func extract(dir string, fn func(tmp string) error) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return serror.New("unable to create repository dir").
+			With("dir", filepath.Dir(dir)).
+			WithErr(err)
+	}
+
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), filepath.Base(dir)+".tmp-*")
+	if err != nil {
+		return serror.New("unable to create repository dir").
+			With("dir", dir).
+			WithErr(err)
+	}
+
+	if err := fn(tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+
+		return err
+	}
+
+	if err := os.Chmod(tmp, 0o755); err != nil {
+		_ = os.RemoveAll(tmp)
+
+		return serror.New("unable to create repository dir").
+			With("dir", dir).
+			WithErr(err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		_ = os.RemoveAll(tmp)
+
+		return serror.New("unable to clean repository dir").
+			With("dir", dir).
+			WithErr(err)
+	}
+
+	if err := os.Rename(tmp, dir); err != nil {
+		_ = os.RemoveAll(tmp)
+
+		return serror.New("unable to move repository dir").
+			With("dir", dir).
+			WithErr(err)
+	}
+
+	return nil
+}
+
+// untar extracts a tar archive into dir.
+func untar(reader io.Reader, dir string) error {
+	archive := tar.NewReader(reader)
+
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return serror.New("unable to read repository archive").
+				WithErr(err)
+		}
+
+		// Prevent writing outside of dir
+		if !filepath.IsLocal(header.Name) {
+			continue
+		}
+
+		target := filepath.Join(dir, header.Name)
+
+		// Prevent writing through symlinks
+		err = ensureNoSymlink(dir, header.Name)
+
+		if err == nil {
+			switch header.Typeflag {
+			case tar.TypeDir:
+				err = os.MkdirAll(target, 0o755)
+			case tar.TypeReg:
+				err = writeFile(target, archive, header.FileInfo().Mode().Perm())
+			case tar.TypeSymlink:
+				// Prevent links pointing outside of dir
+				if filepath.IsAbs(header.Linkname) || !filepath.IsLocal(filepath.Join(filepath.Dir(header.Name), header.Linkname)) {
+					err = serror.New("symlink out of repository").
+						With("link", header.Linkname)
+				} else {
+					err = os.Symlink(header.Linkname, target)
+				}
+			}
+		}
+
+		if err != nil {
+			return serror.New("unable to extract repository archive").
+				With("path", header.Name).
+				WithErr(err)
+		}
+	}
+}
+
+// ensureNoSymlink ensures none of the existing components of a dir relative path is a symlink.
+func ensureNoSymlink(dir, name string) error {
+	current := dir
+
+	for _, component := range strings.Split(filepath.Clean(name), string(filepath.Separator)) {
+		current = filepath.Join(current, component)
+
+		info, err := os.Lstat(current)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return serror.New("path through symlink")
+		}
+	}
+
+	return nil
+}
+
+func writeFile(path string, reader io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+
+	return err
+}
@@ -1,16 +1,27 @@
 package getter_test
 
 import (
+	"context"
+	"encoding/base64"
 	"net/http"
+	"net/http/cgi"
 	"net/http/httptest"
+	netURL "net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/testing/errors"
 	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
 	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
 	"github.com/manala/manala/internal/testing/heredoc"
 
 	"github.com/stretchr/testify/suite"
@@ -34,6 +45,26 @@ func (s *GitSuite) SetupSuite() {
 	mux.Handle("/repository.git/", http.StripPrefix("/repository.git/",
 		http.FileServer(http.Dir(filepath.FromSlash("testdata/GitSuite/repository.git"))),
 	))
+	mux.Handle("/versions.git/", http.StripPrefix("/versions.git/",
+		http.FileServer(http.Dir(filepath.FromSlash("testdata/GitSuite/versions.git"))),
+	))
+	mux.Handle("/recipes.git/", http.StripPrefix("/recipes.git/",
+		http.FileServer(http.Dir(filepath.FromSlash("testdata/GitSuite/recipes.git"))),
+	))
+	// Private repository, requiring basic auth
+	mux.Handle("/private.git/", http.StripPrefix("/private.git/",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			if !ok || password != "secret" || (username != "x-access-token" && username != "oauth2") {
+				w.Header().Set("WWW-Authenticate", `Basic realm="private"`)
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			http.FileServer(http.Dir(filepath.FromSlash("testdata/GitSuite/repository.git"))).ServeHTTP(w, r)
+		}),
+	))
 }
 
 func (s *GitSuite) TearDownSuite() {
@@ -52,7 +83,7 @@ func (s *GitSuite) TestLoaderHandler() {
 		chainMock := &repository.LoaderHandlerChainMock{}
 
 		handler := getter.NewGitLoaderHandler(log.Discard, cache)
-		repository, err := handler.Handle(&repository.LoaderQuery{URL: url}, chainMock)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
 
 		s.Require().NoError(err)
 		s.NotNil(repository)
@@ -72,7 +103,7 @@ func (s *GitSuite) TestLoaderHandler() {
 		chainMock := &repository.LoaderHandlerChainMock{}
 
 		handler := getter.NewGitLoaderHandler(log.Discard, cache)
-		repository, err := handler.Handle(&repository.LoaderQuery{URL: url}, chainMock)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
 
 		s.Require().NoError(err)
 		s.NotNil(repository)
@@ -84,3 +115,794 @@ func (s *GitSuite) TestLoaderHandler() {
 		`, filepath.Join(repository.Dir(), "README"))
 	})
 }
+
+func (s *GitSuite) TestLoaderHandlerRef() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	tests := []struct {
+		test            string
+		ref             string
+		expectedRef     string
+		expectedVersion string
+	}{
+		{test: "Default", ref: "", expectedRef: "", expectedVersion: "3.0.0"},
+		{test: "Branch", ref: "master", expectedRef: "master", expectedVersion: "3.0.0"},
+		{test: "Tag", ref: "v1.0.0", expectedRef: "v1.0.0", expectedVersion: "1.0.0"},
+		{test: "ConstraintCaret", ref: "^2.1", expectedRef: "v2.1.1", expectedVersion: "2.1.1"},
+		{test: "ConstraintTilde", ref: "~2.1.0", expectedRef: "v2.1.1", expectedVersion: "2.1.1"},
+		{test: "ConstraintRange", ref: ">=1, <3", expectedRef: "v2.1.1", expectedVersion: "2.1.1"},
+		{test: "ConstraintPrerelease", ref: "^2.2.0-0", expectedRef: "v2.2.0-beta", expectedVersion: "2.2.0-beta"},
+		{test: "ConstraintWildcard", ref: "1.x", expectedRef: "v1.0.0", expectedVersion: "1.0.0"},
+	}
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			_ = os.RemoveAll(cacheDir)
+
+			url := s.server.URL + "/versions.git"
+			if test.ref != "" {
+				url += "?" + netURL.Values{"ref": {test.ref}}.Encode()
+			}
+
+			chainMock := &repository.LoaderHandlerChainMock{}
+
+			handler := getter.NewGitLoaderHandler(log.Discard, cache)
+			repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+			s.Require().NoError(err)
+			chainMock.AssertExpectations(s.T())
+
+			s.Equal(url, repository.URL())
+			s.Equal(test.expectedRef, repository.Ref())
+			heredoc.EqualFile(s.T(), test.expectedVersion+"\n", filepath.Join(repository.Dir(), "VERSION"))
+		})
+	}
+
+	s.Run("ConstraintLocked", func() {
+		tests := []struct {
+			test            string
+			locked          string
+			expectedRef     string
+			expectedVersion string
+		}{
+			{test: "Matching", locked: "v2.1.0", expectedRef: "v2.1.0", expectedVersion: "2.1.0"},
+			{test: "NotMatching", locked: "v1.0.0", expectedRef: "v2.1.1", expectedVersion: "2.1.1"},
+		}
+		for _, test := range tests {
+			s.Run(test.test, func() {
+				_ = os.RemoveAll(cacheDir)
+
+				url := s.server.URL + "/versions.git?ref=^2.1"
+
+				chainMock := &repository.LoaderHandlerChainMock{}
+
+				ctx := app.WithRepositoryLockedRef(s.T().Context(), test.locked)
+
+				handler := getter.NewGitLoaderHandler(log.Discard, cache)
+				repository, err := handler.Handle(ctx, &repository.LoaderQuery{URL: url}, chainMock)
+
+				s.Require().NoError(err)
+				chainMock.AssertExpectations(s.T())
+
+				s.Equal(test.expectedRef, repository.Ref())
+				s.Equal("^2.1", repository.Constraint())
+				heredoc.EqualFile(s.T(), test.expectedVersion+"\n", filepath.Join(repository.Dir(), "VERSION"))
+			})
+		}
+	})
+
+	s.Run("ConstraintNotMatching", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		url := s.server.URL + "/versions.git?ref=^4"
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+		s.Nil(repository)
+		chainMock.AssertExpectations(s.T())
+
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg: "no repository version matching constraint",
+			Attrs: [][2]any{
+				{"url", url},
+				{"constraint", "^4"},
+			},
+		}, err)
+	})
+}
+
+func (s *GitSuite) TestLoaderHandlerCache() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	// Dedicated server, closed once repository fetched, ensuring no network access
+	fetch := func() string {
+		_ = os.RemoveAll(cacheDir)
+
+		server := httptest.NewServer(http.StripPrefix("/versions.git/",
+			http.FileServer(http.Dir(filepath.FromSlash("testdata/GitSuite/versions.git"))),
+		))
+		defer server.Close()
+
+		url := server.URL + "/versions.git?ref=^2.1"
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache)
+		_, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+		s.Require().NoError(err)
+
+		return url
+	}
+
+	s.Run("Metadata", func() {
+		url := fetch()
+
+		dir, _ := cache.WithDir("repositories").WithHashDir(url).Dir()
+
+		metadata, err := getter.ReadMetadata(dir)
+
+		s.Require().NoError(err)
+		s.Equal(url, metadata.URL)
+		s.Equal("v2.1.1", metadata.Ref)
+		s.WithinDuration(time.Now(), metadata.FetchedAt, time.Minute)
+		s.Positive(metadata.Size)
+	})
+
+	s.Run("Offline", func() {
+		url := fetch()
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithOffline(true))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+		s.Require().NoError(err)
+		chainMock.AssertExpectations(s.T())
+
+		s.Equal(url, repository.URL())
+		s.Equal("v2.1.1", repository.Ref())
+		heredoc.EqualFile(s.T(), "2.1.1\n", filepath.Join(repository.Dir(), "VERSION"))
+	})
+
+	s.Run("OfflineNotCached", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		url := s.server.URL + "/versions.git"
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithOffline(true))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+		s.Nil(repository)
+		chainMock.AssertExpectations(s.T())
+
+		expectation.ExpectError(s.T(), errors.Expectation{
+			Type:  &app.OfflineRepositoryError{},
+			Attrs: [][2]any{{"url", url}},
+		}, err)
+	})
+
+	s.Run("Fresh", func() {
+		url := fetch()
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithCacheTTL(time.Hour))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+		s.Require().NoError(err)
+		chainMock.AssertExpectations(s.T())
+
+		s.Equal("v2.1.1", repository.Ref())
+	})
+
+	s.Run("Stale", func() {
+		url := fetch()
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithCacheTTL(time.Nanosecond))
+		stale, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+		// Refetch attempt, against closed server
+		s.Nil(stale)
+		s.Error(err)
+		chainMock.AssertExpectations(s.T())
+
+		// Former entry left consistent
+		handler = getter.NewGitLoaderHandler(log.Discard, cache, getter.WithOffline(true))
+		cached, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+		s.Require().NoError(err)
+		s.Equal("v2.1.1", cached.Ref())
+		heredoc.EqualFile(s.T(), "2.1.1\n", filepath.Join(cached.Dir(), "VERSION"))
+	})
+}
+
+func (s *GitSuite) TestLoaderHandlerAbort() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	s.Run("Cancel", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		url := s.server.URL + "/versions.git"
+
+		ctx, cancel := context.WithCancel(s.T().Context())
+		cancel()
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache)
+		repository, err := handler.Handle(ctx, &repository.LoaderQuery{URL: url}, chainMock)
+
+		s.Nil(repository)
+		chainMock.AssertExpectations(s.T())
+
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "repository fetching aborted",
+			Attrs: [][2]any{{"url", url}},
+			Err:   expectation.ErrorMessage("context canceled"),
+		}, err)
+
+		// Cache stays consistent
+		dir, _ := cache.WithDir("repositories").WithHashDir(url).Dir()
+		s.NoDirExists(dir)
+		s.NoFileExists(dir + ".json")
+	})
+
+	s.Run("Timeout", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		// Slow server
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer server.Close()
+
+		url := server.URL + "/versions.git"
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithTimeout(100*time.Millisecond))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+		s.Nil(repository)
+		chainMock.AssertExpectations(s.T())
+
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "repository fetching aborted",
+			Attrs: [][2]any{{"url", url}},
+			Err:   expectation.ErrorMessage("context deadline exceeded"),
+		}, err)
+	})
+}
+
+func (s *GitSuite) TestLoaderHandlerStore() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	_ = os.RemoveAll(cacheDir)
+
+	handler := getter.NewGitLoaderHandler(log.Discard, cache)
+
+	for _, ref := range []string{"v1.0.0", "v2.0.0"} {
+		url := s.server.URL + "/versions.git?ref=" + ref
+
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Require().NoError(err)
+		heredoc.EqualFile(s.T(), ref[1:]+"\n", filepath.Join(repository.Dir(), "VERSION"))
+	}
+
+	// Single store, shared across refs
+	stores, _ := os.ReadDir(filepath.Join(cacheDir, "git"))
+	s.Len(stores, 1)
+}
+
+func (s *GitSuite) TestLoaderHandlerShallow() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	_ = os.RemoveAll(cacheDir)
+
+	// Smart http server, as dumb one does not support shallow fetching
+	gitPath, err := exec.LookPath("git")
+	s.Require().NoError(err)
+
+	root := s.T().TempDir()
+	s.Require().NoError(os.CopyFS(filepath.Join(root, "recipes.git"), os.DirFS(filepath.FromSlash("testdata/GitSuite/recipes.git"))))
+	s.Require().NoError(os.MkdirAll(filepath.Join(root, "recipes.git", "refs"), 0o755))
+
+	server := httptest.NewServer(&cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	defer server.Close()
+
+	url := server.URL + "/recipes.git"
+
+	handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitShallow(true))
+	repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+	s.Require().NoError(err)
+	s.FileExists(filepath.Join(repository.Dir(), "foo", ".manala.yaml"))
+
+	store, _ := cache.WithDir("git").WithHashDir(url).Dir()
+	s.FileExists(filepath.Join(store, "shallow"))
+}
+
+func (s *GitSuite) TestLoaderHandlerSparse() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	url := s.server.URL + "/recipes.git"
+
+	s.Run("Recipe", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitSparse(true))
+		repository, err := handler.Handle(app.WithRecipeName(s.T().Context(), "foo"), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Require().NoError(err)
+		s.FileExists(filepath.Join(repository.Dir(), "foo", ".manala.yaml"))
+		s.NoDirExists(filepath.Join(repository.Dir(), "bar"))
+	})
+
+	s.Run("NoRecipe", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitSparse(true))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Require().NoError(err)
+		s.DirExists(filepath.Join(repository.Dir(), "foo"))
+		s.DirExists(filepath.Join(repository.Dir(), "bar"))
+	})
+
+	s.Run("Offline", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		ctx := app.WithRecipeName(s.T().Context(), "foo")
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitSparse(true))
+		_, err := handler.Handle(ctx, &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+		s.Require().NoError(err)
+
+		// Sparse entry served to its recipe
+		handler = getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitSparse(true), getter.WithOffline(true))
+		sparse, err := handler.Handle(ctx, &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Require().NoError(err)
+		s.FileExists(filepath.Join(sparse.Dir(), "foo", ".manala.yaml"))
+
+		// Never served as the full repository
+		handler = getter.NewGitLoaderHandler(log.Discard, cache, getter.WithOffline(true))
+		full, err := handler.Handle(ctx, &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(full)
+		expectation.ExpectError(s.T(), errors.Expectation{
+			Type:  &app.OfflineRepositoryError{},
+			Attrs: [][2]any{{"url", url}},
+		}, err)
+	})
+
+	s.Run("NotFoundRecipe", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitSparse(true))
+		repository, err := handler.Handle(app.WithRecipeName(s.T().Context(), "baz"), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Require().NoError(err)
+		entries, _ := os.ReadDir(repository.Dir())
+		s.Empty(entries)
+	})
+}
+
+func (s *GitSuite) TestLoaderHandlerAuth() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	url := s.server.URL + "/private.git"
+
+	tests := []struct {
+		test string
+		auth *getter.GitAuth
+	}{
+		{
+			test: "Token",
+			auth: &getter.GitAuth{
+				Default: getter.GitCredentials{Token: "secret"},
+			},
+		},
+		{
+			test: "HostToken",
+			auth: &getter.GitAuth{
+				Default: getter.GitCredentials{Token: "default"},
+				Hosts: map[string]getter.GitCredentials{
+					"127.0.0.1": {Token: "secret", Username: "oauth2"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			_ = os.RemoveAll(cacheDir)
+
+			chainMock := &repository.LoaderHandlerChainMock{}
+
+			handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitAuth(test.auth))
+			repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+			s.Require().NoError(err)
+			chainMock.AssertExpectations(s.T())
+
+			heredoc.EqualFile(s.T(), `
+				Hello World!
+			`, filepath.Join(repository.Dir(), "README"))
+
+			// Token is not persisted
+			config, _ := os.ReadFile(filepath.Join(repository.Dir(), ".git", "config"))
+			s.NotContains(string(config), "secret")
+		})
+	}
+
+	s.Run("Unauthorized", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		s.T().Setenv("GIT_TERMINAL_PROMPT", "0")
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitAuth(&getter.GitAuth{
+			Default: getter.GitCredentials{Token: "invalid"},
+		}))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
+
+		s.Nil(repository)
+		s.Require().Error(err)
+		s.NotContains(err.Error(), "invalid")
+	})
+
+	s.Run("SSHKey", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		// Fake ssh command, recording its arguments
+		dir := s.T().TempDir()
+		argsFile := filepath.Join(dir, "args")
+		sshFile := filepath.Join(dir, "ssh")
+		_ = os.WriteFile(sshFile, []byte("#!/bin/sh\necho \"$@\" > "+argsFile+"\nexit 1\n"), 0o755)
+
+		s.T().Setenv("GIT_SSH_COMMAND", sshFile)
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitAuth(&getter.GitAuth{
+			Hosts: map[string]getter.GitCredentials{
+				"example.com": {SSHKey: "/path/to/key"},
+			},
+		}))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: "ssh://git@example.com/repository.git"}, chainMock)
+
+		s.Nil(repository)
+		s.Require().Error(err)
+
+		args, _ := os.ReadFile(argsFile)
+		s.Contains(string(args), "-i /path/to/key -o IdentitiesOnly=yes ")
+	})
+
+	s.Run("SSHKeyQuery", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		// Fake ssh command, recording its arguments and identity file
+		dir := s.T().TempDir()
+		argsFile := filepath.Join(dir, "args")
+		keyFile := filepath.Join(dir, "key")
+		sshFile := filepath.Join(dir, "ssh")
+		_ = os.WriteFile(sshFile, []byte("#!/bin/sh\necho \"$@\" > "+argsFile+"\ncat \"$2\" > "+keyFile+"\nexit 1\n"), 0o755)
+
+		s.T().Setenv("GIT_SSH_COMMAND", sshFile)
+
+		chainMock := &repository.LoaderHandlerChainMock{}
+
+		handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitAuth(&getter.GitAuth{
+			Hosts: map[string]getter.GitCredentials{
+				"example.com": {SSHKey: "/path/to/key"},
+			},
+		}))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: "ssh://git@example.com/repository.git?sshkey=" + netURL.QueryEscape(base64.StdEncoding.EncodeToString([]byte("key")))}, chainMock)
+
+		s.Nil(repository)
+		s.Require().Error(err)
+
+		// Query key takes precedence over auth one
+		args, _ := os.ReadFile(argsFile)
+		s.NotContains(string(args), "/path/to/key")
+		s.Contains(string(args), " -o IdentitiesOnly=yes ")
+
+		key, _ := os.ReadFile(keyFile)
+		s.Equal("key", string(key))
+	})
+
+	s.Run("VersionsLister", func() {
+		lister := getter.NewGitVersionsLister(log.Discard, getter.WithGitAuth(&getter.GitAuth{
+			Default: getter.GitCredentials{Token: "secret"},
+		}))
+
+		_, err := lister.List(s.T().Context(), url)
+
+		s.Require().NoError(err)
+	})
+}
+
+func (s *GitSuite) TestLoaderHandlerSignature() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	dir := s.T().TempDir()
+	key, allowedSigners := sshSigner(s.T(), s.T().TempDir())
+	unknownKey, _ := sshSigner(s.T(), s.T().TempDir())
+
+	// Repository, with signed, unknown signer and unsigned tags
+	git := func(args ...string) {
+		output, err := exec.Command("git", append([]string{
+			"-C", filepath.Join(dir, "src"),
+			"-c", "user.name=Signer", "-c", "user.email=signer@example.com", "-c", "gpg.format=ssh",
+		}, args...)...).CombinedOutput()
+		s.Require().NoError(err, string(output))
+	}
+
+	s.Require().NoError(os.MkdirAll(filepath.Join(dir, "src", "recipe"), 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "src", "recipe", ".manala.yaml"), []byte("manala: {}\n"), 0o644))
+	git("init", "--quiet")
+	git("add", ".")
+	git("commit", "--quiet", "--message", "Recipe")
+	git("-c", "user.signingkey="+key, "tag", "--sign", "--message", "Signed", "signed")
+	git("-c", "user.signingkey="+unknownKey, "tag", "--sign", "--message", "Unknown", "unknown")
+	git("tag", "--annotate", "--message", "Unsigned", "unsigned")
+	git("tag", "lightweight")
+	git("branch", "branch")
+	git("clone", "--quiet", "--bare", ".", filepath.Join(dir, "recipes.git"))
+
+	// Smart http server, to support shallow fetching
+	gitPath, err := exec.LookPath("git")
+	s.Require().NoError(err)
+
+	server := httptest.NewServer(&cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + dir, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	defer server.Close()
+
+	for _, shallow := range []bool{false, true} {
+		s.Run("Signed/Shallow="+strconv.FormatBool(shallow), func() {
+			_ = os.RemoveAll(cacheDir)
+
+			handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithGitShallow(shallow), getter.WithAllowedSigners(allowedSigners))
+			repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: server.URL + "/recipes.git?ref=signed"}, &repository.LoaderHandlerChainMock{})
+
+			s.Require().NoError(err)
+			s.FileExists(filepath.Join(repository.Dir(), "recipe", ".manala.yaml"))
+		})
+	}
+
+	tests := []struct {
+		test   string
+		ref    string
+		reason string
+	}{
+		{test: "UnknownSigner", ref: "unknown", reason: "invalid signature"},
+		{test: "Unsigned", ref: "unsigned", reason: "invalid signature"},
+		{test: "Lightweight", ref: "lightweight", reason: "unsigned ref"},
+		{test: "Branch", ref: "branch", reason: "unsigned ref"},
+		{test: "NoRef", ref: "", reason: "unsigned ref"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			_ = os.RemoveAll(cacheDir)
+
+			url := server.URL + "/recipes.git"
+			if test.ref != "" {
+				url += "?ref=" + test.ref
+			}
+
+			handler := getter.NewGitLoaderHandler(log.Discard, cache, getter.WithAllowedSigners(allowedSigners))
+			repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+			s.Nil(repository)
+			expectation.ExpectError(s.T(), errors.Expectation{
+				Type:  &app.UnverifiedRepositoryError{},
+				Attrs: [][2]any{{"url", server.URL + "/recipes.git"}, {"reason", test.reason}},
+			}, err)
+		})
+	}
+}
+
+func (s *GitSuite) TestLoaderHandlerSymlink() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	tests := []struct {
+		test string
+		link string
+	}{
+		{test: "Absolute", link: "/etc"},
+		{test: "OutOfRepository", link: "../.."},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			_ = os.RemoveAll(cacheDir)
+
+			dir := s.T().TempDir()
+
+			// Repository, with a symlink pointing outside
+			git := func(args ...string) {
+				output, err := exec.Command("git", append([]string{
+					"-C", filepath.Join(dir, "src"),
+					"-c", "user.name=User", "-c", "user.email=user@example.com",
+				}, args...)...).CombinedOutput()
+				s.Require().NoError(err, string(output))
+			}
+
+			s.Require().NoError(os.MkdirAll(filepath.Join(dir, "src", "recipe"), 0o755))
+			s.Require().NoError(os.WriteFile(filepath.Join(dir, "src", "recipe", ".manala.yaml"), []byte("manala: {}\n"), 0o644))
+			s.Require().NoError(os.Symlink(test.link, filepath.Join(dir, "src", "recipe", "link")))
+			git("init", "--quiet")
+			git("add", ".")
+			git("commit", "--quiet", "--message", "Recipe")
+
+			handler := getter.NewGitLoaderHandler(log.Discard, cache)
+			repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: "git::file://" + filepath.Join(dir, "src")}, &repository.LoaderHandlerChainMock{})
+
+			s.Nil(repository)
+			expectation.ExpectError(s.T(), serrortest.Expectation{
+				Msg: "unable to extract repository archive",
+				Attrs: [][2]any{
+					{"path", "recipe/link"},
+				},
+				Err: serrortest.Expectation{
+					Msg: "symlink out of repository",
+					Attrs: [][2]any{
+						{"link", test.link},
+					},
+				},
+			}, err)
+		})
+	}
+}
+
+func (s *GitSuite) TestLoaderHandlerQueryErrors() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	_ = os.RemoveAll(cacheDir)
+
+	url := s.server.URL + "/repository.git?archive=false"
+
+	handler := getter.NewGitLoaderHandler(log.Discard, cache)
+	repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+	s.Nil(repository)
+	expectation.ExpectError(s.T(), serrortest.Expectation{
+		Msg: "unsupported git repository query",
+		Attrs: [][2]any{
+			{"url", url},
+			{"query", "archive"},
+		},
+	}, err)
+}
+
+func (s *GitSuite) TestLoaderHandlerOptions() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	_ = os.RemoveAll(cacheDir)
+
+	handler := getter.NewGitLoaderHandler(log.Discard, cache)
+
+	dir := s.T().TempDir()
+	injected := filepath.Join(dir, "injected")
+
+	s.Run("Remote", func() {
+		url := "git::--upload-pack=touch " + injected
+
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(repository)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "invalid git repository remote",
+			Attrs: [][2]any{{"remote", "--upload-pack=touch " + injected}},
+		}, err)
+		s.NoFileExists(injected)
+	})
+
+	s.Run("Ref", func() {
+		url := s.server.URL + "/repository.git?ref=--upload-pack=touch " + injected
+
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(repository)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "invalid git repository ref",
+			Attrs: [][2]any{{"ref", "--upload-pack=touch " + injected}},
+		}, err)
+		s.NoFileExists(injected)
+	})
+}
+
+func (s *GitSuite) TestVersionsLister() {
+	lister := getter.NewGitVersionsLister(log.Discard)
+
+	versions, err := lister.List(s.T().Context(), s.server.URL+"/versions.git?ref=master")
+
+	s.Require().NoError(err)
+
+	var tags []string
+	for _, version := range versions {
+		tags = append(tags, version.Original())
+	}
+
+	s.Equal([]string{"v3.0.0", "v2.2.0-beta", "v2.1.1", "v2.1.0", "v2.0.0", "v1.0.0"}, tags)
+
+	s.Run("Offline", func() {
+		lister := getter.NewGitVersionsLister(log.Discard, getter.WithOffline(true))
+
+		url := s.server.URL + "/versions.git"
+
+		versions, err := lister.List(s.T().Context(), url)
+
+		s.Nil(versions)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "unable to list repository versions offline",
+			Attrs: [][2]any{{"url", url}},
+		}, err)
+	})
+
+	s.Run("RemoteOption", func() {
+		dir := s.T().TempDir()
+
+		versions, err := lister.List(s.T().Context(), "git::--upload-pack=touch "+filepath.Join(dir, "injected")+"?ref=^1")
+
+		s.Nil(versions)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "invalid git repository remote",
+			Attrs: [][2]any{{"remote", "--upload-pack=touch " + filepath.Join(dir, "injected")}},
+		}, err)
+		s.NoFileExists(filepath.Join(dir, "injected"))
+	})
+}
+
+func (s *GitSuite) TestVersionConstraint() {
+	tests := []struct {
+		test     string
+		ref      string
+		expected bool
+	}{
+		{test: "Empty", ref: "", expected: false},
+		{test: "Branch", ref: "main", expected: false},
+		{test: "Commit", ref: "0e59785", expected: false},
+		{test: "Version", ref: "v1.2.3", expected: false},
+		{test: "VersionWithoutPrefix", ref: "1.2.3", expected: false},
+		{test: "Caret", ref: "^1.2", expected: true},
+		{test: "Tilde", ref: "~1.2.3", expected: true},
+		{test: "Range", ref: ">=1.2, <2", expected: true},
+		{test: "Wildcard", ref: "1.x", expected: true},
+	}
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			_, ok := getter.VersionConstraint(test.ref)
+			s.Equal(test.expected, ok)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package getter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	netURL "net/url"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/hashicorp/go-getter/v2"
+)
+
+// GitVersionsLister lists remote git repository versions, based on its semver tags.
+type GitVersionsLister struct {
+	log     *log.Log
+	getter  *getter.GitGetter
+	options *getterOptions
+}
+
+func NewGitVersionsLister(log *log.Log, opts ...Option) *GitVersionsLister {
+	return &GitVersionsLister{
+		log:     log,
+		options: newOptions(opts...),
+		getter: &getter.GitGetter{
+			Detectors: []getter.Detector{
+				&getter.GitHubDetector{},
+				&getter.GitDetector{},
+				&getter.BitBucketDetector{},
+				&getter.GitLabDetector{},
+			},
+		},
+	}
+}
+
+// List remote repository versions, from newest to oldest.
+func (lister *GitVersionsLister) List(ctx context.Context, url string) ([]*semver.Version, error) {
+	if lister.options.offline {
+		return nil, serror.New("unable to list repository versions offline").
+			With("url", url)
+	}
+
+	remote, err := lister.remote(url)
+	if err != nil {
+		return nil, err
+	}
+
+	lister.log.Debug("list repository versions", "url", url, "remote", remote)
+
+	ctx, cancel := lister.options.withTimeout(ctx)
+	defer cancel()
+
+	// Legacy: go-getter ssh key query
+	_, rawQuery, _ := strings.Cut(url, "?")
+	values, _ := netURL.ParseQuery(rawQuery)
+
+	sshKeyEnv, cleanup, err := sshKeyEnv(lister.log, values.Get("sshkey"))
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	command := exec.CommandContext(ctx, "git", "ls-remote", "--tags", "--refs", "--", remote)
+	command.Env = append(os.Environ(), lister.options.gitAuth.env(lister.log, remote)...)
+	command.Env = append(command.Env, sshKeyEnv...)
+
+	output, err := command.Output()
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, serror.New("repository versions listing aborted").
+				With("url", url).
+				WithErr(ctxErr)
+		}
+
+		serr := serror.New("unable to list repository versions").
+			With("url", url)
+		if err, ok := errors.AsType[*exec.ExitError](err); ok {
+			return nil, serr.WithDump(string(bytes.TrimSpace(err.Stderr)))
+		}
+		return nil, serr.WithErr(err)
+	}
+
+	var versions []*semver.Version
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		_, ref, _ := strings.Cut(scanner.Text(), "\t")
+
+		tag, ok := strings.CutPrefix(ref, "refs/tags/")
+		if !ok {
+			continue
+		}
+
+		// Skip non semver tags
+		version, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+
+		versions = append(versions, version)
+	}
+
+	slices.SortFunc(versions, func(a, b *semver.Version) int {
+		return b.Compare(a)
+	})
+
+	return versions, nil
+}
+
+// Resolve the newest remote repository version matching constraint.
+func (lister *GitVersionsLister) Resolve(ctx context.Context, url string, constraint *semver.Constraints) (*semver.Version, error) {
+	versions, err := lister.List(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		if constraint.Check(version) {
+			return version, nil
+		}
+	}
+
+	return nil, serror.New("no repository version matching constraint").
+		With("url", url, "constraint", constraint.String())
+}
+
+// remote extracts a git remote from a getter url, without forced getter, subdir and query.
+func (lister *GitVersionsLister) remote(url string) (string, error) {
+	url, _ = strings.CutPrefix(url, "git::")
+	url, _, _ = strings.Cut(url, "?")
+	url, _ = getter.SourceDirSubdir(url)
+
+	request := &getter.Request{
+		Src:    url,
+		Forced: "git",
+	}
+
+	if _, err := lister.getter.Detect(request); err != nil {
+		return "", ErrorFrom(err)
+	}
+
+	// Never let a remote be taken as a git option
+	if strings.HasPrefix(request.Src, "-") {
+		return "", serror.New("invalid git repository remote").
+			With("remote", request.Src)
+	}
+
+	return request.Src, nil
+}
+
+// VersionConstraint parses a ref as a semver version constraint.
+// Refs made of plain versions, branches or commits are left aside.
+func VersionConstraint(ref string) (*semver.Constraints, bool) {
+	if ref == "" {
+		return nil, false
+	}
+
+	// Plain version
+	if _, err := semver.NewVersion(ref); err == nil {
+		return nil, false
+	}
+
+	constraint, err := semver.NewConstraint(ref)
+	if err != nil {
+		return nil, false
+	}
+
+	return constraint, true
+}
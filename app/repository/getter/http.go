@@ -13,12 +13,13 @@ import (
 )
 
 type HTTPLoaderHandler struct {
-	log    *log.Log
-	cache  *cache.Cache
-	client *getter.Client
+	log     *log.Log
+	cache   *cache.Cache
+	client  *getter.Client
+	options *getterOptions
 }
 
-func NewHTTPLoaderHandler(log *log.Log, cache *cache.Cache) *HTTPLoaderHandler {
+func NewHTTPLoaderHandler(log *log.Log, cache *cache.Cache, opts ...Option) *HTTPLoaderHandler {
 	return &HTTPLoaderHandler{
 		log:   log,
 		cache: cache.WithDir("repositories"),
@@ -39,10 +40,11 @@ func NewHTTPLoaderHandler(log *log.Log, cache *cache.Cache) *HTTPLoaderHandler {
 			},
 			Decompressors: getter.Decompressors,
 		},
+		options: newOptions(opts...),
 	}
 }
 
-func (handler *HTTPLoaderHandler) Handle(query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
+func (handler *HTTPLoaderHandler) Handle(ctx context.Context, query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
 	handler.log.Debug("handle repository", "handler", "getter.http", "url", query.URL)
 
 	// Cache dir
@@ -60,15 +62,32 @@ func (handler *HTTPLoaderHandler) Handle(query *repository.LoaderQuery, chain re
 		GetMode: getter.ModeDir,
 	}
 
-	response, err := handler.client.Get(context.Background(), request)
+	// Serve from cache
+	if detect(handler.client, request) {
+		repository, ok, err := handler.options.fromCache(handler.log, query.URL, cacheDir, "")
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return repository, nil
+		}
+	}
+
+	response, err := handler.options.fetch(ctx, handler.log, handler.client, request)
 	if err != nil {
 		if IsNotDetected(err) {
 			// Chain
-			return chain.Next(query)
+			return chain.Next(ctx, query)
 		}
 
-		return nil, ErrorFrom(err)
+		return nil, err
+	}
+
+	repository := NewRepository(query.URL, response.Dst)
+
+	if err := toCache(repository); err != nil {
+		return nil, err
 	}
 
-	return NewRepository(query.URL, response.Dst), nil
+	return repository, nil
 }
@@ -1,16 +1,22 @@
 package getter_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/testing/errors"
 	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
 	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
 	"github.com/manala/manala/internal/testing/heredoc"
 
 	"github.com/stretchr/testify/suite"
@@ -52,7 +58,7 @@ func (s *HTTPSuite) TestLoaderHandler() {
 		chainMock := &repository.LoaderHandlerChainMock{}
 
 		handler := getter.NewHTTPLoaderHandler(log.Discard, cache)
-		repository, err := handler.Handle(&repository.LoaderQuery{URL: url}, chainMock)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
 
 		s.Require().NoError(err)
 		s.NotNil(repository)
@@ -72,7 +78,7 @@ func (s *HTTPSuite) TestLoaderHandler() {
 		chainMock := &repository.LoaderHandlerChainMock{}
 
 		handler := getter.NewHTTPLoaderHandler(log.Discard, cache)
-		repository, err := handler.Handle(&repository.LoaderQuery{URL: url}, chainMock)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
 
 		s.Require().NoError(err)
 		s.NotNil(repository)
@@ -84,3 +90,117 @@ func (s *HTTPSuite) TestLoaderHandler() {
 		`, filepath.Join(repository.Dir(), "README"))
 	})
 }
+
+func (s *HTTPSuite) TestLoaderHandlerChecksum() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	archive, _ := os.ReadFile(filepath.FromSlash("testdata/HTTPSuite/archive.zip"))
+	checksum := sha256.Sum256(archive)
+
+	s.Run("Valid", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		url := s.server.URL + "/archive.zip?checksum=sha256:" + hex.EncodeToString(checksum[:])
+
+		handler := getter.NewHTTPLoaderHandler(log.Discard, cache)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Require().NoError(err)
+		s.FileExists(filepath.Join(repository.Dir(), "Hello-World-master", "README"))
+	})
+
+	s.Run("Mismatch", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		url := s.server.URL + "/archive.zip?checksum=sha256:" + hex.EncodeToString(make([]byte, sha256.Size))
+
+		handler := getter.NewHTTPLoaderHandler(log.Discard, cache)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(repository)
+		expectation.ExpectError(s.T(), errors.Expectation{
+			Type:  &app.UnverifiedRepositoryError{},
+			Attrs: [][2]any{{"url", url}, {"reason", "checksum mismatch"}},
+		}, err)
+	})
+}
+
+func (s *HTTPSuite) TestLoaderHandlerSignature() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	// Signed archives
+	dir := s.T().TempDir()
+	key, allowedSigners := sshSigner(s.T(), dir)
+	unknownKey, _ := sshSigner(s.T(), s.T().TempDir())
+
+	archive, _ := os.ReadFile(filepath.FromSlash("testdata/HTTPSuite/archive.zip"))
+	for name, key := range map[string]string{"signed.zip": key, "unknown.zip": unknownKey, "tampered.zip": key, "unsigned.zip": ""} {
+		_ = os.WriteFile(filepath.Join(dir, name), archive, 0o644)
+		if key != "" {
+			sshSign(s.T(), key, filepath.Join(dir, name))
+		}
+	}
+	_ = os.WriteFile(filepath.Join(dir, "tampered.zip"), append(archive, 0), 0o644)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+
+	s.Run("Valid", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		handler := getter.NewHTTPLoaderHandler(log.Discard, cache, getter.WithAllowedSigners(allowedSigners))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: server.URL + "/signed.zip//Hello-World-master"}, &repository.LoaderHandlerChainMock{})
+
+		s.Require().NoError(err)
+		heredoc.EqualFile(s.T(), `
+			Hello World!
+		`, filepath.Join(repository.Dir(), "README"))
+	})
+
+	tests := []struct {
+		test   string
+		file   string
+		reason string
+	}{
+		{test: "UnknownSigner", file: "unknown.zip", reason: "unknown signer"},
+		{test: "Tampered", file: "tampered.zip", reason: "invalid signature"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			_ = os.RemoveAll(cacheDir)
+
+			url := server.URL + "/" + test.file
+
+			handler := getter.NewHTTPLoaderHandler(log.Discard, cache, getter.WithAllowedSigners(allowedSigners))
+			repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+			s.Nil(repository)
+			expectation.ExpectError(s.T(), errors.Expectation{
+				Type:  &app.UnverifiedRepositoryError{},
+				Attrs: [][2]any{{"url", url}, {"reason", test.reason}},
+			}, err)
+		})
+	}
+
+	s.Run("Unsigned", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		url := server.URL + "/unsigned.zip"
+
+		handler := getter.NewHTTPLoaderHandler(log.Discard, cache, getter.WithAllowedSigners(allowedSigners))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(repository)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "unable to fetch repository signature",
+			Attrs: [][2]any{{"src", url + ".sig"}},
+			Err: serrortest.Expectation{
+				Msg:   "unable to handle repository",
+				Attrs: [][2]any{{"error", "bad response code: 404"}},
+			},
+		}, err)
+	})
+}
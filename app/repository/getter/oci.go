@@ -0,0 +1,230 @@
+package getter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	netURL "net/url"
+	"strings"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+// OCILoaderHandler pulls repositories distributed as oci artifacts (oci://registry/namespace/recipes:tag),
+// unpacking their tar layers. Artifacts could be pinned by digest (oci://registry/namespace/recipes@sha256:...).
+type OCILoaderHandler struct {
+	log     *log.Log
+	cache   *cache.Cache
+	options *getterOptions
+}
+
+func NewOCILoaderHandler(log *log.Log, cache *cache.Cache, opts ...Option) *OCILoaderHandler {
+	return &OCILoaderHandler{
+		log:     log,
+		cache:   cache.WithDir("repositories"),
+		options: newOptions(opts...),
+	}
+}
+
+func (handler *OCILoaderHandler) Handle(ctx context.Context, query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
+	handler.log.Debug("handle repository", "handler", "getter.oci", "url", query.URL)
+
+	src, ok := strings.CutPrefix(query.URL, "oci://")
+	if !ok {
+		// Chain
+		return chain.Next(ctx, query)
+	}
+
+	// Artifacts could not be verified against allowed signers
+	if handler.options.allowedSigners != "" {
+		return nil, &app.UnverifiedRepositoryError{URL: query.URL, Reason: "unverifiable source"}
+	}
+
+	// Cache dir
+	cacheDir, err := handler.cache.
+		WithHashDir(query.URL).
+		Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	// Serve from cache
+	repository, ok, err := handler.options.fromCache(handler.log, query.URL, cacheDir, "")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return repository, nil
+	}
+
+	// Reference
+	reference, err := OCIReference(src)
+	if err != nil {
+		return nil, serror.New("invalid oci reference").
+			With("url", query.URL).
+			WithErr(err)
+	}
+
+	ctx, cancel := handler.options.withTimeout(ctx)
+	defer cancel()
+
+	digest, err := handler.pull(ctx, reference, cacheDir)
+	if err != nil {
+		if err := aborted(ctx, query.URL, cacheDir); err != nil {
+			return nil, err
+		}
+
+		return nil, err
+	}
+
+	repository = NewRepository(query.URL, cacheDir)
+	repository.ref = digest
+
+	if err := toCache(repository); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+// pull an artifact, unpacking its layers into dir, and returns its manifest digest.
+func (handler *OCILoaderHandler) pull(ctx context.Context, reference registry.Reference, dir string) (string, error) {
+	serr := serror.New("unable to pull oci artifact").
+		With("reference", reference.String())
+
+	repository, err := remote.NewRepository(reference.String())
+	if err != nil {
+		return "", serr.WithErr(err)
+	}
+
+	// Registries on loopback are reached through plain http, as docker does
+	repository.PlainHTTP = isLoopback(reference.Host())
+
+	// Docker credentials, when available
+	client := &auth.Client{
+		Client: retry.DefaultClient,
+		Cache:  auth.NewCache(),
+	}
+	if store, err := credentials.NewStoreFromDocker(credentials.StoreOptions{}); err == nil {
+		client.Credential = credentials.Credential(store)
+	}
+	repository.Client = client
+
+	// Manifest, verified against its digest
+	handler.log.Debug("resolve oci artifact", "reference", reference.String())
+
+	descriptor, err := repository.Resolve(ctx, reference.ReferenceOrDefault())
+	if err != nil {
+		return "", serr.WithErr(err)
+	}
+
+	manifestContent, err := content.FetchAll(ctx, repository, descriptor)
+	if err != nil {
+		return "", serr.WithErr(err)
+	}
+
+	manifest := &ocispec.Manifest{}
+	if err := json.Unmarshal(manifestContent, manifest); err != nil {
+		return "", serr.WithErr(err)
+	}
+
+	// Unpack layers, into a dir left untouched on failure
+	if err := extract(dir, func(tmp string) error {
+		var unpacked int
+
+		for _, layer := range manifest.Layers {
+			compressed := strings.HasSuffix(layer.MediaType, "tar+gzip")
+			if !compressed && !strings.HasSuffix(layer.MediaType, ".tar") {
+				handler.log.Debug("skip oci artifact layer", "media_type", layer.MediaType, "digest", layer.Digest)
+
+				continue
+			}
+
+			handler.log.Debug("unpack oci artifact layer", "media_type", layer.MediaType, "digest", layer.Digest)
+
+			// Layer, verified against its digest
+			blob, err := content.FetchAll(ctx, repository, layer)
+			if err != nil {
+				return serr.WithErr(err)
+			}
+
+			var reader io.Reader = bytes.NewReader(blob)
+			if compressed {
+				if reader, err = gzip.NewReader(reader); err != nil {
+					return serr.WithErr(err)
+				}
+			}
+
+			if err := untar(reader, tmp); err != nil {
+				return err
+			}
+
+			unpacked++
+		}
+
+		if unpacked == 0 {
+			return serror.New("no tar layer found in oci artifact").
+				With("reference", reference.String())
+		}
+
+		return nil
+	}); err != nil {
+		return "", err
+	}
+
+	return descriptor.Digest.String(), nil
+}
+
+// OCIReference parses an oci reference (registry/namespace/recipes:tag), with an optional ref query
+// (registry/namespace/recipes?ref=tag) taking precedence over its tag or digest.
+func OCIReference(src string) (registry.Reference, error) {
+	src, rawQuery, _ := strings.Cut(src, "?")
+
+	reference, err := registry.ParseReference(src)
+	if err != nil {
+		return registry.Reference{}, err
+	}
+
+	values, err := netURL.ParseQuery(rawQuery)
+	if err != nil {
+		return registry.Reference{}, err
+	}
+
+	if ref := values.Get("ref"); ref != "" {
+		reference.Reference = ref
+		if err := reference.ValidateReference(); err != nil {
+			return registry.Reference{}, err
+		}
+	}
+
+	return reference, nil
+}
+
+func isLoopback(host string) bool {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
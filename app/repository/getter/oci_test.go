@@ -0,0 +1,244 @@
+package getter_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/testing/errors"
+	"github.com/manala/manala/app/testing/mocks"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OCISuite struct {
+	suite.Suite
+
+	server *httptest.Server
+	host   string
+	digest digest.Digest
+}
+
+func TestOCISuite(t *testing.T) {
+	suite.Run(t, new(OCISuite))
+}
+
+func (s *OCISuite) SetupSuite() {
+	// Artifact layer, made of a single recipe
+	layer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(layer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range map[string]string{
+		"recipe/.manala.yaml": "manala:\n    description: Recipe\n",
+	} {
+		s.Require().NoError(tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, _ = tarWriter.Write([]byte(content))
+	}
+	s.Require().NoError(tarWriter.Close())
+	s.Require().NoError(gzipWriter.Close())
+
+	config := []byte("{}")
+
+	blobs := map[digest.Digest][]byte{
+		digest.FromBytes(layer.Bytes()): layer.Bytes(),
+		digest.FromBytes(config):        config,
+	}
+
+	manifest, _ := json.Marshal(ocispec.Manifest{
+		Versioned:    ocispec.Manifest{}.Versioned,
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.manala.recipes.v1",
+		Config: ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeEmptyJSON,
+			Digest:    digest.FromBytes(config),
+			Size:      int64(len(config)),
+		},
+		Layers: []ocispec.Descriptor{{
+			MediaType: ocispec.MediaTypeImageLayerGzip,
+			Digest:    digest.FromBytes(layer.Bytes()),
+			Size:      int64(layer.Len()),
+		}},
+	})
+	s.digest = digest.FromBytes(manifest)
+
+	tampered := digest.FromString("tampered")
+
+	// In-process registry, serving a single "namespace/recipes:1.4" artifact
+	write := func(w http.ResponseWriter, r *http.Request, mediaType string, content []byte) {
+		w.Header().Set("Content-Type", mediaType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Header().Set("Docker-Content-Digest", digest.FromBytes(content).String())
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(content)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/v2/namespace/recipes/manifests/{reference}", func(w http.ResponseWriter, r *http.Request) {
+		// Tampered digest serves the artifact manifest as well
+		if reference := r.PathValue("reference"); reference != "1.4" && reference != s.digest.String() && reference != tampered.String() {
+			http.NotFound(w, r)
+
+			return
+		}
+		write(w, r, ocispec.MediaTypeImageManifest, manifest)
+	})
+	mux.HandleFunc("/v2/namespace/recipes/blobs/{digest}", func(w http.ResponseWriter, r *http.Request) {
+		blob, ok := blobs[digest.Digest(r.PathValue("digest"))]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+		write(w, r, "application/octet-stream", blob)
+	})
+
+	s.server = httptest.NewServer(mux)
+	s.host = strings.TrimPrefix(s.server.URL, "http://")
+}
+
+func (s *OCISuite) TearDownSuite() {
+	s.server.Close()
+}
+
+func (s *OCISuite) TestLoaderHandler() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	tests := []struct {
+		test string
+		url  string
+	}{
+		{test: "Tag", url: "oci://" + s.host + "/namespace/recipes:1.4"},
+		{test: "Digest", url: "oci://" + s.host + "/namespace/recipes@" + s.digest.String()},
+		{test: "RefQuery", url: "oci://" + s.host + "/namespace/recipes:latest?ref=1.4"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			_ = os.RemoveAll(cacheDir)
+
+			chainMock := &repository.LoaderHandlerChainMock{}
+
+			handler := getter.NewOCILoaderHandler(log.Discard, cache)
+			repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: test.url}, chainMock)
+
+			s.Require().NoError(err)
+			chainMock.AssertExpectations(s.T())
+
+			s.Equal(test.url, repository.URL())
+			s.Equal(s.digest.String(), repository.Ref())
+			heredoc.EqualFile(s.T(), `
+				manala:
+				    description: Recipe
+			`, filepath.Join(repository.Dir(), "recipe", ".manala.yaml"))
+		})
+	}
+}
+
+func (s *OCISuite) TestLoaderHandlerChain() {
+	query := &repository.LoaderQuery{URL: "https://example.com/repository.git"}
+
+	repositoryMock := &mocks.Repository{}
+
+	chainMock := &repository.LoaderHandlerChainMock{}
+	chainMock.
+		On("Next", mock.Anything, query).Return(repositoryMock, nil)
+
+	handler := getter.NewOCILoaderHandler(log.Discard, cache.New(""))
+	repository, err := handler.Handle(s.T().Context(), query, chainMock)
+
+	s.Require().NoError(err)
+	s.Equal(repositoryMock, repository)
+	chainMock.AssertExpectations(s.T())
+}
+
+func (s *OCISuite) TestLoaderHandlerErrors() {
+	cacheDir := filepath.FromSlash("testdata/cache")
+	cache := cache.New(cacheDir)
+
+	s.Run("NotFound", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		url := "oci://" + s.host + "/namespace/recipes:1.5"
+
+		handler := getter.NewOCILoaderHandler(log.Discard, cache)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(repository)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "unable to pull oci artifact",
+			Attrs: [][2]any{{"reference", s.host + "/namespace/recipes:1.5"}},
+			Err:   expectation.ErrorMessage(s.host + "/namespace/recipes:1.5: not found"),
+		}, err)
+	})
+
+	s.Run("DigestMismatch", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		tampered := digest.FromString("tampered")
+		url := "oci://" + s.host + "/namespace/recipes@" + tampered.String()
+
+		handler := getter.NewOCILoaderHandler(log.Discard, cache)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(repository)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "unable to pull oci artifact",
+			Attrs: [][2]any{{"reference", s.host + "/namespace/recipes@" + tampered.String()}},
+			Err: expectation.ErrorMessage(`HEAD "http://` + s.host + `/v2/namespace/recipes/manifests/` + tampered.String() + `": ` +
+				`invalid response; digest mismatch in Docker-Content-Digest: received "` + s.digest.String() + `" when expecting "` + tampered.String() + `"`),
+		}, err)
+	})
+
+	s.Run("Unverifiable", func() {
+		_ = os.RemoveAll(cacheDir)
+
+		url := "oci://" + s.host + "/namespace/recipes:1.4"
+
+		handler := getter.NewOCILoaderHandler(log.Discard, cache, getter.WithAllowedSigners("allowed_signers"))
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(repository)
+		expectation.ExpectError(s.T(), errors.Expectation{
+			Type:  &app.UnverifiedRepositoryError{},
+			Attrs: [][2]any{{"url", url}, {"reason", "unverifiable source"}},
+		}, err)
+	})
+
+	s.Run("InvalidReference", func() {
+		url := "oci://" + s.host + "/Namespace/recipes:1.4"
+
+		handler := getter.NewOCILoaderHandler(log.Discard, cache)
+		repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, &repository.LoaderHandlerChainMock{})
+
+		s.Nil(repository)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "invalid oci reference",
+			Attrs: [][2]any{{"url", url}},
+			Err:   expectation.ErrorMessage("invalid reference: invalid repository \"Namespace/recipes\""),
+		}, err)
+	})
+}
@@ -1,8 +1,17 @@
 package getter
 
+import (
+	"github.com/manala/manala/app"
+)
+
 type Repository struct {
 	url string
 	dir string
+	ref string
+	// Version constraint ref was resolved from
+	constraint string
+	// Recipe a sparse repository is restricted to
+	recipe string
 }
 
 func NewRepository(url, dir string) *Repository {
@@ -19,3 +28,16 @@ func (repository *Repository) URL() string {
 func (repository *Repository) Dir() string {
 	return repository.dir
 }
+
+func (repository *Repository) Ref() string {
+	return repository.ref
+}
+
+func (repository *Repository) Constraint() string {
+	return repository.constraint
+}
+
+// Manifest of a fetched repository is left to repository manifest loader handler.
+func (repository *Repository) Manifest() app.RepositoryManifest {
+	return nil
+}
@@ -14,12 +14,13 @@ import (
 )
 
 type S3LoaderHandler struct {
-	log    *log.Log
-	cache  *cache.Cache
-	client *getter.Client
+	log     *log.Log
+	cache   *cache.Cache
+	client  *getter.Client
+	options *getterOptions
 }
 
-func NewS3LoaderHandler(log *log.Log, cache *cache.Cache) *S3LoaderHandler {
+func NewS3LoaderHandler(log *log.Log, cache *cache.Cache, opts ...Option) *S3LoaderHandler {
 	return &S3LoaderHandler{
 		log:   log,
 		cache: cache.WithDir("repositories"),
@@ -33,10 +34,11 @@ func NewS3LoaderHandler(log *log.Log, cache *cache.Cache) *S3LoaderHandler {
 			},
 			Decompressors: getter.Decompressors,
 		},
+		options: newOptions(opts...),
 	}
 }
 
-func (handler *S3LoaderHandler) Handle(query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
+func (handler *S3LoaderHandler) Handle(ctx context.Context, query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
 	handler.log.Debug("handle repository", "handler", "getter.s3", "url", query.URL)
 
 	// Cache dir
@@ -54,15 +56,32 @@ func (handler *S3LoaderHandler) Handle(query *repository.LoaderQuery, chain repo
 		GetMode: getter.ModeDir,
 	}
 
-	response, err := handler.client.Get(context.Background(), request)
+	// Serve from cache
+	if detect(handler.client, request) {
+		repository, ok, err := handler.options.fromCache(handler.log, query.URL, cacheDir, "")
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return repository, nil
+		}
+	}
+
+	response, err := handler.options.fetch(ctx, handler.log, handler.client, request)
 	if err != nil {
 		if IsNotDetected(err) {
 			// Chain
-			return chain.Next(query)
+			return chain.Next(ctx, query)
 		}
 
-		return nil, ErrorFrom(err)
+		return nil, err
+	}
+
+	repository := NewRepository(query.URL, response.Dst)
+
+	if err := toCache(repository); err != nil {
+		return nil, err
 	}
 
-	return NewRepository(query.URL, response.Dst), nil
+	return repository, nil
 }
@@ -62,7 +62,7 @@ func (s *S3Suite) TestLoaderHandler() {
 	chainMock := &repository.LoaderHandlerChainMock{}
 
 	handler := getter.NewS3LoaderHandler(log.Discard, cache)
-	repository, err := handler.Handle(&repository.LoaderQuery{URL: url}, chainMock)
+	repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: url}, chainMock)
 
 	s.Require().NoError(err)
 	s.NotNil(repository)
@@ -0,0 +1,198 @@
+package getter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	netURL "net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+
+	"github.com/hashicorp/go-getter/v2"
+)
+
+// signatureNamespace is the ssh signatures namespace of repositories archives,
+// as produced by "ssh-keygen -Y sign -n file".
+const signatureNamespace = "file"
+
+// ArchiveVerifier verifies repositories archives against their detached ssh signature (archive.tar.gz.sig),
+// using an allowed signers file (see ssh-keygen ALLOWED SIGNERS).
+type ArchiveVerifier struct {
+	log            *log.Log
+	url            string
+	allowedSigners string
+	signature      string
+	verified       bool
+}
+
+// NewArchiveVerifier fetches the detached signature of a repository archive url, using client.
+// The signature is stored in a temporary dir, to be removed by calling Close.
+func NewArchiveVerifier(ctx context.Context, log *log.Log, client *getter.Client, url string, allowedSigners string) (*ArchiveVerifier, error) {
+	dir, err := os.MkdirTemp("", "manala-signature")
+	if err != nil {
+		return nil, serror.New("unable to create signature dir").
+			WithErr(err)
+	}
+
+	verifier := &ArchiveVerifier{
+		log:            log,
+		url:            url,
+		allowedSigners: allowedSigners,
+		signature:      filepath.Join(dir, "archive.sig"),
+	}
+
+	src := SignatureSrc(url)
+
+	log.Debug("fetch repository signature", "src", src)
+
+	if _, err := client.Get(ctx, &getter.Request{
+		Src:     src,
+		Dst:     verifier.signature,
+		GetMode: getter.ModeFile,
+	}); err != nil {
+		_ = verifier.Close()
+
+		if IsNotDetected(err) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		return nil, serror.New("unable to fetch repository signature").
+			With("src", src).
+			WithErr(ErrorFrom(err))
+	}
+
+	return verifier, nil
+}
+
+// Close removes the fetched signature.
+func (verifier *ArchiveVerifier) Close() error {
+	return os.RemoveAll(filepath.Dir(verifier.signature))
+}
+
+// Client returns a copy of client, verifying archives before their decompression.
+func (verifier *ArchiveVerifier) Client(ctx context.Context, client *getter.Client) *getter.Client {
+	verifying := *client
+	verifying.Decompressors = map[string]getter.Decompressor{}
+
+	for name, decompressor := range client.Decompressors {
+		verifying.Decompressors[name] = &verifyingDecompressor{
+			ctx:          ctx,
+			verifier:     verifier,
+			decompressor: decompressor,
+		}
+	}
+
+	return &verifying
+}
+
+// Verified tells whether an archive has been verified.
+func (verifier *ArchiveVerifier) Verified() bool {
+	return verifier.verified
+}
+
+// Verify an archive file against the signature.
+func (verifier *ArchiveVerifier) Verify(ctx context.Context, archive string) error {
+	verifier.log.Debug("verify repository archive signature", "url", verifier.url, "allowed_signers", verifier.allowedSigners)
+
+	// Signature principals
+	output, err := verifier.sshKeygen(ctx, nil, "unknown signer",
+		"-Y", "find-principals",
+		"-f", verifier.allowedSigners,
+		"-s", verifier.signature,
+	)
+	if err != nil {
+		return err
+	}
+
+	principals := strings.Fields(string(output))
+	if len(principals) == 0 {
+		return &app.UnverifiedRepositoryError{URL: verifier.url, Reason: "unknown signer"}
+	}
+
+	file, err := os.Open(archive)
+	if err != nil {
+		return serror.New("unable to open repository archive").
+			WithErr(err)
+	}
+	defer file.Close()
+
+	if _, err := verifier.sshKeygen(ctx, file, "invalid signature",
+		"-Y", "verify",
+		"-f", verifier.allowedSigners,
+		"-I", principals[0],
+		"-n", signatureNamespace,
+		"-s", verifier.signature,
+	); err != nil {
+		return err
+	}
+
+	verifier.verified = true
+
+	return nil
+}
+
+// sshKeygen runs a ssh-keygen command, failures being reported as unverified repository, for a given reason.
+func (verifier *ArchiveVerifier) sshKeygen(ctx context.Context, stdin *os.File, reason string, args ...string) ([]byte, error) {
+	command := exec.CommandContext(ctx, "ssh-keygen", args...)
+	if stdin != nil {
+		command.Stdin = stdin
+	}
+
+	stderr := &bytes.Buffer{}
+	command.Stderr = stderr
+
+	output, err := command.Output()
+	if err != nil {
+		if _, ok := errors.AsType[*exec.ExitError](err); !ok || ctx.Err() != nil {
+			return nil, serror.New("command error").
+				With("command", "ssh-keygen").
+				WithErr(err)
+		}
+
+		verifier.log.Debug("ssh-keygen failure", "args", args, "stderr", strings.TrimSpace(stderr.String()))
+
+		return nil, &app.UnverifiedRepositoryError{URL: verifier.url, Reason: reason}
+	}
+
+	return output, nil
+}
+
+// verifyingDecompressor verifies archives before decompressing them.
+type verifyingDecompressor struct {
+	ctx          context.Context
+	verifier     *ArchiveVerifier
+	decompressor getter.Decompressor
+}
+
+func (decompressor *verifyingDecompressor) Decompress(dst, src string, dir bool, umask os.FileMode) error {
+	if err := decompressor.verifier.Verify(decompressor.ctx, src); err != nil {
+		return err
+	}
+
+	return decompressor.decompressor.Decompress(dst, src, dir, umask)
+}
+
+// SignatureSrc returns the detached signature source of an archive source,
+// stripped from its subdir and archive related queries.
+func SignatureSrc(src string) string {
+	src, _ = getter.SourceDirSubdir(src)
+	src, rawQuery, _ := strings.Cut(src, "?")
+
+	values, _ := netURL.ParseQuery(rawQuery)
+	for _, key := range []string{"archive", "checksum", "filename"} {
+		values.Del(key)
+	}
+
+	src += ".sig"
+	if len(values) > 0 {
+		src += "?" + values.Encode()
+	}
+
+	return src
+}
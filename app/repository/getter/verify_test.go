@@ -0,0 +1,77 @@
+package getter_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manala/manala/app/repository/getter"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type VerifySuite struct{ suite.Suite }
+
+func TestVerifySuite(t *testing.T) {
+	suite.Run(t, new(VerifySuite))
+}
+
+func (s *VerifySuite) TestSignatureSrc() {
+	tests := []struct {
+		test     string
+		src      string
+		expected string
+	}{
+		{
+			test:     "Archive",
+			src:      "https://example.com/recipes.tar.gz",
+			expected: "https://example.com/recipes.tar.gz.sig",
+		},
+		{
+			test:     "Subdir",
+			src:      "https://example.com/recipes.tar.gz//recipes",
+			expected: "https://example.com/recipes.tar.gz.sig",
+		},
+		{
+			test:     "Query",
+			src:      "s3::https://s3.amazonaws.com/bucket/recipes.zip?aws_profile=acme&checksum=sha256:abc&archive=zip",
+			expected: "s3::https://s3.amazonaws.com/bucket/recipes.zip.sig?aws_profile=acme",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			s.Equal(test.expected, getter.SignatureSrc(test.src))
+		})
+	}
+}
+
+// sshSigner generates an ssh signing key into dir, along with an allowed signers file trusting it,
+// and returns both paths.
+func sshSigner(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key := filepath.Join(dir, "key")
+	output, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "signer@example.com", "-f", key).CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	publicKey, err := os.ReadFile(key + ".pub")
+	require.NoError(t, err)
+
+	fields := strings.Fields(string(publicKey))
+	allowedSigners := filepath.Join(dir, "allowed_signers")
+	require.NoError(t, os.WriteFile(allowedSigners, []byte("signer@example.com "+fields[0]+" "+fields[1]+"\n"), 0o644))
+
+	return key, allowedSigners
+}
+
+// sshSign signs a file with a key, into a detached file.sig signature.
+func sshSign(t *testing.T, key string, file string) {
+	t.Helper()
+
+	output, err := exec.Command("ssh-keygen", "-q", "-Y", "sign", "-f", key, "-n", "file", file).CombinedOutput()
+	require.NoError(t, err, string(output))
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/manala/manala/app"
 
 	"github.com/stretchr/testify/mock"
@@ -21,26 +23,26 @@ func NewLoader(opts ...LoaderOption) *Loader {
 	return loader
 }
 
-func (loader *Loader) Load(url string) (app.Repository, error) {
+func (loader *Loader) Load(ctx context.Context, url string) (app.Repository, error) {
 	// Prepare query
 	query := &LoaderQuery{URL: url}
 
 	// Start chain
-	return loader.Next(query)
+	return loader.Next(ctx, query)
 }
 
-func (loader Loader) Next(query *LoaderQuery) (app.Repository, error) {
+func (loader Loader) Next(ctx context.Context, query *LoaderQuery) (app.Repository, error) {
 	if len(loader.handlers) == 0 {
-		return loader.Last(query)
+		return loader.Last(ctx, query)
 	}
 
 	handler := loader.handlers[0]
 	loader.handlers = loader.handlers[1:]
 
-	return handler.Handle(query, loader)
+	return handler.Handle(ctx, query, loader)
 }
 
-func (loader Loader) Last(query *LoaderQuery) (app.Repository, error) {
+func (loader Loader) Last(ctx context.Context, query *LoaderQuery) (app.Repository, error) {
 	return nil, &app.NotFoundRepositoryError{URL: query.URL}
 }
 
@@ -57,36 +59,36 @@ type LoaderQuery struct {
 }
 
 type LoaderHandler interface {
-	Handle(query *LoaderQuery, chain LoaderHandlerChain) (app.Repository, error)
+	Handle(ctx context.Context, query *LoaderQuery, chain LoaderHandlerChain) (app.Repository, error)
 }
 
 type LoaderHandlerMock struct {
 	mock.Mock
 }
 
-func (mock *LoaderHandlerMock) Handle(query *LoaderQuery, chain LoaderHandlerChain) (app.Repository, error) {
-	args := mock.Called(query, chain)
+func (mock *LoaderHandlerMock) Handle(ctx context.Context, query *LoaderQuery, chain LoaderHandlerChain) (app.Repository, error) {
+	args := mock.Called(ctx, query, chain)
 
 	return args.Get(0).(app.Repository), args.Error(1)
 }
 
 type LoaderHandlerChain interface {
-	Next(query *LoaderQuery) (app.Repository, error)
-	Last(query *LoaderQuery) (app.Repository, error)
+	Next(ctx context.Context, query *LoaderQuery) (app.Repository, error)
+	Last(ctx context.Context, query *LoaderQuery) (app.Repository, error)
 }
 
 type LoaderHandlerChainMock struct {
 	mock.Mock
 }
 
-func (mock *LoaderHandlerChainMock) Next(query *LoaderQuery) (app.Repository, error) {
-	args := mock.Called(query)
+func (mock *LoaderHandlerChainMock) Next(ctx context.Context, query *LoaderQuery) (app.Repository, error) {
+	args := mock.Called(ctx, query)
 
 	return args.Get(0).(app.Repository), args.Error(1)
 }
 
-func (mock *LoaderHandlerChainMock) Last(query *LoaderQuery) (app.Repository, error) {
-	args := mock.Called(query)
+func (mock *LoaderHandlerChainMock) Last(ctx context.Context, query *LoaderQuery) (app.Repository, error) {
+	args := mock.Called(ctx, query)
 
 	return args.Get(0).(app.Repository), args.Error(1)
 }
@@ -24,11 +24,11 @@ func (s *LoaderSuite) TestLoad() {
 
 	handlerMock := &repository.LoaderHandlerMock{}
 	handlerMock.
-		On("Handle", &repository.LoaderQuery{URL: "url"}, mock.Anything).Return(repositoryMock, nil)
+		On("Handle", mock.Anything, &repository.LoaderQuery{URL: "url"}, mock.Anything).Return(repositoryMock, nil)
 
 	loader := repository.NewLoader(repository.WithLoaderHandlers(handlerMock))
 
-	repository, err := loader.Load("url")
+	repository, err := loader.Load(s.T().Context(), "url")
 
 	s.Require().NoError(err)
 	s.Equal(repositoryMock, repository)
@@ -39,7 +39,7 @@ func (s *LoaderSuite) TestLoadErrors() {
 	loader := repository.NewLoader()
 
 	s.Run("NotFound", func() {
-		repository, err := loader.Load("url")
+		repository, err := loader.Load(s.T().Context(), "url")
 
 		s.Nil(repository)
 		expectation.ExpectError(s.T(), errors.Expectation{
@@ -0,0 +1,16 @@
+package manifest
+
+type Config struct {
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
+	MinVersion  string          `yaml:"min_version"`
+	Partials    []string        `yaml:"partials"`
+	Recipes     []*RecipeConfig `yaml:"recipes"`
+}
+
+type RecipeConfig struct {
+	Name       string `yaml:"name"`
+	Category   string `yaml:"category"`
+	Hidden     bool   `yaml:"hidden"`
+	Deprecated string `yaml:"deprecated"`
+}
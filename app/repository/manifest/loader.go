@@ -0,0 +1,179 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/errors/source"
+	"github.com/manala/manala/internal/errors/std"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/validation"
+	yamlerrors "github.com/manala/manala/internal/yaml/errors"
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+	yamlvalidation "github.com/manala/manala/internal/yaml/validation"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/goccy/go-yaml"
+)
+
+const filename = ".manala-repository.yaml"
+
+var manifestValidator = validation.MustNewValidator(map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"name":        map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+		"description": map[string]any{"type": "string", "minLength": 1, "maxLength": 256},
+		"min_version": map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+		"partials": map[string]any{
+			"type":  "array",
+			"items": map[string]any{"type": "string", "minLength": 1, "maxLength": 100, "format": "local-path"},
+		},
+		"recipes": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name":       map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+					"category":   map[string]any{"type": "string", "minLength": 1, "maxLength": 100},
+					"hidden":     map[string]any{"type": "boolean"},
+					"deprecated": map[string]any{"type": "string", "minLength": 1, "maxLength": 256},
+				},
+				"additionalProperties": false,
+				"required":             []any{"name"},
+			},
+		},
+	},
+	"additionalProperties": false,
+})
+
+type LoaderHandler struct {
+	log     *log.Log
+	version string
+}
+
+// NewLoaderHandler returns a handler decorating repositories with their optional manifest,
+// ensuring their minimum version requirement against current manala version.
+func NewLoaderHandler(log *log.Log, version string) *LoaderHandler {
+	return &LoaderHandler{
+		log:     log,
+		version: version,
+	}
+}
+
+func (handler *LoaderHandler) Handle(ctx context.Context, query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
+	// Chain
+	repository, err := chain.Next(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	file := filepath.Join(repository.Dir(), filename)
+
+	handler.log.Debug("handle repository manifest", "handler", "manifest", "file", file)
+
+	// Stat file
+	if fileInfo, err := os.Stat(file); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return repository, nil
+		}
+
+		return nil, serror.New("unable to stat repository manifest").
+			With("file", file).
+			WithErr(std.From(err))
+	} else if fileInfo.IsDir() {
+		return nil, serror.New("repository manifest is a directory").
+			With("dir", file)
+	}
+
+	// Read file
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, serror.New("unable to read repository manifest").
+			With("file", file).
+			WithErr(std.From(err))
+	}
+
+	// Prepare source error origin
+	origin := source.Origin{
+		File:     file,
+		Source:   string(content),
+		Language: "yaml",
+	}
+
+	// Parse content
+	node, err := yamlparser.Parse(content)
+	if err != nil {
+		return nil, serror.New("unable to parse repository manifest").
+			WithErr(source.From(err, origin))
+	}
+
+	// Decode manifest map
+	var manifestMap map[string]any
+	if err := yaml.NodeToValue(node, &manifestMap); err != nil {
+		return nil, serror.New("unable to decode repository manifest").
+			WithErr(source.From(yamlerrors.From(err), origin))
+	}
+
+	// Validate manifest map
+	if err := manifestValidator.Validate(manifestMap, yamlvalidation.WithLocator(node)); err != nil {
+		if violations, ok := errors.AsType[validation.Violations](err); ok {
+			return nil, serror.New("invalid repository manifest").
+				WithErr(source.From(violations, origin))
+		}
+		return nil, serror.New("unable to validate repository manifest").
+			With("file", file).WithErr(err)
+	}
+
+	// Decode config
+	config := &Config{}
+	if err := yaml.NodeToValue(node, config); err != nil {
+		return nil, serror.New("unable to decode repository manifest config").
+			WithErr(source.From(err, origin))
+	}
+
+	handler.log.Debug("repository manifest loaded", "handler", "manifest", "file", file)
+
+	// Minimum version
+	if err := handler.require(repository.URL(), config.MinVersion); err != nil {
+		return nil, err
+	}
+
+	return &Repository{
+		Repository: repository,
+		manifest: &Manifest{
+			dir:    repository.Dir(),
+			config: config,
+		},
+	}, nil
+}
+
+// require ensures current version satisfies a repository minimum one.
+// Development and non semver versions always do.
+func (handler *LoaderHandler) require(url string, minVersion string) error {
+	if minVersion == "" {
+		return nil
+	}
+
+	required, err := semver.NewVersion(minVersion)
+	if err != nil {
+		return serror.New("invalid repository min version").
+			With("url", url, "min_version", minVersion)
+	}
+
+	version, err := semver.NewVersion(handler.version)
+	if err != nil {
+		return nil
+	}
+
+	if version.LessThan(required) {
+		return serror.New("repository requires a newer manala version").
+			With("url", url, "version", handler.version, "min_version", minVersion)
+	}
+
+	return nil
+}
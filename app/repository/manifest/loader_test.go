@@ -0,0 +1,214 @@
+package manifest_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/app/repository/manifest"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
+	"github.com/manala/manala/internal/errors/source/sourcetest"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoaderSuite struct{ suite.Suite }
+
+func TestLoaderSuite(t *testing.T) {
+	suite.Run(t, new(LoaderSuite))
+}
+
+func (s *LoaderSuite) TestHandle() {
+	repositoryURL := filepath.FromSlash("testdata/LoaderSuite/TestHandle/repository")
+
+	repository, err := s.handle(repositoryURL, "1.2.0")
+
+	s.Require().NoError(err)
+	s.Equal(repositoryURL, repository.URL())
+	s.Equal(repositoryURL, repository.Dir())
+
+	manifest := repository.Manifest()
+	s.Require().NotNil(manifest)
+
+	s.Equal("name", manifest.Name())
+	s.Equal("description", manifest.Description())
+	s.Equal([]string{filepath.Join(repositoryURL, "_partials", "partial.tmpl")}, manifest.Partials())
+
+	recipes := manifest.Recipes()
+	s.Require().Len(recipes, 2)
+	s.Equal("foo", recipes[0].Name())
+	s.Equal("category", recipes[0].Category())
+	s.False(recipes[0].Hidden())
+	s.Equal("deprecated", recipes[0].Deprecated())
+	s.Equal("bar", recipes[1].Name())
+	s.Empty(recipes[1].Category())
+	s.True(recipes[1].Hidden())
+	s.Empty(recipes[1].Deprecated())
+
+	recipe, ok := manifest.Recipe("bar")
+	s.True(ok)
+	s.Equal("bar", recipe.Name())
+
+	_, ok = manifest.Recipe("baz")
+	s.False(ok)
+}
+
+func (s *LoaderSuite) TestHandleNoManifest() {
+	repositoryURL := filepath.FromSlash("testdata/LoaderSuite/TestHandleNoManifest/repository")
+
+	repository, err := s.handle(repositoryURL, "1.0.0")
+
+	s.Require().NoError(err)
+	s.Equal(repositoryURL, repository.URL())
+	s.Nil(repository.Manifest())
+}
+
+func (s *LoaderSuite) TestHandleVersion() {
+	repositoryURL := filepath.FromSlash("testdata/LoaderSuite/TestHandleVersion/repository")
+
+	for _, version := range []string{"1.2.0", "v1.3.0", "dev"} {
+		s.Run(version, func() {
+			repository, err := s.handle(repositoryURL, version)
+
+			s.Require().NoError(err)
+			s.NotNil(repository.Manifest())
+		})
+	}
+}
+
+func (s *LoaderSuite) TestHandleErrors() {
+	dir := filepath.FromSlash("testdata/LoaderSuite/TestHandleErrors")
+
+	tests := []struct {
+		test     string
+		expected expectation.ErrorExpectation
+	}{
+		{
+			test: "Directory",
+			expected: serrortest.Expectation{
+				Msg: "repository manifest is a directory",
+				Attrs: [][2]any{
+					{"dir", filepath.Join(dir, "Directory", "repository", ".manala-repository.yaml")},
+				},
+			},
+		},
+		{
+			test: "AdditionalProperty",
+			expected: serrortest.Expectation{
+				Msg: "invalid repository manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:2:1
+
+						  1 │ name: name
+						▶ 2 │ foo: bar
+						    ├─╯ additional property 'foo' not allowed
+					`,
+						filepath.Join(dir, "AdditionalProperty", "repository", ".manala-repository.yaml"),
+					)),
+				),
+			},
+		},
+		{
+			test: "RecipeNameMissing",
+			expected: serrortest.Expectation{
+				Msg: "invalid repository manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:2:15
+
+						  1 │ recipes:
+						▶ 2 │     - category: category
+						    ├───────────────╯ missing property 'name'
+					`,
+						filepath.Join(dir, "RecipeNameMissing", "repository", ".manala-repository.yaml"),
+					)),
+				),
+			},
+		},
+		{
+			test: "RecipeHiddenNotBoolean",
+			expected: serrortest.Expectation{
+				Msg: "invalid repository manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:3:15
+
+						  1 │ recipes:
+						  2 │     - name: foo
+						▶ 3 │       hidden: foo
+						    ├───────────────╯ got string, want boolean
+					`,
+						filepath.Join(dir, "RecipeHiddenNotBoolean", "repository", ".manala-repository.yaml"),
+					)),
+				),
+			},
+		},
+		{
+			test: "PartialOutOfRepository",
+			expected: serrortest.Expectation{
+				Msg: "invalid repository manifest",
+				Err: expectation.Errors(
+					sourcetest.Expectation(heredoc.Doc(`
+
+						at %[1]s:2:7
+
+						  1 │ partials:
+						▶ 2 │     - ../partial
+						    ├───────╯ '../partial' is not valid local-path: invalid format
+					`,
+						filepath.Join(dir, "PartialOutOfRepository", "repository", ".manala-repository.yaml"),
+					)),
+				),
+			},
+		},
+		{
+			test: "MinVersionInvalid",
+			expected: serrortest.Expectation{
+				Msg: "invalid repository min version",
+				Attrs: [][2]any{
+					{"url", filepath.Join(dir, "MinVersionInvalid", "repository")},
+					{"min_version", "foo"},
+				},
+			},
+		},
+		{
+			test: "MinVersionNewer",
+			expected: serrortest.Expectation{
+				Msg: "repository requires a newer manala version",
+				Attrs: [][2]any{
+					{"url", filepath.Join(dir, "MinVersionNewer", "repository")},
+					{"version", "1.2.0"},
+					{"min_version", "2.0.0"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			repository, err := s.handle(filepath.Join(dir, test.test, "repository"), "1.2.0")
+
+			s.Nil(repository)
+			expectation.ExpectError(s.T(), test.expected, err)
+		})
+	}
+}
+
+func (s *LoaderSuite) handle(repositoryURL string, version string) (app.Repository, error) {
+	chainMock := &repository.LoaderHandlerChainMock{}
+	chainMock.
+		On("Next", mock.Anything, mock.Anything).Return(getter.NewRepository(repositoryURL, repositoryURL), nil)
+
+	handler := manifest.NewLoaderHandler(log.Discard, version)
+	return handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: repositoryURL}, chainMock)
+}
@@ -0,0 +1,82 @@
+package manifest
+
+import (
+	"path/filepath"
+
+	"github.com/manala/manala/app"
+)
+
+// Repository decorates a repository with its manifest.
+type Repository struct {
+	app.Repository
+	manifest *Manifest
+}
+
+func (repository *Repository) Manifest() app.RepositoryManifest {
+	return repository.manifest
+}
+
+// Manifest describes a repository recipes collection.
+type Manifest struct {
+	dir    string
+	config *Config
+}
+
+func (manifest *Manifest) Name() string {
+	return manifest.config.Name
+}
+
+func (manifest *Manifest) Description() string {
+	return manifest.config.Description
+}
+
+func (manifest *Manifest) Partials() []string {
+	var partials []string
+
+	for _, partial := range manifest.config.Partials {
+		partials = append(partials, filepath.Join(manifest.dir, partial))
+	}
+
+	return partials
+}
+
+func (manifest *Manifest) Recipes() []app.RepositoryManifestRecipe {
+	var recipes []app.RepositoryManifestRecipe
+
+	for _, config := range manifest.config.Recipes {
+		recipes = append(recipes, &Recipe{config: config})
+	}
+
+	return recipes
+}
+
+func (manifest *Manifest) Recipe(name string) (app.RepositoryManifestRecipe, bool) {
+	for _, config := range manifest.config.Recipes {
+		if config.Name == name {
+			return &Recipe{config: config}, true
+		}
+	}
+
+	return nil, false
+}
+
+// Recipe describes a recipe of a repository manifest.
+type Recipe struct {
+	config *RecipeConfig
+}
+
+func (recipe *Recipe) Name() string {
+	return recipe.config.Name
+}
+
+func (recipe *Recipe) Category() string {
+	return recipe.config.Category
+}
+
+func (recipe *Recipe) Hidden() bool {
+	return recipe.config.Hidden
+}
+
+func (recipe *Recipe) Deprecated() string {
+	return recipe.config.Deprecated
+}
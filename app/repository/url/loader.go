@@ -1,6 +1,8 @@
 package url
 
 import (
+	"context"
+
 	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/repository"
 	"github.com/manala/manala/internal/log"
@@ -18,17 +20,38 @@ func NewProcessorLoaderHandler(log *log.Log, processor *Processor) *ProcessorLoa
 	}
 }
 
-func (handler *ProcessorLoaderHandler) Handle(query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
+func (handler *ProcessorLoaderHandler) Handle(ctx context.Context, query *repository.LoaderQuery, chain repository.LoaderHandlerChain) (app.Repository, error) {
 	handler.log.Debug("handle repository url", "handler", "url.processor", "url", query.URL)
 
-	var err error
+	var (
+		alias string
+		err   error
+	)
 
 	// Process query url
-	query.URL, err = handler.processor.Process(query.URL)
+	query.URL, alias, err = handler.processor.ProcessAlias(query.URL)
 	if err != nil {
 		return nil, err
 	}
 
 	// Chain
-	return chain.Next(query)
+	repository, err := chain.Next(ctx, query)
+	if err != nil || alias == "" {
+		return repository, err
+	}
+
+	// Expose alias rather than resolved url, so that it can be swapped centrally
+	return &aliasRepository{
+		Repository: repository,
+		alias:      alias,
+	}, nil
+}
+
+type aliasRepository struct {
+	app.Repository
+	alias string
+}
+
+func (repository *aliasRepository) URL() string {
+	return repository.alias
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/manala/manala/internal/log"
 	"github.com/manala/manala/internal/testing/expectation"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -29,15 +30,40 @@ func (s *LoaderSuite) TestProcessorHandler() {
 
 	chainMock := &repository.LoaderHandlerChainMock{}
 	chainMock.
-		On("Next", &repository.LoaderQuery{URL: "url"}).Return(repositoryMock, nil)
+		On("Next", mock.Anything, &repository.LoaderQuery{URL: "url"}).Return(repositoryMock, nil)
 
-	repository, err := handler.Handle(&repository.LoaderQuery{URL: ""}, chainMock)
+	repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: ""}, chainMock)
 
 	s.Require().NoError(err)
 	s.Equal(repositoryMock, repository)
 	chainMock.AssertExpectations(s.T())
 }
 
+func (s *LoaderSuite) TestProcessorHandlerAlias() {
+	processor := url.NewProcessor(log.Discard)
+	processor.Add("alias:recipe", 10)
+	processor.AddAlias("alias", "url")
+
+	handler := url.NewProcessorLoaderHandler(log.Discard, processor)
+
+	repositoryMock := &mocks.Repository{}
+	repositoryMock.
+		On("Dir").Return("dir")
+
+	chainMock := &repository.LoaderHandlerChainMock{}
+	chainMock.
+		On("Next", mock.Anything, &repository.LoaderQuery{URL: "url"}).Return(repositoryMock, nil)
+
+	repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: ""}, chainMock)
+
+	s.Require().NoError(err)
+	chainMock.AssertExpectations(s.T())
+
+	// Alias exposed rather than resolved url
+	s.Equal("alias", repository.URL())
+	s.Equal("dir", repository.Dir())
+}
+
 func (s *LoaderSuite) TestProcessorHandlerErrors() {
 	processor := url.NewProcessor(log.Discard)
 
@@ -45,7 +71,7 @@ func (s *LoaderSuite) TestProcessorHandlerErrors() {
 
 	chainMock := &repository.LoaderHandlerChainMock{}
 
-	repository, err := handler.Handle(&repository.LoaderQuery{URL: "foo?bar;baz"}, chainMock)
+	repository, err := handler.Handle(s.T().Context(), &repository.LoaderQuery{URL: "foo?bar;baz"}, chainMock)
 
 	s.Nil(repository)
 	expectation.ExpectError(s.T(), serrortest.Expectation{
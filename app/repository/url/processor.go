@@ -16,6 +16,7 @@ import (
 type Processor struct {
 	log     *log.Log
 	entries []processorEntry
+	aliases map[string]string
 }
 
 func NewProcessor(log *log.Log) *Processor {
@@ -34,7 +35,24 @@ func (processor *Processor) AddQuery(key, value string, weight int) {
 	processor.entries = append(processor.entries, processorEntry{query: query, weight: weight})
 }
 
+// AddAlias adds a repository alias, resolved by name, or by shorthand, made of
+// alias name and recipe name separated by a colon (acme:recipe).
+func (processor *Processor) AddAlias(name, url string) {
+	if processor.aliases == nil {
+		processor.aliases = make(map[string]string)
+	}
+
+	processor.aliases[name] = url
+}
+
 func (processor *Processor) Process(url string) (string, error) {
+	url, _, err := processor.ProcessAlias(url)
+
+	return url, err
+}
+
+// ProcessAlias processes url, and also returns its unresolved alias form, if any.
+func (processor *Processor) ProcessAlias(url string) (string, string, error) {
 	entries := slices.Clone(processor.entries)
 
 	if url != "" {
@@ -56,7 +74,7 @@ func (processor *Processor) Process(url string) (string, error) {
 		if entryQuery != "" {
 			values, err := netURL.ParseQuery(entryQuery)
 			if err != nil {
-				return "", serror.New("unable to process repository query").
+				return "", "", serror.New("unable to process repository query").
 					With("query", entryQuery).
 					WithErr(err)
 			}
@@ -78,11 +96,70 @@ func (processor *Processor) Process(url string) (string, error) {
 		}
 	}
 
+	// Alias
+	var alias string
+
+	if name, _, ok := processor.alias(url); ok {
+		aliasURL := processor.aliases[name]
+
+		processor.log.Debug("resolve repository alias",
+			"alias", name,
+			"url", aliasURL,
+		)
+
+		alias = name
+		if query != nil {
+			alias = fmt.Sprintf("%s?%s", alias, query.Encode())
+		}
+
+		// Alias query comes last
+		var aliasQuery string
+		url, aliasQuery, _ = strings.Cut(aliasURL, "?")
+
+		if aliasQuery != "" {
+			values, err := netURL.ParseQuery(aliasQuery)
+			if err != nil {
+				return "", "", serror.New("unable to process repository alias query").
+					With("alias", name, "query", aliasQuery).
+					WithErr(err)
+			}
+
+			_ = mergo.Merge(&query, values)
+		}
+	}
+
 	if url != "" && query != nil {
 		url = fmt.Sprintf("%s?%s", url, query.Encode())
 	}
 
-	return url, nil
+	return url, alias, nil
+}
+
+// Recipe returns the recipe name of an alias shorthand url (acme:recipe), if any.
+func (processor *Processor) Recipe(url string) (string, bool) {
+	url, _, _ = strings.Cut(url, "?")
+
+	_, recipe, ok := processor.alias(url)
+	if !ok || recipe == "" {
+		return "", false
+	}
+
+	return recipe, true
+}
+
+// alias returns the alias name an url refers to, along with its shorthand recipe name, if any.
+// Urls made of a scheme (https://) or a forced getter (git::) never refer to aliases, whatever their name.
+func (processor *Processor) alias(url string) (string, string, bool) {
+	name, recipe, _ := strings.Cut(url, ":")
+	if strings.HasPrefix(recipe, "//") || strings.HasPrefix(recipe, ":") {
+		return "", "", false
+	}
+
+	if _, ok := processor.aliases[name]; !ok {
+		return "", "", false
+	}
+
+	return name, recipe, true
 }
 
 type processorEntry struct {
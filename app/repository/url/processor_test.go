@@ -160,3 +160,139 @@ func (s *ProcessorSuite) TestProcess() {
 		})
 	}
 }
+
+func (s *ProcessorSuite) TestProcessAlias() {
+	tests := []struct {
+		test          string
+		url           string
+		urls          map[int]string
+		queries       map[int]map[string]string
+		expectedURL   string
+		expectedAlias string
+	}{
+		{
+			test:          "NotAlias",
+			url:           "url",
+			expectedURL:   "url",
+			expectedAlias: "",
+		},
+		{
+			test:          "Alias",
+			url:           "alias",
+			expectedURL:   "alias_url?alias_query=alias_query",
+			expectedAlias: "alias",
+		},
+		{
+			test:          "Shorthand",
+			url:           "alias:recipe",
+			expectedURL:   "alias_url?alias_query=alias_query",
+			expectedAlias: "alias",
+		},
+		{
+			test:          "ShorthandNotAlias",
+			url:           "git@example.com:recipes.git",
+			expectedURL:   "git@example.com:recipes.git",
+			expectedAlias: "",
+		},
+		{
+			test:          "SchemeNotAlias",
+			url:           "alias://example.com/recipes.git",
+			expectedURL:   "alias://example.com/recipes.git",
+			expectedAlias: "",
+		},
+		{
+			test:          "ForcedGetterNotAlias",
+			url:           "alias::https://example.com/recipes.git",
+			expectedURL:   "alias::https://example.com/recipes.git",
+			expectedAlias: "",
+		},
+		{
+			test: "AliasQuery",
+			url:  "alias?query=query",
+			queries: map[int]map[string]string{
+				10: {"alias_query": "head_query"},
+			},
+			expectedURL:   "alias_url?alias_query=head_query&query=query",
+			expectedAlias: "alias?alias_query=head_query&query=query",
+		},
+		{
+			test: "HeadAlias",
+			url:  "url",
+			urls: map[int]string{
+				10: "alias",
+			},
+			expectedURL:   "alias_url?alias_query=alias_query",
+			expectedAlias: "alias",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			processor := url.NewProcessor(log.Discard)
+			processor.AddAlias("alias", "alias_url?alias_query=alias_query")
+
+			for weight, url := range test.urls {
+				processor.Add(url, weight)
+			}
+
+			for weight, queries := range test.queries {
+				for key, value := range queries {
+					processor.AddQuery(key, value, weight)
+				}
+			}
+
+			url, alias, err := processor.ProcessAlias(test.url)
+
+			s.Require().NoError(err)
+			s.Equal(test.expectedURL, url)
+			s.Equal(test.expectedAlias, alias)
+		})
+	}
+}
+
+func (s *ProcessorSuite) TestProcessAliasErrors() {
+	processor := url.NewProcessor(log.Discard)
+	processor.AddAlias("alias", "alias_url?bar;baz")
+
+	url, alias, err := processor.ProcessAlias("alias")
+
+	s.Empty(url)
+	s.Empty(alias)
+	expectation.ExpectError(s.T(), serrortest.Expectation{
+		Msg: "unable to process repository alias query",
+		Attrs: [][2]any{
+			{"alias", "alias"},
+			{"query", "bar;baz"},
+		},
+		Err: expectation.ErrorMessage("invalid semicolon separator in query"),
+	}, err)
+}
+
+func (s *ProcessorSuite) TestRecipe() {
+	tests := []struct {
+		test           string
+		url            string
+		expectedRecipe string
+		expectedOk     bool
+	}{
+		{test: "Alias", url: "alias", expectedOk: false},
+		{test: "Shorthand", url: "alias:recipe", expectedRecipe: "recipe", expectedOk: true},
+		{test: "ShorthandQuery", url: "alias:recipe?ref=ref", expectedRecipe: "recipe", expectedOk: true},
+		{test: "ShorthandEmpty", url: "alias:", expectedOk: false},
+		{test: "ShorthandNotAlias", url: "git@example.com:recipes.git", expectedOk: false},
+		{test: "SchemeNotAlias", url: "alias://example.com/recipes.git", expectedOk: false},
+		{test: "ForcedGetterNotAlias", url: "alias::https://example.com/recipes.git", expectedOk: false},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			processor := url.NewProcessor(log.Discard)
+			processor.AddAlias("alias", "alias_url")
+
+			recipe, ok := processor.Recipe(test.url)
+
+			s.Equal(test.expectedOk, ok)
+			s.Equal(test.expectedRecipe, recipe)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/manala/manala/internal/errors/serror"
+)
+
+// ExecBackend resolves secrets from the output of shell commands, run in dir, as in "exec:pass show project/db".
+// As project manifests could come from anyone, commands are only run once explicitly enabled by user.
+type ExecBackend struct {
+	enabled bool
+}
+
+func NewExecBackend(enabled bool) *ExecBackend {
+	return &ExecBackend{
+		enabled: enabled,
+	}
+}
+
+func (backend *ExecBackend) Resolve(ctx context.Context, dir string, ref string) (string, error) {
+	if !backend.enabled {
+		return "", serror.New("exec secrets are disabled, enable them using secret_exec config")
+	}
+
+	var command *exec.Cmd
+	if runtime.GOOS == "windows" {
+		command = exec.CommandContext(ctx, "cmd", "/C", ref)
+	} else {
+		command = exec.CommandContext(ctx, "sh", "-c", ref)
+	}
+	command.Dir = dir
+
+	stderr := &bytes.Buffer{}
+	command.Stderr = stderr
+
+	output, err := command.Output()
+	if err != nil {
+		return "", serror.New("secret command error").
+			With("stderr", strings.TrimSpace(stderr.String())).
+			WithErr(err)
+	}
+
+	return strings.TrimRight(string(output), "\r\n"), nil
+}
@@ -0,0 +1,75 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/errors/std"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/goccy/go-yaml"
+)
+
+// FileBackend resolves secrets from local files, as "<path>", or "<path>#<key>" to extract a dotted key of a yaml
+// (or json) file.
+type FileBackend struct{}
+
+func NewFileBackend() *FileBackend {
+	return &FileBackend{}
+}
+
+func (backend *FileBackend) Resolve(_ context.Context, dir string, ref string) (string, error) {
+	path, key, _ := strings.Cut(ref, "#")
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", serror.New("unable to read secret file").
+			With("file", path).
+			WithErr(std.From(err))
+	}
+
+	if key == "" {
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	return extract(content, key)
+}
+
+// extract returns a dotted key scalar value of a yaml (or json) content.
+func extract(content []byte, key string) (string, error) {
+	var data any
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return "", serror.New("unable to decode secret file").
+			WithErr(err)
+	}
+
+	pointer, err := jsonpointer.New(yamlpath.ToJSONPointer("$." + key))
+	if err != nil {
+		return "", serror.New("invalid secret key").
+			With("key", key).
+			WithErr(err)
+	}
+
+	value, _, err := pointer.Get(data)
+	if err != nil {
+		return "", serror.New("secret key not found").
+			With("key", key)
+	}
+
+	switch value.(type) {
+	case map[string]any, []any, nil:
+		return "", serror.New("secret key is not a scalar").
+			With("key", key)
+	}
+
+	return fmt.Sprint(value), nil
+}
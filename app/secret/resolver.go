@@ -0,0 +1,82 @@
+package secret
+
+import (
+	"context"
+
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+)
+
+// Backend resolves secrets refs, relative paths being resolved against dir.
+type Backend interface {
+	Resolve(ctx context.Context, dir string, ref string) (string, error)
+}
+
+func NewResolver(log *log.Log, opts ...ResolverOption) *Resolver {
+	resolver := &Resolver{
+		log:      log,
+		backends: map[string]Backend{},
+	}
+
+	// Options
+	for _, opt := range opts {
+		opt(resolver)
+	}
+
+	return resolver
+}
+
+type Resolver struct {
+	log      *log.Log
+	backends map[string]Backend
+}
+
+type ResolverOption func(resolver *Resolver)
+
+// WithBackend resolves secrets of a given backend name.
+func WithBackend(name string, backend Backend) ResolverOption {
+	return func(resolver *Resolver) {
+		resolver.backends[name] = backend
+	}
+}
+
+// Resolve returns a copy of vars, secrets replaced by their values.
+// Resolved vars are meant to be used right away, never logged nor stored.
+func (resolver *Resolver) Resolve(ctx context.Context, dir string, vars map[string]any) (map[string]any, error) {
+	resolved := map[string]string{}
+
+	value, err := walk(vars, func(secret *Secret) (any, error) {
+		reference := secret.String()
+
+		// Resolve each secret once
+		if value, ok := resolved[reference]; ok {
+			return value, nil
+		}
+
+		backend, ok := resolver.backends[secret.Backend]
+		if !ok {
+			return nil, serror.New("unknown secret backend").
+				With("secret", reference)
+		}
+
+		resolver.log.Debug("resolve secret", "secret", reference)
+
+		value, err := backend.Resolve(ctx, dir, secret.Ref)
+		if err != nil {
+			return nil, serror.New("unable to resolve secret").
+				With("secret", reference).
+				WithErr(err)
+		}
+
+		resolved[reference] = value
+
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vars, _ = value.(map[string]any)
+
+	return vars, nil
+}
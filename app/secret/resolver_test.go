@@ -0,0 +1,223 @@
+package secret_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/manala/manala/app/secret"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/testing/expectation"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResolverSuite struct{ suite.Suite }
+
+func TestResolverSuite(t *testing.T) {
+	suite.Run(t, new(ResolverSuite))
+}
+
+func (s *ResolverSuite) TestResolve() {
+	resolver := secret.NewResolver(log.Discard,
+		secret.WithBackend("file", secret.NewFileBackend()),
+	)
+
+	vars := map[string]any{
+		"password": &secret.Secret{Backend: "file", Ref: "password"},
+		"db": map[string]any{
+			"password": &secret.Secret{Backend: "file", Ref: "secrets.yaml#db.password"},
+		},
+		"ports": []any{&secret.Secret{Backend: "file", Ref: "secrets.yaml#db.port"}},
+		"foo":   "bar",
+	}
+
+	resolved, err := resolver.Resolve(s.T().Context(), filepath.FromSlash("testdata/ResolverSuite/TestFile"), vars)
+
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"password": "password",
+		"db": map[string]any{
+			"password": "db_password",
+		},
+		"ports": []any{"3306"},
+		"foo":   "bar",
+	}, resolved)
+
+	// Original vars are left untouched
+	s.IsType((*secret.Secret)(nil), vars["password"])
+}
+
+func (s *ResolverSuite) TestResolveErrors() {
+	dir := filepath.FromSlash("testdata/ResolverSuite/TestFile")
+
+	resolver := secret.NewResolver(log.Discard,
+		secret.WithBackend("file", secret.NewFileBackend()),
+	)
+
+	tests := []struct {
+		test     string
+		secret   *secret.Secret
+		expected expectation.ErrorExpectation
+	}{
+		{
+			test:   "UnknownBackend",
+			secret: &secret.Secret{Backend: "vault", Ref: "password"},
+			expected: serrortest.Expectation{
+				Msg: "unknown secret backend",
+				Attrs: [][2]any{
+					{"secret", "!secret vault:password"},
+				},
+			},
+		},
+		{
+			test:   "FileNotFound",
+			secret: &secret.Secret{Backend: "file", Ref: "missing"},
+			expected: serrortest.Expectation{
+				Msg: "unable to resolve secret",
+				Attrs: [][2]any{
+					{"secret", "!secret file:missing"},
+				},
+				Err: serrortest.Expectation{
+					Msg: "unable to read secret file",
+					Attrs: [][2]any{
+						{"file", filepath.Join(dir, "missing")},
+					},
+					Err: expectation.ErrorMessage("file does not exist"),
+				},
+			},
+		},
+		{
+			test:   "KeyNotFound",
+			secret: &secret.Secret{Backend: "file", Ref: "secrets.yaml#db.user"},
+			expected: serrortest.Expectation{
+				Msg: "unable to resolve secret",
+				Attrs: [][2]any{
+					{"secret", "!secret file:secrets.yaml#db.user"},
+				},
+				Err: serrortest.Expectation{
+					Msg: "secret key not found",
+					Attrs: [][2]any{
+						{"key", "db.user"},
+					},
+				},
+			},
+		},
+		{
+			test:   "KeyNotScalar",
+			secret: &secret.Secret{Backend: "file", Ref: "secrets.yaml#db"},
+			expected: serrortest.Expectation{
+				Msg: "unable to resolve secret",
+				Attrs: [][2]any{
+					{"secret", "!secret file:secrets.yaml#db"},
+				},
+				Err: serrortest.Expectation{
+					Msg: "secret key is not a scalar",
+					Attrs: [][2]any{
+						{"key", "db"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			resolved, err := resolver.Resolve(s.T().Context(), dir, map[string]any{
+				"password": test.secret,
+			})
+
+			s.Nil(resolved)
+			expectation.ExpectError(s.T(), test.expected, err)
+		})
+	}
+}
+
+func (s *ResolverSuite) TestSops() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("fake sops command is a shell script")
+	}
+
+	bin, _ := filepath.Abs(filepath.FromSlash("testdata/ResolverSuite/TestSops/bin"))
+	s.T().Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	resolver := secret.NewResolver(log.Discard,
+		secret.WithBackend("sops", secret.NewSopsBackend()),
+	)
+
+	resolved, err := resolver.Resolve(s.T().Context(), "dir", map[string]any{
+		"file":   &secret.Secret{Backend: "sops", Ref: "secrets.enc.yaml"},
+		"key":    &secret.Secret{Backend: "sops", Ref: "secrets.enc.yaml#db.password"},
+		"option": &secret.Secret{Backend: "sops", Ref: "--help"},
+	})
+
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"file":   "--decrypt -- " + filepath.Join("dir", "secrets.enc.yaml"),
+		"key":    `--decrypt --extract ["db"]["password"] -- ` + filepath.Join("dir", "secrets.enc.yaml"),
+		"option": "--decrypt -- " + filepath.Join("dir", "--help"),
+	}, resolved)
+}
+
+func (s *ResolverSuite) TestExec() {
+	if runtime.GOOS == "windows" {
+		s.T().Skip("command relies on a posix shell")
+	}
+
+	resolver := secret.NewResolver(log.Discard,
+		secret.WithBackend("exec", secret.NewExecBackend(true)),
+	)
+
+	resolved, err := resolver.Resolve(s.T().Context(), filepath.FromSlash("testdata/ResolverSuite/TestFile"), map[string]any{
+		"password": &secret.Secret{Backend: "exec", Ref: "cat password"},
+	})
+
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"password": "password",
+	}, resolved)
+
+	s.Run("Error", func() {
+		resolved, err := resolver.Resolve(s.T().Context(), ".", map[string]any{
+			"password": &secret.Secret{Backend: "exec", Ref: "echo failure >&2; exit 1"},
+		})
+
+		s.Nil(resolved)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg: "unable to resolve secret",
+			Attrs: [][2]any{
+				{"secret", "!secret exec:echo failure >&2; exit 1"},
+			},
+			Err: serrortest.Expectation{
+				Msg: "secret command error",
+				Attrs: [][2]any{
+					{"stderr", "failure"},
+				},
+				Err: expectation.ErrorMessage("exit status 1"),
+			},
+		}, err)
+	})
+
+	s.Run("Disabled", func() {
+		resolver := secret.NewResolver(log.Discard,
+			secret.WithBackend("exec", secret.NewExecBackend(false)),
+		)
+
+		resolved, err := resolver.Resolve(s.T().Context(), filepath.FromSlash("testdata/ResolverSuite/TestFile"), map[string]any{
+			"password": &secret.Secret{Backend: "exec", Ref: "cat password"},
+		})
+
+		s.Nil(resolved)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg: "unable to resolve secret",
+			Attrs: [][2]any{
+				{"secret", "!secret exec:cat password"},
+			},
+			Err: serrortest.Expectation{
+				Msg: "exec secrets are disabled, enable them using secret_exec config",
+			},
+		}, err)
+	})
+}
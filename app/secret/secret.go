@@ -0,0 +1,105 @@
+package secret
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+)
+
+// Tag marks secrets in yaml manifests, as in "password: !secret file:.secrets/password".
+const Tag = "!secret"
+
+// Secret references a value held by an external backend, as "<backend>:<ref>".
+// Its value is never held, only resolved on demand, so that it could safely be logged or dumped.
+type Secret struct {
+	Backend string
+	Ref     string
+}
+
+// Parse a secret reference, as "<backend>:<ref>".
+func Parse(reference string) (*Secret, error) {
+	backend, ref, ok := strings.Cut(reference, ":")
+	if !ok || backend == "" || ref == "" {
+		return nil, errors.New("secret reference must be of the form <backend>:<ref>")
+	}
+
+	return &Secret{
+		Backend: backend,
+		Ref:     ref,
+	}, nil
+}
+
+func (secret *Secret) String() string {
+	return Tag + " " + secret.Backend + ":" + secret.Ref
+}
+
+// MarshalYAML dumps secret as its tagged reference.
+func (secret *Secret) MarshalYAML() ([]byte, error) {
+	return []byte(secret.String()), nil
+}
+
+// References returns a copy of value, secrets replaced by their tagged reference.
+func References(value any) any {
+	value, _ = walk(value, func(secret *Secret) (any, error) {
+		return secret.String(), nil
+	})
+
+	return value
+}
+
+// Pointers returns the json pointers of secrets found in value.
+func Pointers(value any) []string {
+	var pointers []string
+
+	var visit func(value any, pointer string)
+	visit = func(value any, pointer string) {
+		switch value := value.(type) {
+		case map[string]any:
+			for _, k := range slices.Sorted(maps.Keys(value)) {
+				visit(value[k], pointer+"/"+jsonpointer.Escape(k))
+			}
+		case []any:
+			for i, v := range value {
+				visit(v, pointer+"/"+strconv.Itoa(i))
+			}
+		case *Secret:
+			pointers = append(pointers, pointer)
+		}
+	}
+
+	visit(value, "")
+
+	return pointers
+}
+
+// walk returns a copy of value, secrets replaced by fn results.
+func walk(value any, fn func(secret *Secret) (any, error)) (any, error) {
+	switch value := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(value))
+		for _, k := range slices.Sorted(maps.Keys(value)) {
+			var err error
+			if m[k], err = walk(value[k], fn); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case []any:
+		s := make([]any, len(value))
+		for i, v := range value {
+			var err error
+			if s[i], err = walk(v, fn); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case *Secret:
+		return fn(value)
+	}
+
+	return value, nil
+}
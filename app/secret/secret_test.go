@@ -0,0 +1,94 @@
+package secret_test
+
+import (
+	"testing"
+
+	"github.com/manala/manala/app/secret"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/suite"
+)
+
+type SecretSuite struct{ suite.Suite }
+
+func TestSecretSuite(t *testing.T) {
+	suite.Run(t, new(SecretSuite))
+}
+
+func (s *SecretSuite) TestParse() {
+	secret, err := secret.Parse("sops:secrets.yaml#db.password")
+
+	s.Require().NoError(err)
+	s.Equal("sops", secret.Backend)
+	s.Equal("secrets.yaml#db.password", secret.Ref)
+	s.Equal("!secret sops:secrets.yaml#db.password", secret.String())
+}
+
+func (s *SecretSuite) TestParseErrors() {
+	tests := []struct {
+		test      string
+		reference string
+	}{
+		{test: "Empty", reference: ""},
+		{test: "BackendMissing", reference: "secrets.yaml"},
+		{test: "BackendEmpty", reference: ":secrets.yaml"},
+		{test: "RefEmpty", reference: "file:"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			secret, err := secret.Parse(test.reference)
+
+			s.Nil(secret)
+			expectation.ExpectError(s.T(),
+				expectation.ErrorMessage("secret reference must be of the form <backend>:<ref>"),
+				err,
+			)
+		})
+	}
+}
+
+func (s *SecretSuite) TestReferences() {
+	vars := map[string]any{
+		"password": &secret.Secret{Backend: "file", Ref: "password"},
+		"tokens":   []any{&secret.Secret{Backend: "exec", Ref: "pass show token"}, "token"},
+		"foo":      "bar",
+	}
+
+	s.Equal(map[string]any{
+		"password": "!secret file:password",
+		"tokens":   []any{"!secret exec:pass show token", "token"},
+		"foo":      "bar",
+	}, secret.References(vars))
+
+	// Original vars are left untouched
+	s.IsType((*secret.Secret)(nil), vars["password"])
+}
+
+func (s *SecretSuite) TestPointers() {
+	vars := map[string]any{
+		"password": &secret.Secret{Backend: "file", Ref: "password"},
+		"tokens":   []any{"token", &secret.Secret{Backend: "exec", Ref: "pass show token"}},
+		"db/app":   map[string]any{"password": &secret.Secret{Backend: "file", Ref: "db"}},
+		"foo":      "bar",
+	}
+
+	s.Equal([]string{
+		"/db~1app/password",
+		"/password",
+		"/tokens/1",
+	}, secret.Pointers(vars))
+}
+
+func (s *SecretSuite) TestMarshalYAML() {
+	data, err := yaml.Marshal(map[string]any{
+		"password": &secret.Secret{Backend: "file", Ref: "password"},
+	})
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), `
+		password: !secret file:password
+	`, string(data))
+}
@@ -0,0 +1,55 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/manala/manala/internal/errors/serror"
+)
+
+// SopsBackend resolves secrets from sops encrypted files, as "<path>", or "<path>#<key>" to extract a dotted key.
+// It relies on the sops command, along with its own keys configuration.
+type SopsBackend struct{}
+
+func NewSopsBackend() *SopsBackend {
+	return &SopsBackend{}
+}
+
+func (backend *SopsBackend) Resolve(ctx context.Context, dir string, ref string) (string, error) {
+	path, key, _ := strings.Cut(ref, "#")
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	args := []string{"--decrypt"}
+
+	// Extract key, as in `["foo"]["bar"]`
+	if key != "" {
+		var extract strings.Builder
+		for token := range strings.SplitSeq(key, ".") {
+			extract.WriteString("[" + strconv.Quote(token) + "]")
+		}
+		args = append(args, "--extract", extract.String())
+	}
+
+	args = append(args, "--", path)
+
+	command := exec.CommandContext(ctx, "sops", args...)
+
+	stderr := &bytes.Buffer{}
+	command.Stderr = stderr
+
+	output, err := command.Output()
+	if err != nil {
+		return "", serror.New("sops command error").
+			With("file", path, "stderr", strings.TrimSpace(stderr.String())).
+			WithErr(err)
+	}
+
+	return strings.TrimRight(string(output), "\r\n"), nil
+}
@@ -23,13 +23,18 @@ func (s *EngineSuite) TestExecutor() {
 
 	repositoryMock := &mocks.Repository{}
 	repositoryMock.
-		On("URL").Return("url")
+		On("URL").Return("url").
+		On("Ref").Return("ref").
+		On("Manifest").Return(nil)
 
 	recipeMock := &mocks.Recipe{}
 	recipeMock.
 		On("Name").Return("name").
 		On("Description").Return("description").
 		On("Icon").Return("icon").
+		On("Category").Return("").
+		On("Deprecated").Return("").
+		On("ReplacedBy").Return("").
 		On("Repository").Return(repositoryMock).
 		On("Partials").Return([]string{})
 
@@ -44,6 +49,7 @@ func (s *EngineSuite) TestExecutor() {
 .Recipe.Description: {{ .Recipe.Description }}
 .Recipe.Icon: {{ .Recipe.Icon }}
 .Recipe.Repository.URL: {{ .Recipe.Repository.URL }}
+.Recipe.Repository.Ref: {{ .Recipe.Repository.Ref }}
 .Recipe.Repository.Path: {{ .Recipe.Repository.Path }}
 .Recipe.Repository.Source: {{ .Recipe.Repository.Source }}
 .Repository.URL: {{ .Repository.URL }}
@@ -57,8 +63,56 @@ func (s *EngineSuite) TestExecutor() {
 		.Recipe.Description: description
 		.Recipe.Icon: icon
 		.Recipe.Repository.URL: url
+		.Recipe.Repository.Ref: ref
 		.Recipe.Repository.Path: url
 		.Recipe.Repository.Source: url
 		.Repository.URL: url
 	`, buffer.String())
 }
+
+func (s *EngineSuite) TestExecutorRepositoryManifest() {
+	engine := template.NewEngine()
+
+	manifestMock := &mocks.RepositoryManifest{}
+	manifestMock.
+		On("Name").Return("name").
+		On("Description").Return("description")
+
+	repositoryMock := &mocks.Repository{}
+	repositoryMock.
+		On("URL").Return("url").
+		On("Ref").Return("ref").
+		On("Manifest").Return(manifestMock)
+
+	recipeMock := &mocks.Recipe{}
+	recipeMock.
+		On("Name").Return("name").
+		On("Description").Return("description").
+		On("Icon").Return("icon").
+		On("Category").Return("category").
+		On("Deprecated").Return("deprecated").
+		On("ReplacedBy").Return("replaced_by").
+		On("Repository").Return(repositoryMock).
+		On("Partials").Return([]string{})
+
+	executor, err := engine.Executor(nil, recipeMock, "dir")
+	s.Require().NoError(err)
+
+	buffer := &bytes.Buffer{}
+	err = executor.Execute(buffer, strings.TrimLeft(`
+.Recipe.Category: {{ .Recipe.Category }}
+.Recipe.Deprecated: {{ .Recipe.Deprecated }}
+.Recipe.ReplacedBy: {{ .Recipe.ReplacedBy }}
+.Recipe.Repository.Name: {{ .Recipe.Repository.Name }}
+.Recipe.Repository.Description: {{ .Recipe.Repository.Description }}
+`, "\n"))
+	s.Require().NoError(err)
+
+	heredoc.Equal(s.T(), `
+		.Recipe.Category: category
+		.Recipe.Deprecated: deprecated
+		.Recipe.ReplacedBy: replaced_by
+		.Recipe.Repository.Name: name
+		.Recipe.Repository.Description: description
+	`, buffer.String())
+}
@@ -11,6 +11,9 @@ type RecipeView struct {
 	Name        string
 	Description string
 	Icon        string
+	Category    string
+	Deprecated  string
+	ReplacedBy  string
 	// Legacy: remove
 	Repository *RepositoryView
 }
@@ -21,6 +24,9 @@ func NewRecipeView(recipe app.Recipe) *RecipeView {
 		Name:        recipe.Name(),
 		Description: recipe.Description(),
 		Icon:        recipe.Icon(),
+		Category:    recipe.Category(),
+		Deprecated:  recipe.Deprecated(),
+		ReplacedBy:  recipe.ReplacedBy(),
 		Repository:  NewRepositoryView(recipe.Repository()),
 	}
 }
@@ -31,7 +37,10 @@ func NewRecipeView(recipe app.Recipe) *RecipeView {
 
 // RepositoryView is a secure and lightweight facade of a Repository, dedicated to template usage.
 type RepositoryView struct {
-	URL string
+	URL         string
+	Ref         string
+	Name        string
+	Description string
 	// Legacy: remove
 	Path string
 	// Legacy: remove
@@ -42,9 +51,17 @@ type RepositoryView struct {
 func NewRepositoryView(repository app.Repository) *RepositoryView {
 	url := repository.URL()
 
-	return &RepositoryView{
+	view := &RepositoryView{
 		URL:    url,
+		Ref:    repository.Ref(),
 		Path:   url,
 		Source: url,
 	}
+
+	if manifest := repository.Manifest(); manifest != nil {
+		view.Name = manifest.Name()
+		view.Description = manifest.Description()
+	}
+
+	return view
 }
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/migration"
 	"github.com/manala/manala/app/sync"
 
 	"github.com/stretchr/testify/mock"
@@ -36,6 +37,36 @@ func (r *Recipe) Icon() string {
 	return args.String(0)
 }
 
+func (r *Recipe) Category() string {
+	args := r.Called()
+
+	return args.String(0)
+}
+
+func (r *Recipe) Deprecated() string {
+	args := r.Called()
+
+	return args.String(0)
+}
+
+func (r *Recipe) ReplacedBy() string {
+	args := r.Called()
+
+	return args.String(0)
+}
+
+func (r *Recipe) Version() string {
+	args := r.Called()
+
+	return args.String(0)
+}
+
+func (r *Recipe) Migrations() []*migration.Migration {
+	args := r.Called()
+
+	return args.Get(0).([]*migration.Migration)
+}
+
 func (r *Recipe) Template() string {
 	args := r.Called()
 
@@ -54,6 +85,12 @@ func (r *Recipe) Sync() []sync.Unit {
 	return args.Get(0).([]sync.Unit)
 }
 
+func (r *Recipe) MergeStrategies() map[string]map[string]string {
+	args := r.Called()
+
+	return args.Get(0).(map[string]map[string]string)
+}
+
 func (r *Recipe) Repository() app.Repository {
 	args := r.Called()
 
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"github.com/manala/manala/app"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -20,3 +22,89 @@ func (r *Repository) Dir() string {
 
 	return args.String(0)
 }
+
+func (r *Repository) Ref() string {
+	args := r.Called()
+
+	return args.String(0)
+}
+
+func (r *Repository) Constraint() string {
+	args := r.Called()
+
+	return args.String(0)
+}
+
+func (r *Repository) Manifest() app.RepositoryManifest {
+	args := r.Called()
+
+	manifest, _ := args.Get(0).(app.RepositoryManifest)
+
+	return manifest
+}
+
+// RepositoryManifest mock a RepositoryManifest.
+type RepositoryManifest struct {
+	mock.Mock
+}
+
+func (m *RepositoryManifest) Name() string {
+	args := m.Called()
+
+	return args.String(0)
+}
+
+func (m *RepositoryManifest) Description() string {
+	args := m.Called()
+
+	return args.String(0)
+}
+
+func (m *RepositoryManifest) Partials() []string {
+	args := m.Called()
+
+	return args.Get(0).([]string)
+}
+
+func (m *RepositoryManifest) Recipes() []app.RepositoryManifestRecipe {
+	args := m.Called()
+
+	return args.Get(0).([]app.RepositoryManifestRecipe)
+}
+
+func (m *RepositoryManifest) Recipe(name string) (app.RepositoryManifestRecipe, bool) {
+	args := m.Called(name)
+
+	recipe, _ := args.Get(0).(app.RepositoryManifestRecipe)
+
+	return recipe, args.Bool(1)
+}
+
+// RepositoryManifestRecipe mock a RepositoryManifestRecipe.
+type RepositoryManifestRecipe struct {
+	mock.Mock
+}
+
+func (r *RepositoryManifestRecipe) Name() string {
+	args := r.Called()
+
+	return args.String(0)
+}
+
+func (r *RepositoryManifestRecipe) Category() string {
+	args := r.Called()
+
+	return args.String(0)
+}
+
+func (r *RepositoryManifestRecipe) Hidden() bool {
+	args := r.Called()
+
+	return args.Bool(0)
+}
+
+func (r *RepositoryManifestRecipe) Deprecated() string {
+	args := r.Called()
+
+	return args.String(0)
+}
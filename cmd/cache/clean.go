@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newCleanCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Flags
+	var olderThan time.Duration
+
+	// Command
+	command := &cobra.Command{
+		Use:               "clean",
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		Short:             "Clean cached repositories",
+		Long: `Clean (manala cache clean) will remove cached repositories, optionally only
+those fetched for longer than a given duration.
+
+Example: manala cache clean --older-than 720h -> resulting in a month old cached
+repositories removal`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runClean(log, api, out, olderThan)
+		},
+	}
+
+	// Set flags
+	command.Flags().DurationVar(&olderThan, "older-than", 0, "only clean repositories fetched for longer than duration")
+
+	return command
+}
+
+func runClean(log *log.Log, api *api.API, out output.Output, olderThan time.Duration) error {
+	// Api
+	cacheManager := api.NewRepositoryCacheManager()
+
+	// Clean entries
+	log.Info("cleaning cache…")
+	entries, err := cacheManager.Clean(olderThan)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		printEntry(out, entry)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/app/repository/getter"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Command
+	command := &cobra.Command{
+		Use:               "cache",
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		Short:             "Manage repositories cache",
+		Long: `Cache (manala cache) will manage repositories cache, allowing to inspect, prune
+and warm it.
+
+Example: manala cache list -> resulting in a cached repositories list display`,
+	}
+
+	// Sub commands
+	command.AddCommand(
+		newListCommand(log, api, out),
+		newCleanCommand(log, api, out),
+		newWarmCommand(log, api, out),
+	)
+
+	return command
+}
+
+// printEntry prints a cache entry, with its url, ref, fetch time and size.
+func printEntry(out output.Output, entry *getter.CacheEntry) {
+	name := entry.URL
+	if name == "" {
+		name = entry.Dir
+	}
+
+	line := out.Style().Render(name)
+	if entry.Ref != "" {
+		line += " " + out.MutedStyle().Render("("+entry.Ref+")")
+	}
+	if entry.Recipe != "" {
+		line += " " + out.MutedStyle().Render("[sparse: "+entry.Recipe+"]")
+	}
+
+	out.Println(line)
+	out.Println("  " + out.MutedStyle().Render(
+		entry.FetchedAt.Format("2006-01-02 15:04:05")+" · "+formatSize(entry.Size),
+	))
+}
+
+// formatSize formats a size in bytes, in a human-readable way.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,124 @@
+package cache_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manala/manala/app/api"
+	cmdCache "github.com/manala/manala/cmd/cache"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandSuite struct{ suite.Suite }
+
+func TestCommandSuite(t *testing.T) {
+	suite.Run(t, new(CommandSuite))
+}
+
+func (s *CommandSuite) TestList() {
+	cacheDir := s.T().TempDir()
+	s.Require().NoError(os.CopyFS(cacheDir, os.DirFS(filepath.FromSlash("testdata/TestList/cache"))))
+
+	stdout, stderr, err := s.execute(cacheDir, "list")
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), `
+		https://example.com/bar.zip
+		  2999-01-02 03:04:05 · 512 B
+		https://example.com/foo.git (v1.2.3)
+		  2020-01-02 03:04:05 · 2.0 KiB
+	`, stdout)
+	heredoc.Equal(s.T(), `
+		 ● listing cache…
+	`, stderr)
+}
+
+func (s *CommandSuite) TestListEmpty() {
+	cacheDir := s.T().TempDir()
+
+	stdout, stderr, err := s.execute(cacheDir, "list")
+
+	s.Require().NoError(err)
+	s.Empty(stdout)
+	heredoc.Equal(s.T(), `
+		 ● listing cache…
+	`, stderr)
+}
+
+func (s *CommandSuite) TestClean() {
+	s.Run("All", func() {
+		cacheDir := s.T().TempDir()
+		s.Require().NoError(os.CopyFS(cacheDir, os.DirFS(filepath.FromSlash("testdata/TestClean/cache"))))
+
+		stdout, stderr, err := s.execute(cacheDir, "clean")
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			https://example.com/bar.zip
+			  2999-01-02 03:04:05 · 512 B
+			https://example.com/foo.git (v1.2.3)
+			  2020-01-02 03:04:05 · 2.0 KiB
+		`, stdout)
+		heredoc.Equal(s.T(), `
+			 ● cleaning cache…
+		`, stderr)
+
+		s.NoDirExists(filepath.Join(cacheDir, "repositories", "bar"))
+		s.NoFileExists(filepath.Join(cacheDir, "repositories", "bar.json"))
+		s.NoDirExists(filepath.Join(cacheDir, "repositories", "foo"))
+		s.NoFileExists(filepath.Join(cacheDir, "repositories", "foo.json"))
+	})
+
+	s.Run("OlderThan", func() {
+		cacheDir := s.T().TempDir()
+		s.Require().NoError(os.CopyFS(cacheDir, os.DirFS(filepath.FromSlash("testdata/TestClean/cache"))))
+
+		stdout, stderr, err := s.execute(cacheDir, "clean", "--older-than", "720h")
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			https://example.com/foo.git (v1.2.3)
+			  2020-01-02 03:04:05 · 2.0 KiB
+		`, stdout)
+		heredoc.Equal(s.T(), `
+			 ● cleaning cache…
+		`, stderr)
+
+		s.DirExists(filepath.Join(cacheDir, "repositories", "bar"))
+		s.FileExists(filepath.Join(cacheDir, "repositories", "bar.json"))
+		s.NoDirExists(filepath.Join(cacheDir, "repositories", "foo"))
+		s.NoFileExists(filepath.Join(cacheDir, "repositories", "foo.json"))
+	})
+}
+
+func (s *CommandSuite) execute(cacheDir string, args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
+	out := &bytes.Buffer{}
+	err := &bytes.Buffer{}
+
+	logger := log.New(output.NewDetached(err))
+	logger.Verbose(1)
+
+	command := cmdCache.NewCommand(
+		logger,
+		api.New(
+			logger,
+			cache.New(cacheDir),
+		),
+		output.NewDetached(out),
+	)
+
+	command.SilenceErrors = true
+	command.SilenceUsage = true
+	command.SetOut(out)
+	command.SetErr(err)
+	command.SetArgs(append([]string{}, args...))
+
+	return out, err, command.Execute()
+}
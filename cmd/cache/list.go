@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newListCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Command
+	command := &cobra.Command{
+		Use:               "list",
+		Aliases:           []string{"ls"},
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		Short:             "List cached repositories",
+		Long: `List (manala cache list) will list cached repositories, with their url, ref,
+fetch time and size.
+
+Example: manala cache list -> resulting in a cached repositories list display`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runList(log, api, out)
+		},
+	}
+
+	return command
+}
+
+func runList(log *log.Log, api *api.API, out output.Output) error {
+	// Api
+	cacheManager := api.NewRepositoryCacheManager()
+
+	// List entries
+	log.Info("listing cache…")
+	entries, err := cacheManager.List()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		printEntry(out, entry)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newWarmCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Flags
+	var repositoryRef string
+
+	// Command
+	command := &cobra.Command{
+		Use:               "warm url",
+		Args:              cobra.ExactArgs(1),
+		DisableAutoGenTag: true,
+		Short:             "Warm repository cache",
+		Long: `Warm (manala cache warm) will fetch a repository into cache, so that it can
+later be used offline.
+
+Example: manala cache warm https://github.com/manala/manala-recipes.git -> resulting
+in a repository fetch`,
+		RunE: func(command *cobra.Command, args []string) error {
+			// Context
+			ctx := command.Context()
+			ctx = app.WithRepositoryURL(ctx, args[0])
+			ctx = app.WithRepositoryRef(ctx, repositoryRef)
+
+			return runWarm(ctx, log, api, out)
+		},
+	}
+
+	// Set flags
+	command.Flags().StringVar(&repositoryRef, "ref", "", "use repository ref")
+
+	return command
+}
+
+func runWarm(ctx context.Context, log *log.Log, api *api.API, out output.Output) error {
+	// Api
+	repositoryLoader := api.NewRepositoryLoader(ctx)
+
+	// Load repository
+	log.Info("warming repository…")
+	repository, err := repositoryLoader.Load(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	line := out.Style().Render(repository.URL())
+	if repository.Ref() != "" {
+		line += " " + out.MutedStyle().Render("("+repository.Ref()+")")
+	}
+
+	out.Println(line)
+
+	return nil
+}
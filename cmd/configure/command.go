@@ -0,0 +1,143 @@
+package configure
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/manala/manala/app/api"
+	cmdInit "github.com/manala/manala/cmd/init"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Flags
+	var (
+		vars cmdInit.Vars
+		yes  bool
+	)
+
+	// Command
+	command := &cobra.Command{
+		Use:               "configure [dir]",
+		Args:              cobra.MaximumNArgs(1),
+		DisableAutoGenTag: true,
+		Short:             "Reconfigure project",
+		Long: `Configure (manala configure) will prompt project recipe options, pre-filled with
+current project variables, then write back changed ones into manifest (.manala.yaml),
+preserving its comments and layout, and synchronize project.
+
+Example: manala configure -> resulting in a reconfiguration in a project dir (default to the
+current directory)
+Example: manala configure --var php.version=8.4 --yes -> resulting in a non-interactive reconfiguration`,
+		RunE: func(command *cobra.Command, args []string) error {
+			// Args
+			dir := filepath.Clean(append(args, "")[0])
+
+			return run(command.Context(), log, api, out, dir, vars, yes)
+		},
+	}
+
+	// Set flags
+	command.Flags().StringArrayVar(&vars.Assignments, "var", nil, "set var (path.to.key=value)")
+	command.Flags().StringVar(&vars.File, "vars-file", "", "set vars from yaml file")
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "skip dialog, using supplied vars")
+
+	return command
+}
+
+func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir string, vars cmdInit.Vars, yes bool) error {
+	// Api
+	repositoryLoader := api.NewRepositoryLoader(ctx)
+	recipeLoader := api.NewRecipeLoader(ctx)
+	projectLoader := api.NewProjectLoader(repositoryLoader, recipeLoader)
+	projectConfigurator := api.NewProjectConfigurator()
+	projectSyncer := api.NewProjectSyncer()
+
+	// Load project
+	log.Info("loading project…")
+	project, err := projectLoader.Load(ctx, dir)
+	if err != nil {
+		return err
+	}
+
+	// Only recipe options being configurable, any other var would never be written
+	if err := vars.ValidateOptions(project.Recipe()); err != nil {
+		return err
+	}
+
+	// Dialog, pre-filled with a copy of current project vars, left untouched for comparison
+	dialogVariant := cmdInit.DialogSingleVariant{
+		Recipe: project.Recipe(),
+		Vars:   clone(project.Vars()).(map[string]any),
+	}
+
+	var outcome *cmdInit.DialogOutcome
+
+	if yes {
+		// Skip dialog
+		outcome, err = cmdInit.SkipDialog(dialogVariant, vars)
+	} else {
+		// Run dialog
+		outcome, err = cmdInit.RunDialog("Manala", dialogVariant, vars, out.Profile)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Configure project
+	log.Info("configuring project…")
+	changed, err := projectConfigurator.Configure(project, outcome.Vars)
+	if err != nil {
+		return err
+	}
+
+	if len(changed) == 0 {
+		out.Println(out.Style().Render("project configuration is unchanged"))
+
+		return nil
+	}
+
+	for _, opt := range changed {
+		log.Info("option configured", "label", opt.Label())
+	}
+
+	// Load project again, along with its new configuration
+	project, err = projectLoader.Load(ctx, dir)
+	if err != nil {
+		return err
+	}
+
+	// Sync project
+	log.Info("syncing project…")
+	err = projectSyncer.Sync(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	out.Println(out.Style().Render("project successfully configured"))
+
+	return nil
+}
+
+// clone deeply copies vars, so that dialog changes could be compared to the original ones.
+func clone(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		values := make(map[string]any, len(value))
+		for k, v := range value {
+			values[k] = clone(v)
+		}
+		return values
+	case []any:
+		values := make([]any, len(value))
+		for i, v := range value {
+			values[i] = clone(v)
+		}
+		return values
+	default:
+		return value
+	}
+}
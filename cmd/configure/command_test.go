@@ -0,0 +1,151 @@
+package configure_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manala/manala/app/api"
+	cmdConfigure "github.com/manala/manala/cmd/configure"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandSuite struct{ suite.Suite }
+
+func TestCommandSuite(t *testing.T) {
+	suite.Run(t, new(CommandSuite))
+}
+
+func (s *CommandSuite) TestConfigure() {
+	repositoryURL, _ := filepath.Abs(filepath.FromSlash("testdata/TestConfigure/repository"))
+
+	projectDir := s.T().TempDir()
+	projectFile := filepath.Join(projectDir, ".manala.yaml")
+
+	s.Require().NoError(os.WriteFile(projectFile, []byte(heredoc.Doc(`
+		# Project
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+
+		# Debug
+		debug: true # Enabled
+	`, repositoryURL)), 0o644))
+
+	s.Run("Configured", func() {
+		stdout, stderr, err := s.execute(
+			projectDir,
+			"--var", "php.version=8.4",
+			"--var", "debug=true",
+			"--yes",
+		)
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			project successfully configured
+		`, stdout)
+		heredoc.Equal(s.T(), `
+			 ● loading project…
+			 ● configuring project…
+			 ● option configured                label=Php version
+			 ● syncing project…
+			 ● file synced                      path=file
+		`, stderr)
+
+		heredoc.EqualFile(s.T(), `
+			# Project
+			manala:
+			  recipe: recipe
+			  repository: %[1]s
+
+			# Debug
+			debug: true # Enabled
+			php:
+			  version: "8.4"
+		`, projectFile, repositoryURL)
+
+		heredoc.EqualFile(s.T(), `
+			php: 8.4
+		`, filepath.Join(projectDir, "file"))
+	})
+
+	s.Run("Unchanged", func() {
+		stdout, stderr, err := s.execute(
+			projectDir,
+			"--var", "php.version=8.4",
+			"--yes",
+		)
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			project configuration is unchanged
+		`, stdout)
+		heredoc.Equal(s.T(), `
+			 ● loading project…
+			 ● configuring project…
+		`, stderr)
+	})
+}
+
+func (s *CommandSuite) TestConfigureErrors() {
+	repositoryURL, _ := filepath.Abs(filepath.FromSlash("testdata/TestConfigure/repository"))
+
+	projectDir := s.T().TempDir()
+	projectFile := filepath.Join(projectDir, ".manala.yaml")
+
+	s.Require().NoError(os.WriteFile(projectFile, []byte(heredoc.Doc(`
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+	`, repositoryURL)), 0o644))
+
+	_, _, err := s.execute(
+		projectDir,
+		"--var", "php.versions=8.4",
+		"--yes",
+	)
+
+	expectation.ExpectError(s.T(), serrortest.Expectation{
+		Msg:   "unknown recipe option var",
+		Attrs: [][2]any{{"var", "php.versions=8.4"}},
+	}, err)
+
+	heredoc.EqualFile(s.T(), `
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+	`, projectFile, repositoryURL)
+}
+
+func (s *CommandSuite) execute(args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
+	out := &bytes.Buffer{}
+	err := &bytes.Buffer{}
+
+	logger := log.New(output.NewDetached(err))
+	logger.Verbose(1)
+
+	command := cmdConfigure.NewCommand(
+		logger,
+		api.New(
+			logger,
+			cache.New(""),
+		),
+		output.NewDetached(out),
+	)
+
+	command.SilenceErrors = true
+	command.SilenceUsage = true
+	command.SetOut(out)
+	command.SetErr(err)
+	command.SetArgs(append([]string{}, args...))
+
+	return out, err, command.Execute()
+}
@@ -0,0 +1,71 @@
+package get
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Command
+	command := &cobra.Command{
+		Use:               "get key [dir]",
+		Args:              cobra.RangeArgs(1, 2),
+		DisableAutoGenTag: true,
+		Short:             "Get project manifest value",
+		Long: `Get (manala get) will print a project manifest (.manala.yaml) value,
+found at the given dotted key path.
+
+Example: manala get php.version -> resulting in "php.version" value of a project dir (default to the
+current directory)`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			// Args
+			key := args[0]
+			dir := filepath.Clean(append(args[1:], "")[0])
+
+			return run(log, api, out, dir, key)
+		},
+	}
+
+	return command
+}
+
+func run(log *log.Log, api *api.API, out output.Output, dir string, key string) error {
+	// Api
+	projectEditor := api.NewProjectEditor(dir)
+
+	// Load project manifest
+	log.Info("loading project manifest…")
+	if err := projectEditor.Load(); err != nil {
+		return err
+	}
+
+	value, ok, err := projectEditor.Get(yamlpath.ToJSONPointer("$." + key))
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return serror.New("project manifest value not found").
+			With("key", key)
+	}
+
+	content, err := yaml.MarshalWithOptions(value, yaml.IndentSequence(true))
+	if err != nil {
+		return serror.New("unable to encode project manifest value").
+			With("key", key).
+			WithErr(err)
+	}
+
+	out.Println(strings.TrimSuffix(string(content), "\n"))
+
+	return nil
+}
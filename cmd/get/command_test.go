@@ -0,0 +1,95 @@
+package get_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manala/manala/app/api"
+	cmdGet "github.com/manala/manala/cmd/get"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandSuite struct{ suite.Suite }
+
+func TestCommandSuite(t *testing.T) {
+	suite.Run(t, new(CommandSuite))
+}
+
+func (s *CommandSuite) TestGet() {
+	projectDir := s.T().TempDir()
+
+	s.Require().NoError(os.WriteFile(filepath.Join(projectDir, ".manala.yaml"), []byte(heredoc.Doc(`
+		manala:
+		  recipe: recipe
+
+		php:
+		  version: "8.4"
+		  extensions: [intl, redis]
+	`)), 0o644))
+
+	s.Run("Scalar", func() {
+		stdout, stderr, err := s.execute("php.version", projectDir)
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			"8.4"
+		`, stdout)
+		heredoc.Equal(s.T(), `
+			 ● loading project manifest…
+		`, stderr)
+	})
+
+	s.Run("Sequence", func() {
+		stdout, _, err := s.execute("php.extensions", projectDir)
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			  - intl
+			  - redis
+		`, stdout)
+	})
+
+	s.Run("NotFound", func() {
+		stdout, _, err := s.execute("php.missing", projectDir)
+
+		s.Empty(stdout)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg:   "project manifest value not found",
+			Attrs: [][2]any{{"key", "php.missing"}},
+		}, err)
+	})
+}
+
+func (s *CommandSuite) execute(args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
+	out := &bytes.Buffer{}
+	err := &bytes.Buffer{}
+
+	logger := log.New(output.NewDetached(err))
+	logger.Verbose(1)
+
+	command := cmdGet.NewCommand(
+		logger,
+		api.New(
+			logger,
+			cache.New(""),
+		),
+		output.NewDetached(out),
+	)
+
+	command.SilenceErrors = true
+	command.SilenceUsage = true
+	command.SetOut(out)
+	command.SetErr(err)
+	command.SetArgs(append([]string{}, args...))
+
+	return out, err, command.Execute()
+}
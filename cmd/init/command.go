@@ -1,11 +1,15 @@
 package init
 
 import (
+	"cmp"
 	"context"
 	"path/filepath"
 
 	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/app/recipe"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/internal/errors/serror"
 	"github.com/manala/manala/internal/log"
 	"github.com/manala/manala/internal/output"
 
@@ -18,6 +22,9 @@ func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
 		repositoryURL string
 		repositoryRef string
 		recipeName    string
+		search        string
+		vars          Vars
+		yes           bool
 	)
 
 	// Command
@@ -28,8 +35,13 @@ func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
 		Short:             "Init project",
 		Long: `Init (manala init) will init a project.
 
+When no recipe is explicitly given, it is selected among recipes of repository, and of all
+configured repository aliases as well, unless a repository is explicitly given.
+
 Example: manala init -> resulting in a project init in a dir (default to the
-current directory)`,
+current directory)
+Example: manala init --search php -> resulting in a recipe selection among those matching "php"
+Example: manala init --recipe php --var php.version=8.4 --yes -> resulting in a non-interactive project init`,
 		RunE: func(command *cobra.Command, args []string) error {
 			// Args
 			dir := filepath.Clean(append(args, "")[0])
@@ -39,8 +51,9 @@ current directory)`,
 			ctx = app.WithRepositoryURL(ctx, repositoryURL)
 			ctx = app.WithRepositoryRef(ctx, repositoryRef)
 			ctx = app.WithRecipeName(ctx, recipeName)
+			ctx = api.WithRepositoryShorthand(ctx)
 
-			return run(ctx, log, api, out, dir)
+			return run(ctx, log, api, out, dir, search, vars, yes)
 		},
 	}
 
@@ -48,13 +61,16 @@ current directory)`,
 	command.Flags().StringVarP(&repositoryURL, "repository", "o", "", "use repository")
 	command.Flags().StringVar(&repositoryRef, "ref", "", "use repository ref")
 	command.Flags().StringVarP(&recipeName, "recipe", "i", "", "use recipe")
+	command.Flags().StringVarP(&search, "search", "s", "", "search recipes by name or description")
+	command.Flags().StringArrayVar(&vars.Assignments, "var", nil, "set var (path.to.key=value)")
+	command.Flags().StringVar(&vars.File, "vars-file", "", "set vars from yaml file")
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "skip dialog, using recipe and supplied vars")
 
 	return command
 }
 
-func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir string) error {
+func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir string, search string, vars Vars, yes bool) error {
 	var (
-		repository    app.Repository
 		dialogVariant DialogVariant
 		project       app.Project
 		err           error
@@ -73,33 +89,71 @@ func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir
 		return &app.AlreadyExistingProjectError{Dir: dir}
 	}
 
-	// Load repository
-	log.Info("loading repository…")
-	repository, err = repositoryLoader.Load("")
-	if err != nil {
-		return err
-	}
-
 	if _, ok := app.RecipeName(ctx); ok {
+		// Load repository
+		log.Info("loading repository…")
+		repository, err := repositoryLoader.Load(ctx, "")
+		if err != nil {
+			return err
+		}
+
 		// Load recipe by context
 		log.Info("loading recipe…")
-		recipe, err := recipeLoader.Load(repository, "")
+		recipe, err := recipeLoader.Load(ctx, repository, "")
 		if err != nil {
 			return err
 		}
 		dialogVariant = DialogSingleVariant{Recipe: recipe}
 	} else {
-		// Select recipe
-		log.Info("loading recipes…")
-		recipes, err := recipeLoader.LoadAll(repository)
-		if err != nil {
-			return err
+		var (
+			recipes []app.Recipe
+			loaded  int
+			loadErr error
+		)
+
+		// Select recipe, across repositories, unreachable ones left aside
+		urls := api.SearchRepositoryURLs(ctx)
+		for _, url := range urls {
+			repositoryRecipes, err := loadRecipes(ctx, log, repositoryLoader, recipeLoader, url)
+			if err != nil {
+				// Sole repository
+				if len(urls) == 1 {
+					return err
+				}
+
+				log.Warn("unable to load repository recipes", "url", cmp.Or(url, "default"), "error", err)
+				if loadErr == nil {
+					loadErr = err
+				}
+
+				continue
+			}
+
+			loaded++
+			recipes = append(recipes, recipe.Search(repositoryRecipes, search)...)
+		}
+
+		if loaded == 0 {
+			return loadErr
+		}
+
+		if len(recipes) == 0 {
+			return serror.New("no recipe matching search").
+				With("search", search)
 		}
+
 		dialogVariant = DialogMultiVariant{Recipes: recipes}
 	}
 
-	// Run dialog
-	outcome, err := RunDialog("Manala", dialogVariant, out.Profile)
+	var outcome *DialogOutcome
+
+	if yes {
+		// Skip dialog
+		outcome, err = SkipDialog(dialogVariant, vars)
+	} else {
+		// Run dialog
+		outcome, err = RunDialog("Manala", dialogVariant, vars, out.Profile)
+	}
 	if err != nil {
 		return err
 	}
@@ -113,7 +167,7 @@ func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir
 
 	// Sync project
 	log.Info("syncing project…")
-	err = projectSyncer.Sync(project)
+	err = projectSyncer.Sync(ctx, project)
 	if err != nil {
 		return err
 	}
@@ -122,3 +176,17 @@ func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir
 
 	return nil
 }
+
+// loadRecipes loads all recipes of a repository.
+func loadRecipes(ctx context.Context, log *log.Log, repositoryLoader *repository.Loader, recipeLoader *recipe.Loader, url string) ([]app.Recipe, error) {
+	// Load repository
+	log.Info("loading repository…")
+	repository, err := repositoryLoader.Load(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load recipes
+	log.Info("loading recipes…")
+	return recipeLoader.LoadAll(ctx, repository)
+}
@@ -147,6 +147,45 @@ func (s *CommandSuite) TestRepositoryArg() {
 	`, filepath.Join(projectDir, ".manala.yaml"), repositoryURL)
 }
 
+func (s *CommandSuite) TestRepositoryAlias() {
+	projectDir := filepath.FromSlash("testdata/TestRepositoryAlias/project")
+	repositoryURL := filepath.FromSlash("testdata/TestRepositoryAlias/repository")
+
+	_ = os.RemoveAll(projectDir)
+
+	stdout, stderr, err := s.executeWithOptions(
+		[]api.Option{
+			api.WithRepositoryAliases(map[string]string{"alias": repositoryURL}),
+		},
+		projectDir,
+		"--repository", "alias:recipe",
+	)
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), `
+		project successfully initialized
+	`, stdout)
+	heredoc.Equal(s.T(), `
+		 ● finding project…
+		 ● loading repository…
+		 ● loading recipe…
+		 ● creating project…
+		 ● syncing project…
+	`, stderr)
+
+	// Alias recorded rather than resolved url
+	heredoc.EqualFile(s.T(), `
+		####################################################################
+		#                         !!! REMINDER !!!                         #
+		# Don't forget to run `+"`"+`manala up`+"`"+` each time you update this file ! #
+		####################################################################
+
+		manala:
+		    recipe: recipe
+		    repository: alias
+	`, filepath.Join(projectDir, ".manala.yaml"))
+}
+
 func (s *CommandSuite) TestRepositoryErrors() {
 	dir := filepath.FromSlash("testdata/TestRepositoryErrors")
 
@@ -260,7 +299,222 @@ func (s *CommandSuite) TestRecipeErrors() {
 	}
 }
 
+func (s *CommandSuite) TestSearchErrors() {
+	projectDir := filepath.FromSlash("testdata/TestSearchErrors/project")
+	repositoryURL := filepath.FromSlash("testdata/TestSearchErrors/repository")
+
+	stdout, stderr, err := s.execute(repositoryURL,
+		projectDir,
+		"--search", "foo",
+	)
+
+	s.Empty(stdout)
+	heredoc.Equal(s.T(), `
+		 ● finding project…
+		 ● loading repository…
+		 ● loading recipes…
+	`, stderr)
+	expectation.ExpectError(s.T(), serrortest.Expectation{
+		Msg:   "no recipe matching search",
+		Attrs: [][2]any{{"search", "foo"}},
+	}, err)
+
+	s.NoDirExists(projectDir)
+}
+
+func (s *CommandSuite) TestVars() {
+	projectDir := filepath.FromSlash("testdata/TestVars/project")
+	repositoryURL := filepath.FromSlash("testdata/TestVars/repository")
+
+	_ = os.RemoveAll(projectDir)
+
+	stdout, stderr, err := s.execute(repositoryURL,
+		projectDir,
+		"--recipe", "recipe",
+		"--vars-file", filepath.FromSlash("testdata/TestVars/vars.yaml"),
+		"--var", "php.version=8.4",
+		"--var", "debug=true",
+		"--var", "port=8443",
+		"--var", "php.extensions=[intl]",
+		"--yes",
+	)
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), `
+		project successfully initialized
+	`, stdout)
+	heredoc.Equal(s.T(), `
+		 ● finding project…
+		 ● loading repository…
+		 ● loading recipe…
+		 ● creating project…
+		 ● syncing project…
+	`, stderr)
+
+	heredoc.EqualFile(s.T(), `
+		manala:
+		    recipe: recipe
+		    repository: %[1]s
+
+		debug: true
+		name: foo
+		php:
+		    extensions:
+		        - intl
+		    version: '8.4'
+		port: 8443
+	`, filepath.Join(projectDir, ".manala.yaml"), repositoryURL)
+}
+
+func (s *CommandSuite) TestVarsErrors() {
+	projectDir := filepath.FromSlash("testdata/TestVarsErrors/project")
+	repositoryURL := filepath.FromSlash("testdata/TestVarsErrors/repository")
+
+	tests := []struct {
+		test          string
+		args          []string
+		expectedError expectation.ErrorExpectation
+	}{
+		{
+			test: "Assignment",
+			args: []string{"--var", "name"},
+			expectedError: serrortest.Expectation{
+				Msg:   "invalid var assignment, expected path.to.key=value",
+				Attrs: [][2]any{{"var", "name"}},
+			},
+		},
+		{
+			test: "Path",
+			args: []string{"--var", ".=x"},
+			expectedError: serrortest.Expectation{
+				Msg:   "invalid var path",
+				Attrs: [][2]any{{"var", ".=x"}},
+			},
+		},
+		{
+			test: "Enum",
+			args: []string{"--var", "php.version=7.4"},
+			expectedError: serrortest.Expectation{
+				Msg: "invalid recipe option value",
+				Attrs: [][2]any{
+					{"label", "Php version"},
+					{"var", "php.version=7.4"},
+				},
+				Err: expectation.ErrorMessage("value must be one of '8.3', '8.4'"),
+			},
+		},
+		{
+			test: "String",
+			args: []string{"--var", "name=foo_bar_baz"},
+			expectedError: serrortest.Expectation{
+				Msg:   "invalid recipe option value",
+				Attrs: [][2]any{{"label", "Name"}},
+				Err:   expectation.ErrorMessage("maxLength: got 11, want 10"),
+			},
+		},
+		{
+			test: "Integer",
+			args: []string{"--var", "port=80"},
+			expectedError: serrortest.Expectation{
+				Msg: "invalid recipe option value",
+				Attrs: [][2]any{
+					{"label", "Port"},
+					{"var", "port=80"},
+				},
+				Err: expectation.ErrorMessage("minimum: got 80, want 1,024"),
+			},
+		},
+		{
+			test: "IntegerType",
+			args: []string{"--var", "port=foo"},
+			expectedError: serrortest.Expectation{
+				Msg: "invalid recipe option value",
+				Attrs: [][2]any{
+					{"label", "Port"},
+					{"var", "port=foo"},
+				},
+				Err: expectation.ErrorMessage("invalid integer"),
+			},
+		},
+		{
+			test: "Boolean",
+			args: []string{"--var", "debug=foo"},
+			expectedError: serrortest.Expectation{
+				Msg: "invalid recipe option value",
+				Attrs: [][2]any{
+					{"label", "Debug"},
+					{"var", "debug=foo"},
+				},
+				Err: expectation.ErrorMessage("invalid boolean"),
+			},
+		},
+		{
+			test: "Array",
+			args: []string{"--var", "php.extensions=[xdebug]"},
+			expectedError: serrortest.Expectation{
+				Msg: "invalid recipe option value",
+				Attrs: [][2]any{
+					{"label", "Php extensions"},
+					{"var", "php.extensions=[xdebug]"},
+				},
+				Err: expectation.ErrorMessage("value must be one of 'intl', 'redis'"),
+			},
+		},
+		{
+			test: "ArrayType",
+			args: []string{"--var", "php.extensions=intl"},
+			expectedError: serrortest.Expectation{
+				Msg: "invalid recipe option value",
+				Attrs: [][2]any{
+					{"label", "Php extensions"},
+					{"var", "php.extensions=intl"},
+				},
+				Err: expectation.ErrorMessage("invalid array"),
+			},
+		},
+		{
+			test: "Schema",
+			args: []string{"--var", "extra.port=8080"},
+			expectedError: serrortest.Expectation{
+				Msg: "invalid project vars",
+				Err: expectation.ErrorMessage("additional property 'extra' not allowed"),
+			},
+		},
+		{
+			test: "File",
+			args: []string{"--vars-file", filepath.FromSlash("testdata/TestVarsErrors/missing.yaml")},
+			expectedError: serrortest.Expectation{
+				Msg:   "unable to read vars file",
+				Attrs: [][2]any{{"file", filepath.FromSlash("testdata/TestVarsErrors/missing.yaml")}},
+				Err:   expectation.ErrorMessage("file does not exist"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			stdout, _, err := s.execute(repositoryURL,
+				append([]string{projectDir, "--recipe", "recipe", "--yes"}, test.args...)...,
+			)
+
+			s.Empty(stdout)
+			expectation.ExpectError(s.T(), test.expectedError, err)
+
+			s.NoDirExists(projectDir)
+		})
+	}
+}
+
 func (s *CommandSuite) execute(defaultRepositoryURL string, args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
+	return s.executeWithOptions(
+		[]api.Option{
+			api.WithDefaultRepositoryURL(defaultRepositoryURL),
+		},
+		args...,
+	)
+}
+
+func (s *CommandSuite) executeWithOptions(opts []api.Option, args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
 	out := &bytes.Buffer{}
 	err := &bytes.Buffer{}
 
@@ -272,7 +526,7 @@ func (s *CommandSuite) execute(defaultRepositoryURL string, args ...string) (*by
 		api.New(
 			logger,
 			cache.New(""),
-			api.WithDefaultRepositoryURL(defaultRepositoryURL),
+			opts...,
 		),
 		output.NewDetached(out),
 	)
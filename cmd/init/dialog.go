@@ -3,6 +3,7 @@ package init
 import (
 	"github.com/manala/manala/app"
 	"github.com/manala/manala/cmd"
+	"github.com/manala/manala/internal/errors/serror"
 	"github.com/manala/manala/internal/output"
 
 	"codeberg.org/tslocum/cview"
@@ -14,32 +15,55 @@ type (
 	DialogVariant       any
 	DialogSingleVariant struct {
 		Recipe app.Recipe
+		// Initial vars, default to recipe ones
+		Vars map[string]any
 	}
 	DialogMultiVariant struct {
 		Recipes []app.Recipe
 	}
 )
 
+func (variant DialogSingleVariant) vars() map[string]any {
+	if variant.Vars != nil {
+		return variant.Vars
+	}
+	return variant.Recipe.Vars()
+}
+
 type DialogOutcome struct {
 	Recipe app.Recipe
 	Vars   map[string]any
 }
 
-func RunDialog(title string, variant DialogVariant, profile output.Profile) (*DialogOutcome, error) {
+// RunDialog runs a recipe selection and configuration dialog, supplied vars serving as initial values.
+func RunDialog(title string, variant DialogVariant, vars Vars, profile output.Profile) (*DialogOutcome, error) {
 	var outcome *DialogOutcome
 
 	// Dialog
 	dialog, panels := NewDialog(title, profile)
 	defer dialog.HandlePanic()
 
-	// Form
+	// Review
+	review, reviewPanel := NewDialogReview("Review recipe", profile)
+	panels.AddPanel("review", reviewPanel, true, false)
+
+	// Form, followed by a review
 	form, formPanel := NewDialogForm("Configure recipe", profile)
 	form.SetErroredFunc(func(err error) { dialog.Fatal(err) })
-	form.SetAppliedFunc(func() { dialog.Stop() })
+	form.SetAppliedFunc(func() {
+		review.Build(form.Active(), &outcome.Vars,
+			func() { panels.SetCurrentPanel("form") },
+			func() { dialog.Stop() },
+		)
+		panels.SetCurrentPanel("review")
+	})
 
 	switch variant := variant.(type) {
 	case DialogSingleVariant:
-		outcome = &DialogOutcome{variant.Recipe, variant.Recipe.Vars()}
+		outcome = &DialogOutcome{variant.Recipe, variant.vars()}
+		if err := vars.Apply(outcome.Recipe, &outcome.Vars); err != nil {
+			return nil, err
+		}
 
 		options := outcome.Recipe.Options()
 		if len(options) == 0 {
@@ -59,6 +83,11 @@ func RunDialog(title string, variant DialogVariant, profile output.Profile) (*Di
 		list.SetDoneFunc(func() { dialog.Cancel() })
 		list.SetSelectedFunc(func(recipe app.Recipe) {
 			outcome = &DialogOutcome{recipe, recipe.Vars()}
+			if err := vars.Apply(outcome.Recipe, &outcome.Vars); err != nil {
+				dialog.Fatal(err)
+
+				return
+			}
 
 			options := outcome.Recipe.Options()
 			if len(options) == 0 {
@@ -92,6 +121,33 @@ func RunDialog(title string, variant DialogVariant, profile output.Profile) (*Di
 	return outcome, nil
 }
 
+// SkipDialog comes to the same outcome as a dialog would, using supplied vars.
+func SkipDialog(variant DialogVariant, vars Vars) (*DialogOutcome, error) {
+	var outcome *DialogOutcome
+
+	switch variant := variant.(type) {
+	case DialogSingleVariant:
+		outcome = &DialogOutcome{variant.Recipe, variant.vars()}
+	case DialogMultiVariant:
+		if len(variant.Recipes) > 1 {
+			return nil, serror.New("several recipes to select from, use --recipe").
+				With("recipes", len(variant.Recipes))
+		}
+
+		outcome = &DialogOutcome{variant.Recipes[0], variant.Recipes[0].Vars()}
+	}
+
+	if err := vars.Apply(outcome.Recipe, &outcome.Vars); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateVars(outcome.Recipe, &outcome.Vars); err != nil {
+		return nil, err
+	}
+
+	return outcome, nil
+}
+
 type Dialog struct {
 	*cview.Application
 
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/recipe/option"
@@ -21,6 +23,14 @@ type DialogForm struct {
 	profile output.Profile
 	errored func(error)
 	applied func()
+
+	options []app.RecipeOption
+	vars    *map[string]any
+	items   []DialogFormItem
+	active  []bool
+	pages   []string
+	page    int
+	footer  *cview.TextView
 }
 
 func NewDialogForm(title string, profile output.Profile) (*DialogForm, *cview.Flex) {
@@ -45,7 +55,17 @@ func NewDialogForm(title string, profile output.Profile) (*DialogForm, *cview.Fl
 	form.SetButtonBackgroundColor(profile.MutedColor())
 	form.SetButtonBackgroundColorFocused(profile.MutedColor())
 
-	return form, NewDialogPanel(title, form, profile)
+	// Footer, for wizard step and validation summary
+	form.footer = cview.NewTextView()
+	form.footer.SetBackgroundColor(color.Default)
+	form.footer.SetPadding(0, 0, 2, 0)
+
+	flex := cview.NewFlex()
+	flex.SetDirection(cview.FlexRow)
+	flex.AddItem(form, 0, 1, true)
+	flex.AddItem(form.footer, 1, 0, false)
+
+	return form, NewDialogPanel(title, flex, profile)
 }
 
 func (form *DialogForm) SetErroredFunc(handler func(error)) {
@@ -58,6 +78,17 @@ func (form *DialogForm) SetAppliedFunc(handler func()) {
 
 func (form *DialogForm) Build(options []app.RecipeOption, vars *map[string]any) error {
 	form.Clear(true)
+	form.options = options
+	form.vars = vars
+	form.items = nil
+
+	// Pages, one by options group, in order of appearance
+	form.pages = nil
+	for _, opt := range options {
+		if !slices.Contains(form.pages, opt.Group()) {
+			form.pages = append(form.pages, opt.Group())
+		}
+	}
 
 	// Items
 	var items []DialogFormItem
@@ -70,8 +101,8 @@ func (form *DialogForm) Build(options []app.RecipeOption, vars *map[string]any)
 					With("label", opt.Label()).
 					WithErr(err)
 			}
+			item.changed = form.changed
 			items = append(items, item)
-			form.AddFormItem(item)
 		case *option.String:
 			item, err := NewDialogTextFormItem(opt, vars, form.errored, form.profile)
 			if err != nil {
@@ -79,24 +110,197 @@ func (form *DialogForm) Build(options []app.RecipeOption, vars *map[string]any)
 					With("label", opt.Label()).
 					WithErr(err)
 			}
+			item.changed = form.changed
+			items = append(items, item)
+		case *option.Boolean:
+			item, err := NewDialogCheckBoxFormItem(opt, vars, form.errored)
+			if err != nil {
+				return serror.New("invalid recipe option").
+					With("label", opt.Label()).
+					WithErr(err)
+			}
+			item.changed = form.changed
+			items = append(items, item)
+		case *option.Integer:
+			item, err := NewDialogNumericFormItem(opt, parseInteger, vars, form.errored, form.profile)
+			if err != nil {
+				return serror.New("invalid recipe option").
+					With("label", opt.Label()).
+					WithErr(err)
+			}
+			item.changed = form.changed
+			items = append(items, item)
+		case *option.Number:
+			item, err := NewDialogNumericFormItem(opt, parseNumber, vars, form.errored, form.profile)
+			if err != nil {
+				return serror.New("invalid recipe option").
+					With("label", opt.Label()).
+					WithErr(err)
+			}
+			item.changed = form.changed
+			items = append(items, item)
+		case *option.Array:
+			item, err := NewDialogMultiSelectFormItem(opt, vars, form.errored, form.profile)
+			if err != nil {
+				return serror.New("invalid recipe option").
+					With("label", opt.Label()).
+					WithErr(err)
+			}
+			item.changed = form.changed
 			items = append(items, item)
-			form.AddFormItem(item)
 		default:
 			return serror.New("unknown recipe option").
 				With("label", opt.Label())
 		}
 	}
 
-	// Apply
-	form.AddButton("Apply", func() {
-		applied := true
-		for _, item := range items {
-			applied = item.Apply() && applied
+	form.items = items
+
+	// First page
+	return form.turn(0)
+}
+
+// Paged tells whether options are spread over several pages, as a wizard.
+func (form *DialogForm) Paged() bool {
+	return len(form.pages) > 1
+}
+
+// Active returns active options, in order.
+func (form *DialogForm) Active() []app.RecipeOption {
+	var options []app.RecipeOption
+	for i, opt := range form.options {
+		if form.active[i] {
+			options = append(options, opt)
 		}
-		if applied && form.applied != nil {
-			form.applied()
+	}
+	return options
+}
+
+// turn turns to the given page, with its navigation buttons.
+func (form *DialogForm) turn(page int) error {
+	form.page = page
+	form.active = nil
+
+	form.ClearButtons()
+	if page > 0 {
+		form.AddButton("Back", func() {
+			if err := form.turn(page - 1); err != nil {
+				form.errored(err)
+			}
+		})
+	}
+	if page < len(form.pages)-1 {
+		form.AddButton("Next", func() {
+			if !form.apply() {
+				return
+			}
+			if err := form.turn(page + 1); err != nil {
+				form.errored(err)
+			}
+		})
+	} else {
+		form.AddButton("Apply", func() {
+			if form.apply() && form.applied != nil {
+				form.applied()
+			}
+		})
+	}
+
+	if err := form.refresh(); err != nil {
+		return err
+	}
+
+	form.SetFocus(0)
+	form.summarize(nil)
+
+	return nil
+}
+
+// apply applies current page visible items, summarizing invalid ones.
+func (form *DialogForm) apply() bool {
+	var invalid []string
+	for i, item := range form.items {
+		if form.visible(i) && !item.Apply() {
+			invalid = append(invalid, form.options[i].Label())
 		}
-	})
+	}
+
+	form.summarize(invalid)
+
+	return len(invalid) == 0
+}
+
+// summarize renders footer, with current wizard step, and invalid items labels, if any.
+func (form *DialogForm) summarize(invalid []string) {
+	var text string
+	if form.Paged() {
+		text = fmt.Sprintf("Step %d/%d", form.page+1, len(form.pages))
+		if group := form.pages[form.page]; group != "" {
+			text += " · " + group
+		}
+	}
+
+	if len(invalid) > 0 {
+		if text != "" {
+			text += " · "
+		}
+		text += fmt.Sprintf("%d invalid value(s): %s", len(invalid), strings.Join(invalid, ", "))
+		form.footer.SetTextColor(form.profile.ErrorColor())
+	} else {
+		form.footer.SetTextColor(form.profile.MutedColor())
+	}
+
+	form.footer.SetText(cview.Escape(text))
+}
+
+// visible tells whether the item of the given option index is shown on current page.
+func (form *DialogForm) visible(i int) bool {
+	return form.active[i] && form.options[i].Group() == form.pages[form.page]
+}
+
+// changed refreshes visible items, once an item value changed.
+func (form *DialogForm) changed() {
+	if err := form.refresh(); err != nil {
+		form.errored(err)
+	}
+}
+
+// refresh evaluates options conditions against vars being built,
+// and shows only the items of the active ones on current page, keeping focus on current item.
+func (form *DialogForm) refresh() error {
+	active := make([]bool, len(form.options))
+	for i, opt := range form.options {
+		var err error
+		if active[i], err = opt.Active(form.vars); err != nil {
+			return serror.New("invalid recipe option condition").
+				With("label", opt.Label()).
+				WithErr(err)
+		}
+	}
+
+	if slices.Equal(active, form.active) {
+		return nil
+	}
+
+	form.active = active
+
+	var focused cview.FormItem
+	if i, _ := form.GetFocusedItemIndex(); i != -1 {
+		focused = form.GetFormItem(i)
+	}
+
+	form.Clear(false)
+	for i, item := range form.items {
+		if form.visible(i) {
+			form.AddFormItem(item)
+		}
+	}
+
+	if focused != nil {
+		if i := form.IndexOfFormItem(focused); i != -1 {
+			form.SetFocus(i)
+		}
+	}
 
 	return nil
 }
@@ -106,6 +310,7 @@ func (form *DialogForm) Build(options []app.RecipeOption, vars *map[string]any)
 /*********/
 
 type DialogFormItem interface {
+	cview.FormItem
 	Apply() bool
 }
 
@@ -116,6 +321,7 @@ type DialogTextFormItem struct {
 	vars    *map[string]any
 	profile output.Profile
 	errored func(error)
+	changed func()
 }
 
 func NewDialogTextFormItem(
@@ -131,13 +337,16 @@ func NewDialogTextFormItem(
 		vars:       vars,
 		errored:    errored,
 		profile:    profile,
+		changed:    func() {},
 	}
 
 	// Input field
 	item.SetFieldNoteTextColor(profile.MutedColor())
 	item.SetLabel(option.Label())
 	item.SetChangedFunc(func(_ string) {
-		item.Apply()
+		if item.Apply() {
+			item.changed()
+		}
 	})
 
 	// Initial value
@@ -192,6 +401,7 @@ type DialogSelectFormItem struct {
 	values  []any
 	vars    *map[string]any
 	errored func(error)
+	changed func()
 }
 
 func NewSelectFormItem(
@@ -207,6 +417,7 @@ func NewSelectFormItem(
 		vars:     vars,
 		values:   option.Values(),
 		errored:  errored,
+		changed:  func() {},
 	}
 
 	// Dropdown
@@ -216,22 +427,13 @@ func NewSelectFormItem(
 	item.SetDropDownSelectedBackgroundColor(profile.MutedColor())
 	item.SetLabel(option.Label())
 	item.SetSelectedFunc(func(_ int, _ *cview.DropDownOption) {
-		item.Apply()
+		if item.Apply() {
+			item.changed()
+		}
 	})
 
 	for _, value := range item.values {
-		var text string
-		switch value {
-		case nil:
-			text = "<None>"
-		case true:
-			text = "<True>"
-		case false:
-			text = "<False>"
-		default:
-			text = fmt.Sprintf("%v", value)
-		}
-		item.AddOptions(cview.NewDropDownOption(text))
+		item.AddOptions(cview.NewDropDownOption(valueText(value)))
 	}
 
 	// Initial value
@@ -266,3 +468,303 @@ func (item *DialogSelectFormItem) Apply() bool {
 
 	return true
 }
+
+type DialogCheckBoxFormItem struct {
+	*cview.CheckBox
+
+	option  *option.Boolean
+	vars    *map[string]any
+	errored func(error)
+	changed func()
+}
+
+func NewDialogCheckBoxFormItem(
+	option *option.Boolean,
+	vars *map[string]any,
+	errored func(error),
+) (*DialogCheckBoxFormItem, error) {
+	// Item
+	item := &DialogCheckBoxFormItem{
+		CheckBox: cview.NewCheckBox(),
+		option:   option,
+		vars:     vars,
+		errored:  errored,
+		changed:  func() {},
+	}
+
+	// Checkbox
+	item.SetLabel(option.Label())
+	item.SetMessage(option.Help())
+	item.SetChangedFunc(func(_ bool) {
+		if item.Apply() {
+			item.changed()
+		}
+	})
+
+	// Initial value
+	value, err := option.Get(vars)
+	if err != nil {
+		return nil, err
+	}
+	item.SetChecked(value)
+
+	return item, nil
+}
+
+func (item *DialogCheckBoxFormItem) Apply() bool {
+	// Accession
+	if err := item.option.Set(item.vars, item.IsChecked()); err != nil {
+		item.errored(serror.New("accession error").
+			With("label", item.option.Label()).
+			WithErr(err),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// DialogNumericOption describe both integer and number options.
+type DialogNumericOption interface {
+	app.RecipeOption
+	Get(data *map[string]any) (any, error)
+	Set(data *map[string]any, v any) error
+	Validate(v any) error
+}
+
+type DialogNumericFormItem struct {
+	*cview.InputField
+
+	option  DialogNumericOption
+	parse   func(text string) (any, error)
+	vars    *map[string]any
+	profile output.Profile
+	errored func(error)
+	changed func()
+}
+
+func NewDialogNumericFormItem(
+	option DialogNumericOption,
+	parse func(text string) (any, error),
+	vars *map[string]any,
+	errored func(error),
+	profile output.Profile,
+) (*DialogNumericFormItem, error) {
+	// Item
+	item := &DialogNumericFormItem{
+		InputField: cview.NewInputField(),
+		option:     option,
+		parse:      parse,
+		vars:       vars,
+		errored:    errored,
+		profile:    profile,
+		changed:    func() {},
+	}
+
+	// Input field
+	item.SetFieldNoteTextColor(profile.MutedColor())
+	item.SetLabel(option.Label())
+	item.SetChangedFunc(func(_ string) {
+		if item.Apply() {
+			item.changed()
+		}
+	})
+
+	// Initial value
+	value, err := option.Get(vars)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		item.SetText(fmt.Sprintf("%v", value))
+	}
+
+	return item, nil
+}
+
+func (item *DialogNumericFormItem) Apply() bool {
+	// Parsing
+	value, err := item.parse(item.GetText())
+	if err != nil {
+		item.SetFieldNoteTextColor(item.profile.ErrorColor())
+		item.SetFieldNote(err.Error())
+
+		return false
+	}
+
+	// Validation
+	if err := item.option.Validate(value); err != nil {
+		if violation, ok := errors.AsType[*validation.Violation](err); ok {
+			item.SetFieldNoteTextColor(item.profile.ErrorColor())
+			item.SetFieldNote(violation.Error())
+		} else {
+			item.errored(serror.New("validation error").
+				With("label", item.option.Label()).
+				WithErr(err),
+			)
+		}
+		return false
+	}
+
+	item.SetFieldNote(item.option.Help())
+	item.SetFieldNoteTextColor(item.profile.MutedColor())
+
+	// Accession
+	if err := item.option.Set(item.vars, value); err != nil {
+		item.errored(serror.New("accession error").
+			With("label", item.option.Label()).
+			WithErr(err),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// parseInteger parses an integer input text, empty one meaning no value.
+func parseInteger(text string) (any, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid integer")
+	}
+
+	return value, nil
+}
+
+// parseNumber parses a number input text, empty one meaning no value.
+func parseNumber(text string) (any, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, errors.New("invalid number")
+	}
+
+	return value, nil
+}
+
+type DialogMultiSelectFormItem struct {
+	*cview.DropDown
+
+	option   *option.Array
+	values   []any
+	selected []bool
+	vars     *map[string]any
+	profile  output.Profile
+	errored  func(error)
+	changed  func()
+}
+
+func NewDialogMultiSelectFormItem(
+	option *option.Array,
+	vars *map[string]any,
+	errored func(error),
+	profile output.Profile,
+) (*DialogMultiSelectFormItem, error) {
+	// Item
+	item := &DialogMultiSelectFormItem{
+		DropDown: cview.NewDropDown(),
+		option:   option,
+		vars:     vars,
+		values:   option.Values(),
+		errored:  errored,
+		profile:  profile,
+		changed:  func() {},
+	}
+
+	// Dropdown, each selection toggling its value
+	item.SetDropDownTextColor(profile.ReverseColor())
+	item.SetDropDownBackgroundColor(profile.MutedColor())
+	item.SetDropDownSelectedTextColor(profile.EmphasisColor())
+	item.SetDropDownSelectedBackgroundColor(profile.MutedColor())
+	item.SetLabel(option.Label())
+	item.SetSelectedFunc(func(i int, opt *cview.DropDownOption) {
+		item.selected[i] = !item.selected[i]
+		opt.SetText(item.text(i))
+		if item.Apply() {
+			item.changed()
+		}
+	})
+
+	// Initial values
+	values, err := option.Get(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	item.selected = make([]bool, len(item.values))
+	for i, value := range item.values {
+		item.selected[i] = slices.Contains(values, value)
+		item.AddOptions(cview.NewDropDownOption(item.text(i)))
+	}
+	item.SetCurrentOption(0)
+
+	return item, nil
+}
+
+func (item *DialogMultiSelectFormItem) text(i int) string {
+	if item.selected[i] {
+		return "[x] " + valueText(item.values[i])
+	}
+	return "[ ] " + valueText(item.values[i])
+}
+
+func (item *DialogMultiSelectFormItem) Apply() bool {
+	values := []any{}
+	for i, value := range item.values {
+		if item.selected[i] {
+			values = append(values, value)
+		}
+	}
+
+	// Validation, reported along label, for lack of field note
+	if err := item.option.Validate(values); err != nil {
+		if violation, ok := errors.AsType[*validation.Violation](err); ok {
+			item.SetLabelColor(item.profile.ErrorColor())
+			item.SetLabel(item.option.Label() + " (" + violation.Error() + ")")
+		} else {
+			item.errored(serror.New("validation error").
+				With("label", item.option.Label()).
+				WithErr(err),
+			)
+		}
+		return false
+	}
+
+	item.SetLabel(item.option.Label())
+	item.SetLabelColor(item.profile.MutedColor())
+
+	// Accession
+	if err := item.option.Set(item.vars, values); err != nil {
+		item.errored(serror.New("accession error").
+			With("label", item.option.Label()).
+			WithErr(err),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// valueText returns a human-readable text for a select value.
+func valueText(value any) string {
+	switch value {
+	case nil:
+		return "<None>"
+	case true:
+		return "<True>"
+	case false:
+		return "<False>"
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
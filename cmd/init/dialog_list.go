@@ -1,7 +1,11 @@
 package init
 
 import (
+	"cmp"
+	"slices"
+
 	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/recipe"
 	"github.com/manala/manala/internal/output"
 
 	"codeberg.org/tslocum/cview"
@@ -31,17 +35,69 @@ func NewDialogList(title string, profile output.Profile) (*DialogList, *cview.Fl
 
 func (list *DialogList) SetSelectedFunc(handler func(app.Recipe)) {
 	list.List.SetSelectedFunc(func(_ int, item *cview.ListItem) {
-		recipe := item.GetReference().(app.Recipe)
+		// Skip repository and category headers
+		recipe, ok := item.GetReference().(app.Recipe)
+		if !ok {
+			return
+		}
 		handler(recipe)
 	})
 }
 
+// Build list items from recipes, grouped by repository when coming from several ones, then by category.
 func (list *DialogList) Build(recipes []app.Recipe) {
-	for _, recipe := range recipes {
-		item := cview.NewListItem(recipe.Name())
-		item.SetSecondaryText("   " + recipe.Description())
-		item.SetReference(recipe)
+	grouped := slices.ContainsFunc(recipes, func(recipe app.Recipe) bool {
+		return recipe.Repository().URL() != recipes[0].Repository().URL()
+	})
+
+	// Split recipes by repository
+	var repositories [][]app.Recipe
+	for i, recipe := range recipes {
+		if i == 0 || recipe.Repository().URL() != recipes[i-1].Repository().URL() {
+			repositories = append(repositories, nil)
+		}
+		repositories[len(repositories)-1] = append(repositories[len(repositories)-1], recipe)
+	}
+
+	current := -1
+
+	for _, repositoryRecipes := range repositories {
+		// Repository header
+		if grouped {
+			header := cview.NewListItem("── " + cview.Escape(repositoryRecipes[0].Repository().URL()))
+			list.AddItem(header)
+		}
+
+		categories := recipe.Categorize(repositoryRecipes)
+
+		for _, category := range categories {
+			// Category header, as soon as some recipes are categorized
+			if category.Name != "" || len(categories) > 1 {
+				header := cview.NewListItem("·  " + cview.Escape(cmp.Or(category.Name, "Other")))
+				list.AddItem(header)
+			}
+
+			for _, recipe := range category.Recipes {
+				name := recipe.Name()
+				if recipe.Deprecated() != "" {
+					name += " (deprecated)"
+				}
+
+				item := cview.NewListItem(name)
+				item.SetSecondaryText("   " + recipe.Description())
+				item.SetReference(recipe)
+
+				list.AddItem(item)
+
+				if current < 0 {
+					current = list.GetItemCount() - 1
+				}
+			}
+		}
+	}
 
-		list.AddItem(item)
+	// Select first recipe, rather than its headers
+	if current > 0 {
+		list.SetCurrentItem(current)
 	}
 }
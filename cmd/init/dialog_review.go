@@ -0,0 +1,89 @@
+package init
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/internal/output"
+
+	"codeberg.org/tslocum/cview"
+	"github.com/gdamore/tcell/v3/color"
+	"github.com/go-openapi/jsonpointer"
+)
+
+// DialogReview lists every chosen option value, prior to any project creation.
+type DialogReview struct {
+	*cview.Form
+
+	text    *cview.TextView
+	profile output.Profile
+}
+
+func NewDialogReview(title string, profile output.Profile) (*DialogReview, *cview.Flex) {
+	// Text
+	text := cview.NewTextView()
+	text.SetPadding(1, 0, 2, 1)
+	text.SetBackgroundColor(color.Default)
+	text.SetTextColor(profile.Color())
+	text.SetDynamicColors(false)
+
+	// Form, for buttons only
+	review := &DialogReview{
+		Form:    cview.NewForm(),
+		text:    text,
+		profile: profile,
+	}
+	review.SetPadding(0, 1, 2, 1)
+	review.SetButtonsAlign(cview.AlignLeft)
+	review.SetBackgroundColor(color.Default)
+	review.SetButtonTextColor(profile.ReverseColor())
+	review.SetButtonTextColorFocused(profile.Color())
+	review.SetButtonBackgroundColor(profile.MutedColor())
+	review.SetButtonBackgroundColorFocused(profile.MutedColor())
+
+	flex := cview.NewFlex()
+	flex.SetDirection(cview.FlexRow)
+	flex.AddItem(text, 0, 1, false)
+	flex.AddItem(review, 3, 0, true)
+
+	return review, NewDialogPanel(title, flex, profile)
+}
+
+// Build review text from options values, along with back and apply buttons.
+func (review *DialogReview) Build(options []app.RecipeOption, vars *map[string]any, back func(), apply func()) {
+	var lines []string
+	for _, opt := range options {
+		lines = append(lines, fmt.Sprintf("%s: %s", opt.Label(), reviewValue(opt, vars)))
+	}
+
+	review.text.SetText(strings.Join(lines, "\n"))
+
+	review.Clear(true)
+	review.AddButton("Back", back)
+	review.AddButton("Apply", apply)
+	review.SetFocus(1)
+}
+
+// reviewValue renders option value, found at its pointer.
+func reviewValue(opt app.RecipeOption, vars *map[string]any) string {
+	pointer, err := jsonpointer.New(opt.Pointer())
+	if err != nil {
+		return ""
+	}
+
+	value, _, err := pointer.Get(*vars)
+	if err != nil {
+		return ""
+	}
+
+	if values, ok := value.([]any); ok {
+		texts := make([]string, len(values))
+		for i := range values {
+			texts[i] = valueText(values[i])
+		}
+		return strings.Join(texts, ", ")
+	}
+
+	return valueText(value)
+}
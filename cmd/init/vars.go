@@ -0,0 +1,354 @@
+package init
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/recipe/option"
+	"github.com/manala/manala/app/secret"
+	"github.com/manala/manala/internal/errors/serror"
+	"github.com/manala/manala/internal/errors/source"
+	"github.com/manala/manala/internal/errors/std"
+	"github.com/manala/manala/internal/validation"
+	yamlerrors "github.com/manala/manala/internal/yaml/errors"
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"dario.cat/mergo"
+	"github.com/go-openapi/jsonpointer"
+	"github.com/goccy/go-yaml"
+)
+
+// Vars gathers recipe vars supplied from a file, and from "path.to.key=value" assignments,
+// to be applied in this order, on top of recipe vars.
+type Vars struct {
+	File        string
+	Assignments []string
+}
+
+// Apply supplied vars on top of recipe ones, through recipe options when targeted.
+func (v Vars) Apply(recipe app.Recipe, vars *map[string]any) error {
+	if v.File != "" {
+		if err := v.applyFile(vars); err != nil {
+			return err
+		}
+	}
+
+	for _, assignment := range v.Assignments {
+		if err := v.applyAssignment(recipe, vars, assignment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v Vars) applyFile(vars *map[string]any) error {
+	content, err := os.ReadFile(v.File)
+	if err != nil {
+		return serror.New("unable to read vars file").
+			With("file", v.File).
+			WithErr(std.From(err))
+	}
+
+	// Prepare source error origin
+	origin := source.Origin{
+		File:     v.File,
+		Source:   string(content),
+		Language: "yaml",
+	}
+
+	node, err := yamlparser.Parse(content)
+	if err != nil {
+		return serror.New("unable to parse vars file").
+			WithErr(source.From(err, origin))
+	}
+
+	var fileVars map[string]any
+	if err := yaml.NodeToValue(node, &fileVars); err != nil {
+		return serror.New("unable to decode vars file").
+			WithErr(source.From(yamlerrors.From(err), origin))
+	}
+
+	_ = mergo.Merge(vars, fileVars, mergo.WithOverride)
+
+	return nil
+}
+
+// ValidateOptions ensures assignments all target recipe options.
+func (v Vars) ValidateOptions(recipe app.Recipe) error {
+	for _, assignment := range v.Assignments {
+		pointer, _, err := parseAssignment(assignment)
+		if err != nil {
+			return err
+		}
+
+		if !slices.ContainsFunc(recipe.Options(), func(opt app.RecipeOption) bool {
+			return opt.Pointer() == pointer
+		}) {
+			return serror.New("unknown recipe option var").
+				With("var", assignment)
+		}
+	}
+
+	return nil
+}
+
+// parseAssignment returns "path.to.key=value" assignment json pointer and value.
+func parseAssignment(assignment string) (string, string, error) {
+	path, value, ok := strings.Cut(assignment, "=")
+	if !ok || path == "" {
+		return "", "", serror.New("invalid var assignment, expected path.to.key=value").
+			With("var", assignment)
+	}
+
+	pointer := yamlpath.ToJSONPointer("$." + path)
+	if pointer == "" {
+		return "", "", serror.New("invalid var path").
+			With("var", assignment)
+	}
+
+	return pointer, value, nil
+}
+
+func (v Vars) applyAssignment(recipe app.Recipe, vars *map[string]any, assignment string) error {
+	pointer, value, err := parseAssignment(assignment)
+	if err != nil {
+		return err
+	}
+
+	// Through recipe option
+	for _, opt := range recipe.Options() {
+		switch opt := opt.(type) {
+		case *option.String:
+			if opt.Pointer() != pointer {
+				continue
+			}
+
+			return opt.Set(vars, value)
+		case *option.Enum:
+			if opt.Pointer() != pointer {
+				continue
+			}
+
+			for _, enumValue := range opt.Values() {
+				if enumValue == nil && (value == "" || value == "null" || value == "~") ||
+					enumValue != nil && fmt.Sprint(enumValue) == value {
+					return opt.Set(vars, enumValue)
+				}
+			}
+
+			return serror.New("invalid recipe option value").
+				With("label", opt.Label(), "var", assignment).
+				WithErr(opt.Validate(value))
+		case *option.Boolean:
+			if opt.Pointer() != pointer {
+				continue
+			}
+
+			boolean, err := strconv.ParseBool(value)
+			if err == nil {
+				if err = opt.Validate(boolean); err == nil {
+					return opt.Set(vars, boolean)
+				}
+			} else {
+				err = errors.New("invalid boolean")
+			}
+
+			return serror.New("invalid recipe option value").
+				With("label", opt.Label(), "var", assignment).
+				WithErr(err)
+		case *option.Integer:
+			if opt.Pointer() != pointer {
+				continue
+			}
+
+			integer, err := parseInteger(value)
+			if err == nil {
+				if err = opt.Validate(integer); err == nil {
+					return opt.Set(vars, integer)
+				}
+			}
+
+			return serror.New("invalid recipe option value").
+				With("label", opt.Label(), "var", assignment).
+				WithErr(err)
+		case *option.Number:
+			if opt.Pointer() != pointer {
+				continue
+			}
+
+			number, err := parseNumber(value)
+			if err == nil {
+				if err = opt.Validate(number); err == nil {
+					return opt.Set(vars, number)
+				}
+			}
+
+			return serror.New("invalid recipe option value").
+				With("label", opt.Label(), "var", assignment).
+				WithErr(err)
+		case *option.Array:
+			if opt.Pointer() != pointer {
+				continue
+			}
+
+			// Yaml sequence ([intl, redis]), its items matched against option values
+			var decoded any
+			if err := yaml.Unmarshal([]byte(value), &decoded); err != nil {
+				decoded = value
+			}
+
+			items, ok := decoded.([]any)
+			if !ok {
+				if decoded != nil {
+					return serror.New("invalid recipe option value").
+						With("label", opt.Label(), "var", assignment).
+						WithErr(errors.New("invalid array"))
+				}
+
+				items = []any{}
+			}
+
+			values := make([]any, len(items))
+			for i, item := range items {
+				values[i] = item
+				for _, arrayValue := range opt.Values() {
+					if fmt.Sprint(arrayValue) == fmt.Sprint(item) {
+						values[i] = arrayValue
+
+						break
+					}
+				}
+			}
+
+			if err := opt.Validate(values); err != nil {
+				return serror.New("invalid recipe option value").
+					With("label", opt.Label(), "var", assignment).
+					WithErr(err)
+			}
+
+			return opt.Set(vars, values)
+		}
+	}
+
+	// Decode value as yaml scalar, so that "true" or "123" get typed
+	var decoded any
+	if err := yaml.Unmarshal([]byte(value), &decoded); err != nil {
+		decoded = value
+	}
+
+	// Walk through vars, creating missing intermediate mappings
+	current := *vars
+	tokens := yamlpath.SplitJSONPointer(pointer)
+	for _, token := range tokens[:len(tokens)-1] {
+		if _, ok := current[token]; !ok {
+			current[token] = map[string]any{}
+		}
+
+		next, ok := current[token].(map[string]any)
+		if !ok {
+			return serror.New("unable to assign var").
+				With("var", assignment, "key", token)
+		}
+
+		current = next
+	}
+
+	current[tokens[len(tokens)-1]] = decoded
+
+	return nil
+}
+
+// ValidateVars ensures vars are valid against recipe active options, just like a dialog would,
+// and eventually against recipe schema.
+func ValidateVars(recipe app.Recipe, vars *map[string]any) error {
+	for _, opt := range recipe.Options() {
+		// Skip inactive options, just like a dialog would hide them
+		active, err := opt.Active(vars)
+		if err != nil {
+			return serror.New("invalid recipe option condition").
+				With("label", opt.Label()).
+				WithErr(err)
+		}
+
+		if !active {
+			continue
+		}
+
+		// Skip secrets, only resolved at sync time
+		if pointer, err := jsonpointer.New(opt.Pointer()); err == nil {
+			value, _, _ := pointer.Get(*vars)
+			if _, ok := value.(*secret.Secret); ok {
+				continue
+			}
+		}
+
+		switch opt := opt.(type) {
+		case *option.String:
+			var value string
+			if value, err = opt.Get(vars); err == nil {
+				if err = opt.Validate(value); err == nil {
+					err = opt.Set(vars, value)
+				}
+			}
+		case *option.Enum:
+			var value any
+			if value, err = opt.Get(vars); err == nil {
+				err = opt.Validate(value)
+			}
+		case *option.Boolean:
+			var value bool
+			if value, err = opt.Get(vars); err == nil {
+				err = opt.Validate(value)
+			}
+		case *option.Integer:
+			var value any
+			if value, err = opt.Get(vars); err == nil {
+				err = opt.Validate(value)
+			}
+		case *option.Number:
+			var value any
+			if value, err = opt.Get(vars); err == nil {
+				err = opt.Validate(value)
+			}
+		case *option.Array:
+			var value []any
+			if value, err = opt.Get(vars); err == nil {
+				err = opt.Validate(value)
+			}
+		default:
+			return serror.New("unknown recipe option").
+				With("label", opt.Label())
+		}
+
+		if err != nil {
+			return serror.New("invalid recipe option value").
+				With("label", opt.Label()).
+				WithErr(err)
+		}
+	}
+
+	validator, err := validation.NewValidator(recipe.Schema())
+	if err != nil {
+		return err
+	}
+
+	// Secrets only being required, as their values are resolved at sync time
+	if err := validator.Validate(secret.References(*vars), validation.WithSkippedValues(secret.Pointers(*vars)...)); err != nil {
+		if violations, ok := errors.AsType[validation.Violations](err); ok {
+			return serror.New("invalid project vars").
+				WithErr(violations)
+		}
+
+		return serror.New("unable to validate project vars").
+			WithErr(err)
+	}
+
+	return nil
+}
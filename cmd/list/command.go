@@ -1,10 +1,16 @@
 package list
 
 import (
+	"cmp"
 	"context"
+	netURL "net/url"
+	"strings"
 
 	"github.com/manala/manala/app"
 	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/app/recipe"
+	"github.com/manala/manala/app/repository"
+	"github.com/manala/manala/app/repository/getter"
 	"github.com/manala/manala/internal/log"
 	"github.com/manala/manala/internal/output"
 
@@ -16,6 +22,8 @@ func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
 	var (
 		repositoryURL string
 		repositoryRef string
+		versions      bool
+		search        string
 	)
 
 	// Command
@@ -27,52 +35,169 @@ func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
 		Short:             "List recipes",
 		Long: `List (manala list) will list recipes available on repository.
 
-Example: manala list -> resulting in a recipes list display`,
+When no repository is explicitly given, recipes of all configured repository aliases
+are listed as well, grouped by repository.
+
+Example: manala list -> resulting in a recipes list display
+Example: manala list --search php -> resulting in a display of recipes matching "php"
+Example: manala list --versions -> resulting in a repository versions list display`,
 		RunE: func(command *cobra.Command, _ []string) error {
 			// Context
 			ctx := command.Context()
 			ctx = app.WithRepositoryURL(ctx, repositoryURL)
 			ctx = app.WithRepositoryRef(ctx, repositoryRef)
 
-			return run(ctx, log, api, out)
+			if versions {
+				return runVersions(ctx, log, api, out)
+			}
+
+			return run(ctx, log, api, out, search)
 		},
 	}
 
 	// Set flags
 	command.Flags().StringVarP(&repositoryURL, "repository", "o", "", "use repository")
 	command.Flags().StringVar(&repositoryRef, "ref", "", "use repository ref")
+	command.Flags().BoolVar(&versions, "versions", false, "list repository versions")
+	command.Flags().StringVarP(&search, "search", "s", "", "search recipes by name or description")
 
 	return command
 }
 
-func run(ctx context.Context, log *log.Log, api *api.API, out output.Output) error {
-	var (
-		repository app.Repository
-		recipes    []app.Recipe
-		err        error
-	)
-
+func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, search string) error {
 	// Api
 	repositoryLoader := api.NewRepositoryLoader(ctx)
 	recipeLoader := api.NewRecipeLoader(ctx)
 
+	urls := api.SearchRepositoryURLs(ctx)
+
+	var (
+		loaded  int
+		loadErr error
+	)
+
+	for _, url := range urls {
+		// Load repository recipes, unreachable repositories left aside
+		repository, recipes, err := loadRecipes(ctx, log, repositoryLoader, recipeLoader, url)
+		if err != nil {
+			// Sole repository
+			if len(urls) == 1 {
+				return err
+			}
+
+			log.Warn("unable to load repository recipes", "url", cmp.Or(url, "default"), "error", err)
+			if loadErr == nil {
+				loadErr = err
+			}
+
+			continue
+		}
+
+		loaded++
+
+		recipes = recipe.Search(recipes, search)
+		if len(recipes) == 0 {
+			continue
+		}
+
+		indent := ""
+
+		// Repository header, when searching across several ones, or described by its manifest
+		manifest := repository.Manifest()
+		if len(urls) > 1 || (manifest != nil && manifest.Name() != "") {
+			header := out.MutedStyle().Render("[" + repository.URL() + "]")
+			if manifest != nil && manifest.Name() != "" {
+				header += " " + out.Style().Render(manifest.Name())
+				if manifest.Description() != "" {
+					header += " " + out.MutedStyle().Render(manifest.Description())
+				}
+			}
+			out.Println(header)
+			indent = "  "
+		}
+
+		categories := recipe.Categorize(recipes)
+
+		for _, category := range categories {
+			categoryIndent := indent
+
+			// Category header, as soon as some recipes are categorized
+			if category.Name != "" || len(categories) > 1 {
+				out.Println(indent + out.Style().Render(cmp.Or(category.Name, "Other")+":"))
+				categoryIndent += "  "
+			}
+
+			for _, recipe := range category.Recipes {
+				line := categoryIndent + out.Style().Render(recipe.Name())
+				if deprecated := recipe.Deprecated(); deprecated != "" {
+					if replacedBy := recipe.ReplacedBy(); replacedBy != "" {
+						deprecated += ", replaced by " + replacedBy
+					}
+					line += " " + out.WarnStyle().Render("(deprecated: "+deprecated+")")
+				}
+				out.Println(line)
+				out.Println(categoryIndent + "  " + out.MutedStyle().Render(recipe.Description()))
+			}
+		}
+	}
+
+	if loaded == 0 {
+		return loadErr
+	}
+
+	return nil
+}
+
+// loadRecipes loads a repository, along with all its recipes.
+func loadRecipes(ctx context.Context, log *log.Log, repositoryLoader *repository.Loader, recipeLoader *recipe.Loader, url string) (app.Repository, []app.Recipe, error) {
 	// Load repository
 	log.Info("loading repository…")
-	repository, err = repositoryLoader.Load("")
+	repository, err := repositoryLoader.Load(ctx, url)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// Load recipes
 	log.Info("loading recipes…")
-	recipes, err = recipeLoader.LoadAll(repository)
+	recipes, err := recipeLoader.LoadAll(ctx, repository)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return repository, recipes, nil
+}
+
+func runVersions(ctx context.Context, log *log.Log, api *api.API, out output.Output) error {
+	// Api
+	repositoryVersionsLister := api.NewRepositoryVersionsLister()
+
+	// Repository url, aliases resolved, so that versions could be listed straight from remote
+	url, err := api.ContextRepositoryURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Current ref, or version constraint
+	_, rawQuery, _ := strings.Cut(url, "?")
+	values, _ := netURL.ParseQuery(rawQuery)
+	ref := values.Get("ref")
+	constraint, isConstraint := getter.VersionConstraint(ref)
+
+	// List versions
+	log.Info("listing repository versions…")
+	versions, err := repositoryVersionsLister.List(ctx, url)
 	if err != nil {
 		return err
 	}
 
-	for _, recipe := range recipes {
-		out.Println(out.Style().Render(recipe.Name()))
-		out.Println("  " + out.MutedStyle().Render(recipe.Description()))
+	current := false
+	for _, version := range versions {
+		line := out.Style().Render(version.Original())
+		if !current && ((isConstraint && constraint.Check(version)) || version.Original() == ref) {
+			line += " " + out.MutedStyle().Render("(current)")
+			current = true
+		}
+		out.Println(line)
 	}
 
 	return nil
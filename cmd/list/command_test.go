@@ -2,6 +2,8 @@ package list_test
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"testing"
 
@@ -64,6 +66,183 @@ func (s *CommandSuite) TestRepositoryArg() {
 	`, stderr)
 }
 
+func (s *CommandSuite) TestRepositoryManifest() {
+	repositoryURL := filepath.FromSlash("testdata/TestRepositoryManifest/repository")
+
+	stdout, _, err := s.execute(repositoryURL)
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), `
+		[%[1]s] Acme Acme recipes
+		  Node:
+		    foo (deprecated: Use bar instead)
+		      Foo
+		  Php:
+		    bar
+		      Bar
+		  Other:
+		    baz
+		      Baz
+	`, stdout, repositoryURL)
+}
+
+func (s *CommandSuite) TestDeprecated() {
+	repositoryURL := filepath.FromSlash("testdata/TestDeprecated/repository")
+
+	stdout, stderr, err := s.execute(repositoryURL)
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), `
+		bar
+		  Bar
+		foo (deprecated: Use bar instead, replaced by bar)
+		  Foo
+	`, stdout)
+	heredoc.Equal(s.T(), `
+		 ● loading repository…
+		 ● loading recipes…
+	`, stderr)
+}
+
+func (s *CommandSuite) TestRepositories() {
+	repositoryURL := filepath.FromSlash("testdata/TestRepositories/repository")
+	aliasURL := filepath.FromSlash("testdata/TestRepositories/alias")
+
+	stdout, stderr, err := s.executeWithOptions(
+		[]api.Option{
+			api.WithDefaultRepositoryURL(repositoryURL),
+			api.WithRepositoryAliases(map[string]string{"alias": aliasURL}),
+		},
+	)
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), `
+		[%[1]s]
+		  bar
+		    Bar
+		  foo
+		    Foo php
+		[alias]
+		  node
+		    Node
+		  php
+		    Php
+	`, stdout, repositoryURL)
+	heredoc.Equal(s.T(), `
+		 ● loading repository…
+		 ● loading recipes…
+		 ● loading repository…
+		 ● loading recipes…
+	`, stderr)
+
+	s.Run("Repository", func() {
+		stdout, _, err := s.executeWithOptions(
+			[]api.Option{
+				api.WithDefaultRepositoryURL(repositoryURL),
+				api.WithRepositoryAliases(map[string]string{"alias": aliasURL}),
+			},
+			"--repository", "alias",
+		)
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			node
+			  Node
+			php
+			  Php
+		`, stdout)
+	})
+}
+
+func (s *CommandSuite) TestRepositoriesUnreachable() {
+	repositoryURL := filepath.FromSlash("testdata/TestRepositories/repository")
+	aliasURL := filepath.FromSlash("testdata/TestRepositories/unreachable")
+
+	stdout, stderr, err := s.executeWithOptions(
+		[]api.Option{
+			api.WithDefaultRepositoryURL(repositoryURL),
+			api.WithRepositoryAliases(map[string]string{"alias": aliasURL}),
+		},
+	)
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), `
+		[%[1]s]
+		  bar
+		    Bar
+		  foo
+		    Foo php
+	`, stdout, repositoryURL)
+	s.Contains(stderr.String(), "unable to load repository")
+
+	s.Run("All", func() {
+		_, _, err := s.executeWithOptions(
+			[]api.Option{
+				api.WithDefaultRepositoryURL(aliasURL),
+				api.WithRepositoryAliases(map[string]string{"alias": aliasURL}),
+			},
+		)
+
+		expectation.ExpectError(s.T(), errors.Expectation{
+			Type:  &app.NotFoundRepositoryError{},
+			Attrs: [][2]any{{"url", aliasURL}},
+		}, err)
+	})
+}
+
+func (s *CommandSuite) TestSearch() {
+	repositoryURL := filepath.FromSlash("testdata/TestRepositories/repository")
+	aliasURL := filepath.FromSlash("testdata/TestRepositories/alias")
+
+	tests := []struct {
+		test           string
+		search         string
+		expectedStdout string
+	}{
+		{
+			test:   "Name",
+			search: "bar",
+			expectedStdout: heredoc.Doc(`
+				[%[1]s]
+				  bar
+				    Bar
+			`, repositoryURL),
+		},
+		{
+			test:   "NameOrDescription",
+			search: "PHP",
+			expectedStdout: heredoc.Doc(`
+				[%[1]s]
+				  foo
+				    Foo php
+				[alias]
+				  php
+				    Php
+			`, repositoryURL),
+		},
+		{
+			test:           "None",
+			search:         "qux",
+			expectedStdout: "",
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			stdout, _, err := s.executeWithOptions(
+				[]api.Option{
+					api.WithDefaultRepositoryURL(repositoryURL),
+					api.WithRepositoryAliases(map[string]string{"alias": aliasURL}),
+				},
+				"--search", test.search,
+			)
+
+			s.Require().NoError(err)
+			s.Equal(test.expectedStdout, stdout.String())
+		})
+	}
+}
+
 func (s *CommandSuite) TestRepositoryErrors() {
 	dir := filepath.FromSlash("testdata/TestRepositoryErrors")
 
@@ -158,7 +337,42 @@ func (s *CommandSuite) TestRecipeErrors() {
 	}
 }
 
+func (s *CommandSuite) TestVersions() {
+	server := httptest.NewServer(http.StripPrefix("/repository.git/",
+		http.FileServer(http.Dir(filepath.FromSlash("testdata/TestVersions/repository.git"))),
+	))
+	defer server.Close()
+
+	stdout, stderr, err := s.execute("",
+		"--repository", server.URL+"/repository.git",
+		"--ref", "^2.1",
+		"--versions",
+	)
+
+	s.Require().NoError(err)
+	heredoc.Equal(s.T(), `
+		v3.0.0
+		v2.2.0-beta
+		v2.1.1 (current)
+		v2.1.0
+		v2.0.0
+		v1.0.0
+	`, stdout)
+	heredoc.Equal(s.T(), `
+		 ● listing repository versions…
+	`, stderr)
+}
+
 func (s *CommandSuite) execute(defaultRepositoryURL string, args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
+	return s.executeWithOptions(
+		[]api.Option{
+			api.WithDefaultRepositoryURL(defaultRepositoryURL),
+		},
+		args...,
+	)
+}
+
+func (s *CommandSuite) executeWithOptions(opts []api.Option, args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
 	out := &bytes.Buffer{}
 	err := &bytes.Buffer{}
 
@@ -169,8 +383,8 @@ func (s *CommandSuite) execute(defaultRepositoryURL string, args ...string) (*by
 		logger,
 		api.New(
 			logger,
-			cache.New(""),
-			api.WithDefaultRepositoryURL(defaultRepositoryURL),
+			cache.New(s.T().TempDir()),
+			opts...,
 		),
 		output.NewDetached(out),
 	)
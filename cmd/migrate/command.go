@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Command
+	command := &cobra.Command{
+		Use:               "migrate [dir]",
+		Args:              cobra.MaximumNArgs(1),
+		DisableAutoGenTag: true,
+		Short:             "Migrate project off its deprecated recipe",
+		Long: `Migrate (manala migrate) will rewrite project manifest (.manala.yaml) recipe,
+replacing a deprecated one by its replacement, as declared in its manifest.
+
+Example: manala migrate -> resulting in a migration in a project dir (default to the
+current directory)`,
+		RunE: func(command *cobra.Command, args []string) error {
+			// Args
+			dir := filepath.Clean(append(args, "")[0])
+
+			return run(command.Context(), log, api, out, dir)
+		},
+	}
+
+	return command
+}
+
+func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir string) error {
+	// Api
+	repositoryLoader := api.NewRepositoryLoader(ctx)
+	recipeLoader := api.NewRecipeLoader(ctx)
+	projectLoader := api.NewProjectLoader(repositoryLoader, recipeLoader)
+	projectMigrator := api.NewProjectMigrator(repositoryLoader, recipeLoader)
+
+	// Load project
+	log.Info("loading project…")
+	project, err := projectLoader.Load(ctx, dir)
+	if err != nil {
+		return err
+	}
+
+	// Migrate project
+	log.Info("migrating project…")
+	recipe, err := projectMigrator.Migrate(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	if recipe == nil {
+		out.Println(out.Style().Render("project recipe is up to date"))
+
+		return nil
+	}
+
+	out.Println(out.Style().Render("project successfully migrated to recipe " + recipe.Name()))
+
+	return nil
+}
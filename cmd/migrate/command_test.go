@@ -0,0 +1,98 @@
+package migrate_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manala/manala/app/api"
+	cmdMigrate "github.com/manala/manala/cmd/migrate"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandSuite struct{ suite.Suite }
+
+func TestCommandSuite(t *testing.T) {
+	suite.Run(t, new(CommandSuite))
+}
+
+func (s *CommandSuite) TestMigrate() {
+	repositoryURL := filepath.FromSlash("testdata/TestMigrate/repository")
+
+	projectDir := s.T().TempDir()
+	projectFile := filepath.Join(projectDir, ".manala.yaml")
+
+	s.Require().NoError(os.WriteFile(projectFile, []byte(heredoc.Doc(`
+		manala:
+		  recipe: foo
+	`)), 0o644))
+
+	s.Run("Migrated", func() {
+		stdout, stderr, err := s.execute(repositoryURL,
+			projectDir,
+		)
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			project successfully migrated to recipe bar
+		`, stdout)
+		heredoc.Equal(s.T(), `
+			 ● loading project…
+			 ▲ deprecated recipe                recipe=foo reason=Use bar instead replaced_by=bar
+			 ● migrating project…
+		`, stderr)
+
+		content, _ := os.ReadFile(projectFile)
+		heredoc.Equal(s.T(), `
+			manala:
+			  recipe: bar
+		`, content)
+	})
+
+	s.Run("UpToDate", func() {
+		stdout, stderr, err := s.execute(repositoryURL,
+			projectDir,
+		)
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			project recipe is up to date
+		`, stdout)
+		heredoc.Equal(s.T(), `
+			 ● loading project…
+			 ● migrating project…
+		`, stderr)
+	})
+}
+
+func (s *CommandSuite) execute(defaultRepositoryURL string, args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
+	out := &bytes.Buffer{}
+	err := &bytes.Buffer{}
+
+	logger := log.New(output.NewDetached(err))
+	logger.Verbose(1)
+
+	command := cmdMigrate.NewCommand(
+		logger,
+		api.New(
+			logger,
+			cache.New(""),
+			api.WithDefaultRepositoryURL(defaultRepositoryURL),
+		),
+		output.NewDetached(out),
+	)
+
+	command.SilenceErrors = true
+	command.SilenceUsage = true
+	command.SetOut(out)
+	command.SetErr(err)
+	command.SetArgs(append([]string{}, args...))
+
+	return out, err, command.Execute()
+}
@@ -0,0 +1,29 @@
+package recipe
+
+import (
+	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Command
+	command := &cobra.Command{
+		Use:               "recipe",
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		Short:             "Manage recipes",
+		Long: `Recipe (manala recipe) will manage repository recipes, allowing to document them.
+
+Example: manala recipe docs php -> resulting in a php recipe vars reference display`,
+	}
+
+	// Sub commands
+	command.AddCommand(
+		newDocsCommand(log, api, out),
+	)
+
+	return command
+}
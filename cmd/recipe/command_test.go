@@ -0,0 +1,122 @@
+package recipe_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/manala/manala/app/api"
+	cmdRecipe "github.com/manala/manala/cmd/recipe"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandSuite struct{ suite.Suite }
+
+func TestCommandSuite(t *testing.T) {
+	suite.Run(t, new(CommandSuite))
+}
+
+func (s *CommandSuite) TestDocs() {
+	repositoryURL := filepath.FromSlash("testdata/TestDocs/repository")
+
+	fooDoc := "\n" +
+		"## foo\n" +
+		"\n" +
+		"Foo\n" +
+		"\n" +
+		"### Variables\n" +
+		"\n" +
+		"| Variable | Type | Default | Option | Description |\n" +
+		"|----------|------|---------|--------|-------------|\n" +
+		"| `debug` | boolean | `false` | Debug |  |\n" +
+		"| `name` |  | `null` |  |  |\n" +
+		"| `php.extensions` | array | `[]` |  | Php extensions |\n" +
+		"| `php.memory_limit` | string | `\"128M\"` |  |  |\n" +
+		"| `php.version` | one of `\"8.3\"`, `\"8.4\"` | `\"8.4\"` | Php version — Runtime \\| version | Php version |\n" +
+		"| `port` | integer | `8080` |  | Http port |\n"
+
+	barDoc := `
+		## bar
+
+		Bar
+
+		**Deprecated**: Use foo instead
+
+		### Variables
+
+		No variables.
+	`
+
+	s.Run("Recipe", func() {
+		stdout, stderr, err := s.execute(repositoryURL, "docs", "foo")
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), fooDoc, stdout)
+		heredoc.Equal(s.T(), `
+			 ● loading repository…
+			 ● loading recipe…
+		`, stderr)
+	})
+
+	s.Run("Recipes", func() {
+		stdout, stderr, err := s.execute("", "docs",
+			"--repository", repositoryURL,
+		)
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), heredoc.Doc(barDoc)+"\n"+heredoc.Doc(fooDoc), stdout)
+		heredoc.Equal(s.T(), `
+			 ● loading repository…
+			 ● loading recipes…
+		`, stderr)
+	})
+
+	s.Run("Dir", func() {
+		dir := filepath.Join(s.T().TempDir(), "recipes")
+
+		stdout, stderr, err := s.execute(repositoryURL, "docs",
+			"--dir", dir,
+		)
+
+		s.Require().NoError(err)
+		s.Empty(stdout)
+		heredoc.Equal(s.T(), `
+			 ● loading repository…
+			 ● loading recipes…
+			 ● generating recipes docs…
+		`, stderr)
+		heredoc.EqualFile(s.T(), barDoc, filepath.Join(dir, "bar.md"))
+		heredoc.EqualFile(s.T(), fooDoc, filepath.Join(dir, "foo.md"))
+	})
+}
+
+func (s *CommandSuite) execute(defaultRepositoryURL string, args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
+	out := &bytes.Buffer{}
+	err := &bytes.Buffer{}
+
+	logger := log.New(output.NewDetached(err))
+	logger.Verbose(1)
+
+	command := cmdRecipe.NewCommand(
+		logger,
+		api.New(
+			logger,
+			cache.New(s.T().TempDir()),
+			api.WithDefaultRepositoryURL(defaultRepositoryURL),
+		),
+		output.NewDetached(out),
+	)
+
+	command.SilenceErrors = true
+	command.SilenceUsage = true
+	command.SetOut(out)
+	command.SetErr(err)
+	command.SetArgs(append([]string{}, args...))
+
+	return out, err, command.Execute()
+}
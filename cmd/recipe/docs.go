@@ -0,0 +1,117 @@
+package recipe
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/manala/manala/app"
+	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/app/recipe/doc"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newDocsCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Flags
+	var (
+		repositoryURL string
+		repositoryRef string
+		dir           string
+	)
+
+	// Command
+	command := &cobra.Command{
+		Use:               "docs [recipe]",
+		Args:              cobra.MaximumNArgs(1),
+		DisableAutoGenTag: true,
+		Short:             "Document recipes vars",
+		Long: `Docs (manala recipe docs) will document recipes vars, as a markdown reference table,
+from their schema descriptions, options labels and help, and default values.
+
+When no recipe is given, all repository recipes are documented.
+
+Example: manala recipe docs php -> resulting in a php recipe vars reference display
+Example: manala recipe docs --dir docs/recipes -> resulting in a markdown page for each repository recipe`,
+		RunE: func(command *cobra.Command, args []string) error {
+			// Args
+			name := append(args, "")[0]
+
+			// Context
+			ctx := command.Context()
+			ctx = app.WithRepositoryURL(ctx, repositoryURL)
+			ctx = app.WithRepositoryRef(ctx, repositoryRef)
+			if name != "" {
+				ctx = app.WithRecipeName(ctx, name)
+			}
+
+			if dir != "" {
+				dir = filepath.Clean(dir)
+			}
+
+			return runDocs(ctx, log, api, out, name, dir)
+		},
+	}
+
+	// Set flags
+	command.Flags().StringVarP(&repositoryURL, "repository", "o", "", "use repository")
+	command.Flags().StringVar(&repositoryRef, "ref", "", "use repository ref")
+	command.Flags().StringVar(&dir, "dir", "", "write a markdown page for each recipe into directory")
+
+	return command
+}
+
+func runDocs(ctx context.Context, log *log.Log, api *api.API, out output.Output, name string, dir string) error {
+	// Api
+	repositoryLoader := api.NewRepositoryLoader(ctx)
+	recipeLoader := api.NewRecipeLoader(ctx)
+
+	// Load repository
+	log.Info("loading repository…")
+	repository, err := repositoryLoader.Load(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var recipes []app.Recipe
+
+	if name != "" {
+		// Load recipe
+		log.Info("loading recipe…")
+		recipe, err := recipeLoader.Load(ctx, repository, name)
+		if err != nil {
+			return err
+		}
+
+		recipes = append(recipes, recipe)
+	} else {
+		// Load recipes
+		log.Info("loading recipes…")
+		if recipes, err = recipeLoader.LoadAll(ctx, repository); err != nil {
+			return err
+		}
+	}
+
+	// Static site pages
+	if dir != "" {
+		log.Info("generating recipes docs…")
+		return doc.GenMarkdownTree(recipes, dir)
+	}
+
+	for i, recipe := range recipes {
+		if i > 0 {
+			out.Println()
+		}
+
+		var b strings.Builder
+		if err := doc.GenMarkdown(recipe, &b); err != nil {
+			return err
+		}
+
+		out.Print(b.String())
+	}
+
+	return nil
+}
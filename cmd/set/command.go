@@ -0,0 +1,92 @@
+package set
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand(log *log.Log, api *api.API, out output.Output) *cobra.Command {
+	// Command
+	command := &cobra.Command{
+		Use:               "set key value [dir]",
+		Args:              cobra.RangeArgs(2, 3),
+		DisableAutoGenTag: true,
+		Short:             "Set project manifest value",
+		Long: `Set (manala set) will set a project manifest (.manala.yaml) value,
+at the given dotted key path, preserving manifest comments and layout.
+Value is decoded as yaml, unless replacing a string, and the resulting manifest
+validated against recipe.
+
+Example: manala set php.version 8.4 -> resulting in "php.version" value set in a project dir (default to the
+current directory)
+Example: manala set manala.recipe php -> resulting in a project recipe switch`,
+		RunE: func(command *cobra.Command, args []string) error {
+			// Args
+			key, value := args[0], args[1]
+			dir := filepath.Clean(append(args[2:], "")[0])
+
+			return run(command.Context(), log, api, out, dir, key, value)
+		},
+	}
+
+	return command
+}
+
+func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir string, key string, value string) error {
+	// Api
+	repositoryLoader := api.NewRepositoryLoader(ctx)
+	recipeLoader := api.NewRecipeLoader(ctx)
+	projectLoader := api.NewProjectLoader(repositoryLoader, recipeLoader)
+	projectEditor := api.NewProjectEditor(dir)
+
+	// Load project manifest
+	log.Info("loading project manifest…")
+	if err := projectEditor.Load(); err != nil {
+		return err
+	}
+
+	pointer := yamlpath.ToJSONPointer("$." + key)
+
+	// Decode value as yaml, so that "true" or "123" get typed, unless replacing a string
+	var decoded any
+	if current, _, _ := projectEditor.Get(pointer); isString(current) {
+		decoded = value
+	} else if err := yaml.Unmarshal([]byte(value), &decoded); err != nil {
+		decoded = value
+	}
+
+	if err := projectEditor.Set(pointer, decoded); err != nil {
+		return err
+	}
+
+	if err := projectEditor.Save(); err != nil {
+		return err
+	}
+
+	// Load project, ensuring manifest is still valid, otherwise revert it
+	log.Info("loading project…")
+	if _, err := projectLoader.Load(ctx, dir); err != nil {
+		if err := projectEditor.Revert(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	out.Println(out.Style().Render("project manifest successfully updated"))
+
+	return nil
+}
+
+func isString(value any) bool {
+	_, ok := value.(string)
+	return ok
+}
@@ -0,0 +1,120 @@
+package set_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manala/manala/app/api"
+	cmdSet "github.com/manala/manala/cmd/set"
+	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror/serrortest"
+	"github.com/manala/manala/internal/errors/source/sourcetest"
+	"github.com/manala/manala/internal/log"
+	"github.com/manala/manala/internal/output"
+	"github.com/manala/manala/internal/testing/expectation"
+	"github.com/manala/manala/internal/testing/heredoc"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandSuite struct{ suite.Suite }
+
+func TestCommandSuite(t *testing.T) {
+	suite.Run(t, new(CommandSuite))
+}
+
+func (s *CommandSuite) TestSet() {
+	repositoryURL, _ := filepath.Abs(filepath.FromSlash("testdata/TestSet/repository"))
+
+	projectDir := s.T().TempDir()
+	projectFile := filepath.Join(projectDir, ".manala.yaml")
+
+	s.Require().NoError(os.WriteFile(projectFile, []byte(heredoc.Doc(`
+		manala:
+		  recipe: recipe
+		  repository: %[1]s
+
+		php:
+		  version: "8.3" # Php version
+	`, repositoryURL)), 0o644))
+
+	s.Run("Set", func() {
+		stdout, stderr, err := s.execute("php.version", "8.4", projectDir)
+
+		s.Require().NoError(err)
+		heredoc.Equal(s.T(), `
+			project manifest successfully updated
+		`, stdout)
+		heredoc.Equal(s.T(), `
+			 ● loading project manifest…
+			 ● loading project…
+		`, stderr)
+
+		heredoc.EqualFile(s.T(), `
+			manala:
+			  recipe: recipe
+			  repository: %[1]s
+
+			php:
+			  version: "8.4" # Php version
+		`, projectFile, repositoryURL)
+	})
+
+	s.Run("Invalid", func() {
+		stdout, _, err := s.execute("php.version", "7.4", projectDir)
+
+		s.Empty(stdout)
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg: "invalid project manifest vars",
+			Err: expectation.Errors(
+				sourcetest.Expectation(heredoc.Doc(`
+
+					at %[1]s:6:12
+
+					  3 │   repository: %[2]s
+					  4 │
+					  5 │ php:
+					▶ 6 │   version: "7.4" # Php version
+					    ├────────────╯ value must be one of '8.3', '8.4'
+				`, projectFile, repositoryURL)),
+			),
+		}, err)
+
+		// Reverted
+		heredoc.EqualFile(s.T(), `
+			manala:
+			  recipe: recipe
+			  repository: %[1]s
+
+			php:
+			  version: "8.4" # Php version
+		`, projectFile, repositoryURL)
+	})
+}
+
+func (s *CommandSuite) execute(args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
+	out := &bytes.Buffer{}
+	err := &bytes.Buffer{}
+
+	logger := log.New(output.NewDetached(err))
+	logger.Verbose(1)
+
+	command := cmdSet.NewCommand(
+		logger,
+		api.New(
+			logger,
+			cache.New(""),
+		),
+		output.NewDetached(out),
+	)
+
+	command.SilenceErrors = true
+	command.SilenceUsage = true
+	command.SetOut(out)
+	command.SetErr(err)
+	command.SetArgs(append([]string{}, args...))
+
+	return out, err, command.Execute()
+}
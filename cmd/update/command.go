@@ -40,6 +40,7 @@ current directory)`,
 			ctx = app.WithRepositoryURL(ctx, repositoryURL)
 			ctx = app.WithRepositoryRef(ctx, repositoryRef)
 			ctx = app.WithRecipeName(ctx, recipeName)
+			ctx = api.WithRepositoryShorthand(ctx)
 
 			return run(ctx, log, api, out, dir, recursive)
 		},
@@ -67,15 +68,18 @@ func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir
 
 	if recursive {
 		// Get project loader
-		projectLoader := api.NewProjectLoader(repositoryLoader, recipeLoader)
+		projectLoader := api.NewProjectLoader(repositoryLoader, recipeLoader,
+			api.WithProjectLoaderMigrate(true),
+			api.WithProjectLoaderLock(true),
+		)
 
 		// Recursively load projects
 		log.Info("loading projects recursive…")
-		err = projectLoader.LoadRecursive(dir,
+		err = projectLoader.LoadRecursive(ctx, dir,
 			func(project app.Project) error {
 				// Sync project
 				log.Info("syncing project…")
-				err = projectSyncer.Sync(project)
+				err = projectSyncer.Sync(ctx, project)
 				if err != nil {
 					return err
 				}
@@ -93,18 +97,20 @@ func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, dir
 	// Get project loader
 	projectLoader := api.NewProjectLoader(repositoryLoader, recipeLoader,
 		api.WithProjectLoaderFrom(true),
+		api.WithProjectLoaderMigrate(true),
+		api.WithProjectLoaderLock(true),
 	)
 
 	// Load project
 	log.Info("loading project…")
-	project, err = projectLoader.Load(dir)
+	project, err = projectLoader.Load(ctx, dir)
 	if err != nil {
 		return err
 	}
 
 	// Sync project
 	log.Info("syncing project…")
-	err = projectSyncer.Sync(project)
+	err = projectSyncer.Sync(ctx, project)
 	if err != nil {
 		return err
 	}
@@ -41,6 +41,7 @@ current directory)`,
 			ctx = app.WithRepositoryURL(ctx, repositoryURL)
 			ctx = app.WithRepositoryRef(ctx, repositoryRef)
 			ctx = app.WithRecipeName(ctx, recipeName)
+			ctx = api.WithRepositoryShorthand(ctx)
 
 			return run(ctx, log, api, out, notifier, dir, all, notify)
 		},
@@ -71,7 +72,7 @@ func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, not
 
 	// Load project
 	log.Info("loading project…")
-	project, err = projectLoader.Load(dir)
+	project, err = projectLoader.Load(ctx, dir)
 	if err != nil {
 		return err
 	}
@@ -85,7 +86,7 @@ func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, not
 		Watch(ctx, project, func(project app.Project) app.Project {
 			// Load project
 			log.Info("loading project…")
-			if project, err = projectLoader.Load(project.Dir()); err != nil {
+			if project, err = projectLoader.Load(ctx, project.Dir()); err != nil {
 				log.Error(err)
 
 				if notify {
@@ -97,7 +98,7 @@ func run(ctx context.Context, log *log.Log, api *api.API, out output.Output, not
 
 			// Sync project
 			log.Info("syncing project…")
-			if err = projectSyncer.Sync(project); err != nil {
+			if err = projectSyncer.Sync(ctx, project); err != nil {
 				log.Error(err)
 
 				if notify {
@@ -0,0 +1,108 @@
+package sync
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	blockBegin = "manala:begin"
+	blockEnd   = "manala:end"
+)
+
+// blockComments associates files extensions with their comment delimiters.
+// Any other file is commented using "#".
+var blockComments = map[string][2]string{
+	".c":    {"// ", ""},
+	".cpp":  {"// ", ""},
+	".css":  {"/* ", " */"},
+	".go":   {"// ", ""},
+	".htm":  {"<!-- ", " -->"},
+	".html": {"<!-- ", " -->"},
+	".ini":  {"; ", ""},
+	".java": {"// ", ""},
+	".js":   {"// ", ""},
+	".less": {"// ", ""},
+	".lua":  {"-- ", ""},
+	".md":   {"<!-- ", " -->"},
+	".php":  {"// ", ""},
+	".rs":   {"// ", ""},
+	".scss": {"// ", ""},
+	".sql":  {"-- ", ""},
+	".ts":   {"// ", ""},
+	".twig": {"{# ", " #}"},
+	".xml":  {"<!-- ", " -->"},
+}
+
+// blockMarkers returns begin/end markers lines, commented according to file type.
+func blockMarkers(file string) (string, string, error) {
+	ext := strings.ToLower(filepath.Ext(file))
+
+	// Json does not support comments at all
+	if ext == ".json" {
+		return "", "", errors.New("block markers are not supported in json files")
+	}
+
+	comment, ok := blockComments[ext]
+	if !ok {
+		comment = [2]string{"# ", ""}
+	}
+
+	return comment[0] + blockBegin + comment[1],
+		comment[0] + blockEnd + comment[1],
+		nil
+}
+
+// Markers are only looked for on comment only lines, possibly surrounded by comment delimiters,
+// so that markers found elsewhere (strings, code,...) are left untouched.
+var (
+	blockBeginRegex = regexp.MustCompile(`(?m)^[ \t]*[-#/*;<!>{}]*[ \t]*manala:begin[ \t]*[-#/*;<!>{}]*[ \t\r]*(?:\n|$)`)
+	blockEndRegex   = regexp.MustCompile(`(?m)^[ \t]*[-#/*;<!>{}]*[ \t]*manala:end[ \t]*[-#/*;<!>{}]*[ \t\r]*$`)
+)
+
+// mergeBlock replaces content found between destination begin/end markers
+// by block, leaving everything outside the markers untouched.
+// Markers lines, commented according to file type, are appended to the destination when not found.
+func mergeBlock(dst, block []byte, file string) ([]byte, error) {
+	// Ensure block ends with a new line
+	if len(block) > 0 && !bytes.HasSuffix(block, []byte("\n")) {
+		block = append(block, '\n')
+	}
+
+	buffer := &bytes.Buffer{}
+
+	// Begin marker
+	begin := blockBeginRegex.FindIndex(dst)
+	if begin == nil {
+		markerBegin, markerEnd, err := blockMarkers(file)
+		if err != nil {
+			return nil, err
+		}
+
+		// Append block to destination
+		buffer.Write(dst)
+		if len(dst) > 0 && !bytes.HasSuffix(dst, []byte("\n")) {
+			buffer.WriteByte('\n')
+		}
+		buffer.WriteString(markerBegin + "\n")
+		buffer.Write(block)
+		buffer.WriteString(markerEnd + "\n")
+
+		return buffer.Bytes(), nil
+	}
+
+	// End marker
+	end := blockEndRegex.FindIndex(dst[begin[1]:])
+	if end == nil {
+		return nil, errors.New("block end marker not found")
+	}
+
+	buffer.Write(dst[:begin[1]])
+	buffer.Write(block)
+	buffer.Write(dst[begin[1]+end[0]:])
+
+	return buffer.Bytes(), nil
+}
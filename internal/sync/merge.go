@@ -0,0 +1,128 @@
+package sync
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	yamlmapping "github.com/manala/manala/internal/yaml/mapping"
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// Merge strategies.
+const (
+	MergeRecipeWins  = "recipe-wins"
+	MergeProjectWins = "project-wins"
+	MergeAppend      = "append"
+)
+
+var mergeStrategies = map[string]yamlmapping.MergeStrategy{
+	MergeRecipeWins:  yamlmapping.MergeOverride,
+	MergeProjectWins: yamlmapping.MergeKeep,
+	MergeAppend:      yamlmapping.MergeAppend,
+}
+
+var jsonIndentRegex = regexp.MustCompile(`(?m)^([ \t]+)\S`)
+
+// mergeDocument deep merges src yaml (or json) document into dst one.
+// Strategies are indexed by json pointers, and apply to their descendants,
+// unless overridden. Recipe wins by default.
+// Empty (or comments only) dst document is considered as an empty map.
+func mergeDocument(dst, src []byte, isJSON bool, strategies map[string]string) ([]byte, error) {
+	srcNode, err := yamlparser.ParseRaw(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEmptyDocument(dst) {
+		var content []byte
+
+		// Keep destination comments, as json does not support them
+		if comments := bytes.TrimSpace(dst); !isJSON && len(comments) > 0 {
+			content = append(comments, '\n')
+		}
+
+		content = append(content, srcNode.String()+"\n"...)
+
+		if !isJSON {
+			return content, nil
+		}
+
+		return toJSON(content, dst)
+	}
+
+	dstNode, err := yamlparser.ParseRaw(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	yamlmapping.Merge(dstNode, srcNode, func(path string) yamlmapping.MergeStrategy {
+		// Look for the closest strategy, walking up the pointer
+		pointer := yamlpath.ToJSONPointer(path)
+		for {
+			if strategy, ok := strategies[pointer]; ok {
+				return mergeStrategies[strategy]
+			}
+
+			if pointer == "" {
+				return yamlmapping.MergeOverride
+			}
+
+			pointer = pointer[:strings.LastIndex(pointer, "/")]
+		}
+	})
+
+	content := []byte(dstNode.String() + "\n")
+
+	if !isJSON {
+		return content, nil
+	}
+
+	return toJSON(content, dst)
+}
+
+// toJSON converts yaml content back to json, respecting dst indentation.
+func toJSON(content, dst []byte) ([]byte, error) {
+	content, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, err
+	}
+
+	indent := "  "
+	if matches := jsonIndentRegex.FindSubmatch(dst); matches != nil {
+		indent = string(matches[1])
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := json.Indent(buffer, bytes.TrimSpace(content), "", indent); err != nil {
+		return nil, err
+	}
+
+	buffer.WriteByte('\n')
+
+	return buffer.Bytes(), nil
+}
+
+// isEmptyDocument reports whether yaml content holds no document, or comments only.
+func isEmptyDocument(content []byte) bool {
+	file, err := parser.ParseBytes(content, parser.ParseComments)
+	if err != nil {
+		return false
+	}
+
+	for _, doc := range file.Docs {
+		switch doc.Body.(type) {
+		case nil, *ast.CommentGroupNode:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
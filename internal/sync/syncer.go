@@ -32,8 +32,14 @@ func (syncer *Syncer) Sync(
 	dstDir string,
 	dst string,
 	templateExecutor *engine.Executor,
+	opts ...SyncOption,
 ) error {
-	node, err := newNode(srcDir, src, dstDir, dst, templateExecutor)
+	options := &syncOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	node, err := newNode(srcDir, src, dstDir, dst, templateExecutor, options)
 	if err != nil {
 		return err
 	}
@@ -92,6 +98,7 @@ func (syncer *Syncer) syncNode(node *node) error {
 				node.Dst.Dir,
 				filepath.Join(relDstPath, file),
 				node.TemplateExecutor,
+				node.Options,
 			)
 			if err != nil {
 				return err
@@ -172,7 +179,7 @@ func (syncer *Syncer) syncNode(node *node) error {
 
 		srcReader = bytes.NewReader(buffer.Bytes())
 
-		if node.Dst.IsExist {
+		if node.Dst.IsExist && !node.IsBlock && !node.IsMerge {
 			// Get template hash
 			hash := sha256.New()
 			if _, err := io.Copy(hash, buffer); err != nil {
@@ -192,7 +199,7 @@ func (syncer *Syncer) syncNode(node *node) error {
 
 		defer srcFile.Close()
 
-		if node.Dst.IsExist {
+		if node.Dst.IsExist && !node.IsBlock && !node.IsMerge {
 			// Get source hash
 			hash := sha256.New()
 			if _, err := io.Copy(hash, srcFile); err != nil {
@@ -209,6 +216,55 @@ func (syncer *Syncer) syncNode(node *node) error {
 		srcReader = srcFile
 	}
 
+	if node.IsBlock || node.IsMerge {
+		// Read source content
+		srcContent, err := io.ReadAll(srcReader)
+		if err != nil {
+			return err
+		}
+
+		// Read destination content
+		var dstContent []byte
+		if node.Dst.IsExist {
+			if dstContent, err = os.ReadFile(node.Dst.Path); err != nil {
+				return serror.New("file system error").
+					With("file", node.Dst.Path).
+					WithErr(std.From(err))
+			}
+		}
+
+		content := srcContent
+
+		if node.IsBlock {
+			// Merge block into destination content
+			if content, err = mergeBlock(dstContent, srcContent, node.Dst.Path); err != nil {
+				return serror.New("unable to merge block").
+					With("file", node.Dst.Path).
+					WithErr(err)
+			}
+		} else if node.Dst.IsExist {
+			// Merge document into destination document
+			if content, err = mergeDocument(
+				dstContent,
+				srcContent,
+				filepath.Ext(node.Dst.Path) == ".json",
+				node.Options.mergeStrategies[filepath.ToSlash(relDstPath)],
+			); err != nil {
+				return serror.New("unable to merge file").
+					With("file", node.Dst.Path).
+					WithErr(err)
+			}
+		}
+
+		srcReader = bytes.NewReader(content)
+
+		if node.Dst.IsExist {
+			// Get content hash
+			hash := sha256.Sum256(content)
+			equal = bytes.Equal(hash[:], node.Dst.Hash)
+		}
+	}
+
 	// Files are not equals or destination does not exist
 	if !equal {
 		// Destination file mode
@@ -263,9 +319,11 @@ type node struct {
 		Files        []string
 		IsExecutable bool
 	}
-	IsDist bool
-	IsTmpl bool
-	Dst    struct {
+	IsDist  bool
+	IsTmpl  bool
+	IsBlock bool
+	IsMerge bool
+	Dst     struct {
 		Dir     string
 		Path    string
 		Mode    os.FileMode
@@ -275,18 +333,22 @@ type node struct {
 		Files   []string
 	}
 	TemplateExecutor *engine.Executor
+	Options          *syncOptions
 }
 
 var (
-	distRegex = regexp.MustCompile(`(\.dist)(?:$|\.tmpl$)`)
-	tmplRegex = regexp.MustCompile(`(\.tmpl)(?:$|\.dist$)`)
+	distRegex  = regexp.MustCompile(`(\.dist)(?:$|\.tmpl$)`)
+	tmplRegex  = regexp.MustCompile(`(\.tmpl)(?:$|\.dist$|\.block$|\.merge$)`)
+	blockRegex = regexp.MustCompile(`(\.block)(?:$|\.tmpl$)`)
+	mergeRegex = regexp.MustCompile(`(\.merge)(?:$|\.tmpl$)`)
 )
 
-func newNode(srcDir, src, dstDir, dst string, templateExecutor *engine.Executor) (*node, error) {
+func newNode(srcDir, src, dstDir, dst string, templateExecutor *engine.Executor, options *syncOptions) (*node, error) {
 	node := &node{}
 	node.Src.Dir = srcDir
 	node.Dst.Dir = dstDir
 	node.TemplateExecutor = templateExecutor
+	node.Options = options
 
 	srcPath := filepath.Join(node.Src.Dir, src)
 
@@ -329,6 +391,16 @@ func newNode(srcDir, src, dstDir, dst string, templateExecutor *engine.Executor)
 			node.IsTmpl = true
 			dst = tmplRegex.ReplaceAllString(dst, "")
 		}
+
+		if blockRegex.MatchString(src) {
+			node.IsBlock = true
+			dst = blockRegex.ReplaceAllString(dst, "")
+		}
+
+		if mergeRegex.MatchString(src) {
+			node.IsMerge = true
+			dst = mergeRegex.ReplaceAllString(dst, "")
+		}
 	}
 
 	dstPath := filepath.Join(node.Dst.Dir, dst)
@@ -389,3 +461,17 @@ func newNode(srcDir, src, dstDir, dst string, templateExecutor *engine.Executor)
 
 	return node, nil
 }
+
+type syncOptions struct {
+	mergeStrategies map[string]map[string]string
+}
+
+type SyncOption func(options *syncOptions)
+
+// WithMergeStrategies sets merge strategies, indexed by destination file paths,
+// relative to destination dir, then by json pointers.
+func WithMergeStrategies(strategies map[string]map[string]string) SyncOption {
+	return func(options *syncOptions) {
+		options.mergeStrategies = strategies
+	}
+}
@@ -267,3 +267,288 @@ func (s *SyncerSuite) TestSyncTemplate() {
 		}, err)
 	})
 }
+
+func (s *SyncerSuite) TestSyncBlock() {
+	sourcePath := filepath.FromSlash("testdata/SyncerSuite/TestSyncBlock/source")
+	destinationPath := filepath.FromSlash("testdata/SyncerSuite/TestSyncBlock/destination")
+
+	_ = os.RemoveAll(destinationPath)
+	_ = os.Mkdir(destinationPath, 0o755)
+	_ = os.WriteFile(filepath.Join(destinationPath, "file_without_block"), []byte("baz"), 0o666)
+	_ = os.WriteFile(filepath.Join(destinationPath, "file_with_block"), []byte(heredoc.Doc(`
+		baz
+		// manala:begin
+		qux
+		// manala:end
+		quux
+	`)), 0o666)
+	_ = os.WriteFile(filepath.Join(destinationPath, "file_with_inline_markers"), []byte(heredoc.Doc(`
+		echo "manala:begin"
+		echo "# manala:end"
+	`)), 0o666)
+	_ = os.WriteFile(filepath.Join(destinationPath, "file_with_unclosed_block"), []byte(heredoc.Doc(`
+		baz
+		# manala:begin
+		qux
+	`)), 0o666)
+
+	s.Run("DestinationFileNotExists", func() {
+		err := s.syncer.Sync(sourcePath, "foo.block", destinationPath, "foo", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			# manala:begin
+			foo
+			bar
+			# manala:end
+		`, filepath.Join(destinationPath, "foo"))
+	})
+
+	s.Run("DestinationFileTypeComment", func() {
+		err := s.syncer.Sync(sourcePath, "foo.block", destinationPath, "foo.md", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			<!-- manala:begin -->
+			foo
+			bar
+			<!-- manala:end -->
+		`, filepath.Join(destinationPath, "foo.md"))
+	})
+
+	s.Run("DestinationFileTypeUnsupported", func() {
+		err := s.syncer.Sync(sourcePath, "foo.block", destinationPath, "foo.json", nil)
+
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg: "unable to merge block",
+			Attrs: [][2]any{
+				{"file", filepath.Join(destinationPath, "foo.json")},
+			},
+			Err: expectation.ErrorMessage("block markers are not supported in json files"),
+		}, err)
+	})
+
+	s.Run("DestinationFileWithoutBlock", func() {
+		err := s.syncer.Sync(sourcePath, "foo.block", destinationPath, "file_without_block", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			baz
+			# manala:begin
+			foo
+			bar
+			# manala:end
+		`, filepath.Join(destinationPath, "file_without_block"))
+	})
+
+	s.Run("DestinationFileWithBlock", func() {
+		err := s.syncer.Sync(sourcePath, "foo.block", destinationPath, "file_with_block", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			baz
+			// manala:begin
+			foo
+			bar
+			// manala:end
+			quux
+		`, filepath.Join(destinationPath, "file_with_block"))
+	})
+
+	s.Run("DestinationFileWithInlineMarkers", func() {
+		err := s.syncer.Sync(sourcePath, "foo.block", destinationPath, "file_with_inline_markers", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			echo "manala:begin"
+			echo "# manala:end"
+			# manala:begin
+			foo
+			bar
+			# manala:end
+		`, filepath.Join(destinationPath, "file_with_inline_markers"))
+	})
+
+	s.Run("DestinationFileWithUnclosedBlock", func() {
+		err := s.syncer.Sync(sourcePath, "foo.block", destinationPath, "file_with_unclosed_block", nil)
+
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg: "unable to merge block",
+			Attrs: [][2]any{
+				{"file", filepath.Join(destinationPath, "file_with_unclosed_block")},
+			},
+			Err: expectation.ErrorMessage("block end marker not found"),
+		}, err)
+	})
+
+	s.Run("Template", func() {
+		err := s.syncer.Sync(sourcePath, "bar.block.tmpl", destinationPath, "bar", s.templateExecutor)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			# manala:begin
+			bar
+			# manala:end
+		`, filepath.Join(destinationPath, "bar"))
+	})
+}
+
+func (s *SyncerSuite) TestSyncMerge() {
+	sourcePath := filepath.FromSlash("testdata/SyncerSuite/TestSyncMerge/source")
+	destinationPath := filepath.FromSlash("testdata/SyncerSuite/TestSyncMerge/destination")
+
+	_ = os.RemoveAll(destinationPath)
+	_ = os.Mkdir(destinationPath, 0o755)
+	_ = os.WriteFile(filepath.Join(destinationPath, "file.yaml"), []byte(heredoc.Doc(`
+		# Foo
+		foo:
+		  bar: foo
+		  baz:
+		    - baz
+		# Project
+		project: project
+	`)), 0o666)
+	_ = os.WriteFile(filepath.Join(destinationPath, "file.json"), []byte(heredoc.Doc(`
+		{
+		    "project": "project",
+		    "foo": "foo"
+		}
+	`)), 0o666)
+	_ = os.WriteFile(filepath.Join(destinationPath, "invalid.yaml"), []byte(heredoc.Doc(`
+		- foo
+	`)), 0o666)
+
+	s.Run("DestinationFileNotExists", func() {
+		err := s.syncer.Sync(sourcePath, "foo.yaml.merge", destinationPath, "foo.yaml", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			foo:
+			  bar: bar
+			  baz:
+			    - baz
+			    - qux
+			quux: quux
+		`, filepath.Join(destinationPath, "foo.yaml"))
+	})
+
+	s.Run("DestinationFileExists", func() {
+		err := s.syncer.Sync(sourcePath, "foo.yaml.merge", destinationPath, "file.yaml.merge", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			# Foo
+			foo:
+			  bar: bar
+			  baz:
+			    - baz
+			    - qux
+			# Project
+			project: project
+			quux: quux
+		`, filepath.Join(destinationPath, "file.yaml"))
+	})
+
+	s.Run("DestinationFileEmpty", func() {
+		_ = os.WriteFile(filepath.Join(destinationPath, "empty.yaml"), []byte(heredoc.Doc(`
+			# Empty
+		`)), 0o666)
+		_ = os.WriteFile(filepath.Join(destinationPath, "empty.json"), []byte(""), 0o666)
+
+		err := s.syncer.Sync(sourcePath, "foo.yaml.merge", destinationPath, "empty.yaml.merge", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			# Empty
+			foo:
+			  bar: bar
+			  baz:
+			    - baz
+			    - qux
+			quux: quux
+		`, filepath.Join(destinationPath, "empty.yaml"))
+
+		err = s.syncer.Sync(sourcePath, "bar.json.merge", destinationPath, "empty.json.merge", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			{
+			  "foo": "bar",
+			  "baz": {
+			    "qux": "quux"
+			  }
+			}
+		`, filepath.Join(destinationPath, "empty.json"))
+	})
+
+	s.Run("DestinationFileExistsStrategies", func() {
+		_ = os.WriteFile(filepath.Join(destinationPath, "strategies.yaml"), []byte(heredoc.Doc(`
+			foo:
+			  bar: foo
+			  baz:
+			    - foo
+		`)), 0o666)
+
+		err := s.syncer.Sync(sourcePath, "foo.yaml.merge", destinationPath, "strategies.yaml.merge", nil,
+			sync.WithMergeStrategies(map[string]map[string]string{
+				"strategies.yaml": {
+					"/foo":     sync.MergeProjectWins,
+					"/foo/baz": sync.MergeAppend,
+				},
+			}),
+		)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			foo:
+			  bar: foo
+			  baz:
+			    - foo
+			    - baz
+			    - qux
+			quux: quux
+		`, filepath.Join(destinationPath, "strategies.yaml"))
+	})
+
+	s.Run("DestinationFileExistsJSON", func() {
+		err := s.syncer.Sync(sourcePath, "bar.json.merge", destinationPath, "file.json.merge", nil)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			{
+			    "project": "project",
+			    "foo": "bar",
+			    "baz": {
+			        "qux": "quux"
+			    }
+			}
+		`, filepath.Join(destinationPath, "file.json"))
+	})
+
+	s.Run("DestinationFileInvalid", func() {
+		err := s.syncer.Sync(sourcePath, "foo.yaml.merge", destinationPath, "invalid.yaml.merge", nil)
+
+		expectation.ExpectError(s.T(), serrortest.Expectation{
+			Msg: "unable to merge file",
+			Attrs: [][2]any{
+				{"file", filepath.Join(destinationPath, "invalid.yaml")},
+			},
+			Err: expectation.ErrorMessage("yaml document must be a map"),
+		}, err)
+	})
+
+	s.Run("Template", func() {
+		_ = os.WriteFile(filepath.Join(destinationPath, "baz.yaml"), []byte(heredoc.Doc(`
+			foo: foo
+			bar: bar
+		`)), 0o666)
+
+		err := s.syncer.Sync(sourcePath, "baz.yaml.merge.tmpl", destinationPath, "baz.yaml.merge.tmpl", s.templateExecutor)
+		s.Require().NoError(err)
+
+		heredoc.EqualFile(s.T(), `
+			foo: bar
+			bar: bar
+		`, filepath.Join(destinationPath, "baz.yaml"))
+	})
+}
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"errors"
+	"path/filepath"
 	"regexp"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
@@ -38,6 +39,20 @@ var (
 			return nil
 		},
 	}
+	// Local Path.
+	LocalPathFormat = &jsonschema.Format{
+		Name: "local-path",
+		Validate: func(v any) error {
+			s, ok := v.(string)
+			if !ok {
+				return errors.New("not a string")
+			}
+			if !filepath.IsLocal(s) {
+				return errors.New("invalid format")
+			}
+			return nil
+		},
+	}
 	// Domain.
 	domainRegex  = regexp.MustCompile(`^([a-zA-Z0-9][a-zA-Z0-9-]{0,61}[a-zA-Z0-9]\.)+[a-zA-Z]{2,}$`)
 	DomainFormat = &jsonschema.Format{
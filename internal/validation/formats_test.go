@@ -31,6 +31,16 @@ func (s *FormatsSuite) TestFilePath() {
 	s.Require().Error(validation.FilePathFormat.Validate("/foo/*"))
 }
 
+func (s *FormatsSuite) TestLocalPath() {
+	s.NoError(validation.LocalPathFormat.Validate("foo"))
+	s.NoError(validation.LocalPathFormat.Validate("foo/bar"))
+	s.NoError(validation.LocalPathFormat.Validate("foo/../bar"))
+
+	s.Require().Error(validation.LocalPathFormat.Validate("/foo"))
+	s.Require().Error(validation.LocalPathFormat.Validate("../foo"))
+	s.Require().Error(validation.LocalPathFormat.Validate("foo/../.."))
+}
+
 func (s *FormatsSuite) TestDomain() {
 	s.NoError(validation.DomainFormat.Validate("foo.bar"))
 	s.NoError(validation.DomainFormat.Validate("foo.bar.baz"))
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/go-openapi/jsonpointer"
 	"github.com/santhosh-tekuri/jsonschema/v6"
@@ -25,6 +26,7 @@ func NewValidator(schema map[string]any) (*Validator, error) {
 	// Formats
 	compiler.RegisterFormat(GitRepoFormat)
 	compiler.RegisterFormat(FilePathFormat)
+	compiler.RegisterFormat(LocalPathFormat)
 	compiler.RegisterFormat(DomainFormat)
 	compiler.AssertFormat()
 
@@ -90,6 +92,10 @@ func (v *Validator) Validate(value any, opts ...ValidateOption) error {
 				})
 			}
 		default:
+			if cfg.skipped(location) {
+				continue
+			}
+
 			line, column := cfg.locator.ValueAt(location)
 			violations = append(violations, &Violation{
 				error:    errors.New(unit.Error.String()),
@@ -100,6 +106,10 @@ func (v *Validator) Validate(value any, opts ...ValidateOption) error {
 		}
 	}
 
+	if len(violations) == 0 {
+		return nil
+	}
+
 	// Keep deterministic violations order
 	slices.SortFunc(violations, func(a, b *Violation) int {
 		// By location
@@ -114,10 +124,28 @@ func (v *Validator) Validate(value any, opts ...ValidateOption) error {
 }
 
 type validateConfig struct {
-	locator Locator
+	locator       Locator
+	skippedValues []string
+}
+
+// skipped tells whether violations of a value location are skipped.
+func (cfg *validateConfig) skipped(location string) bool {
+	for _, pointer := range cfg.skippedValues {
+		if location == pointer || strings.HasPrefix(location, pointer+"/") {
+			return true
+		}
+	}
+	return false
 }
+
 type ValidateOption func(cfg *validateConfig)
 
 func WithLocator(locator Locator) ValidateOption {
 	return func(cfg *validateConfig) { cfg.locator = locator }
 }
+
+// WithSkippedValues skips violations of values found at the given json pointers, or under them.
+// Values are still required to be present, and allowed as properties.
+func WithSkippedValues(pointers ...string) ValidateOption {
+	return func(cfg *validateConfig) { cfg.skippedValues = append(cfg.skippedValues, pointers...) }
+}
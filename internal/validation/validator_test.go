@@ -269,3 +269,48 @@ func (s *ValidatorLocatorSuite) TestValidateViolations() {
 		})
 	}
 }
+
+func (s *ValidatorLocatorSuite) TestValidateSkippedValues() {
+	validator, err := validation.NewValidator(map[string]any{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []any{"root_foo", "root_bar"},
+		"properties": map[string]any{
+			"root_foo": map[string]any{"type": "integer"},
+			"root_bar": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"nested_foo": map[string]any{"type": "integer"}},
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	s.Run("Skipped", func() {
+		err := validator.Validate(map[string]any{
+			"root_foo": "string",
+			"root_bar": map[string]any{"nested_foo": "string"},
+		}, validation.WithSkippedValues("/root_foo", "/root_bar"))
+
+		s.Require().NoError(err)
+	})
+
+	s.Run("Required", func() {
+		err := validator.Validate(map[string]any{
+			"root_foo": "string",
+			"root_baz": "string",
+		}, validation.WithSkippedValues("/root_foo", "/root_baz"))
+
+		expectation.ExpectError(s.T(), expectation.Errors(
+			validationtest.ViolationExpectation{
+				Location: "",
+				Position: [2]int{0, 0},
+				Err:      expectation.ErrorMessage("missing property 'root_bar'"),
+			},
+			validationtest.ViolationExpectation{
+				Location: "/root_baz",
+				Position: [2]int{0, 0},
+				Err:      expectation.ErrorMessage("additional property 'root_baz' not allowed"),
+			},
+		), err)
+	})
+}
@@ -0,0 +1,101 @@
+package mapping
+
+import (
+	"slices"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// MergeStrategy describes how a source value is merged into a destination one.
+type MergeStrategy int
+
+const (
+	// MergeOverride replaces destination value by source one.
+	MergeOverride MergeStrategy = iota
+	// MergeKeep keeps destination value.
+	MergeKeep
+	// MergeAppend appends source sequence items missing from destination sequence.
+	MergeAppend
+)
+
+// Merge deep merges src mapping node into dst one. Keys only found in src are appended
+// to dst, and conflicting values are handled according to the strategy returned by
+// the strategy function, called with the source value path.
+// Comments and keys order of dst are preserved.
+func Merge(dst, src *ast.MappingNode, strategy func(path string) MergeStrategy) {
+	for _, srcValue := range src.Values {
+		i := slices.IndexFunc(dst.Values, func(v *ast.MappingValueNode) bool {
+			return v.Key.GetToken().Value == srcValue.Key.GetToken().Value
+		})
+
+		// Missing key; append
+		if i == -1 {
+			if len(dst.Values) > 0 {
+				srcValue.AddColumn(column(dst.Values[0]) - column(srcValue))
+			}
+
+			dst.Values = append(dst.Values, srcValue)
+
+			continue
+		}
+
+		dstValue := dst.Values[i]
+
+		// Both mapping; go deeper
+		if dstMapping, ok := dstValue.Value.(*ast.MappingNode); ok {
+			if srcMapping, ok := srcValue.Value.(*ast.MappingNode); ok {
+				Merge(dstMapping, srcMapping, strategy)
+
+				continue
+			}
+		}
+
+		switch strategy(srcValue.GetPath()) {
+		case MergeKeep:
+			continue
+		case MergeAppend:
+			// Both sequences; append missing items
+			if dstSequence, ok := dstValue.Value.(*ast.SequenceNode); ok {
+				if srcSequence, ok := srcValue.Value.(*ast.SequenceNode); ok {
+					appendSequence(dstSequence, srcSequence)
+
+					continue
+				}
+			}
+		}
+
+		// Override
+		srcValue.AddColumn(column(dstValue) - column(srcValue))
+		dstValue.Value = srcValue.Value
+	}
+}
+
+func appendSequence(dst, src *ast.SequenceNode) {
+	offset := dst.Start.Position.Column - src.Start.Position.Column
+
+	for i, srcValue := range src.Values {
+		if slices.ContainsFunc(dst.Values, func(v ast.Node) bool {
+			return v.String() == srcValue.String()
+		}) {
+			continue
+		}
+
+		srcValue.AddColumn(offset)
+
+		// Keep head comments aligned with values
+		if len(dst.ValueHeadComments) == len(dst.Values) {
+			var comment *ast.CommentGroupNode
+			if i < len(src.ValueHeadComments) {
+				comment = src.ValueHeadComments[i]
+			}
+
+			dst.ValueHeadComments = append(dst.ValueHeadComments, comment)
+		}
+
+		dst.Values = append(dst.Values, srcValue)
+	}
+}
+
+func column(node *ast.MappingValueNode) int {
+	return node.Key.GetToken().Position.Column
+}
@@ -0,0 +1,187 @@
+package mapping_test
+
+import (
+	"testing"
+
+	"github.com/manala/manala/internal/testing/heredoc"
+	yamlmapping "github.com/manala/manala/internal/yaml/mapping"
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MergeSuite struct{ suite.Suite }
+
+func TestMergeSuite(t *testing.T) {
+	suite.Run(t, new(MergeSuite))
+}
+
+func (s *MergeSuite) TestMerge() {
+	tests := []struct {
+		test       string
+		dst        string
+		src        string
+		strategies map[string]yamlmapping.MergeStrategy
+		expected   string
+	}{
+		{
+			test: "Append",
+			dst: heredoc.Doc(`
+				# Foo
+				foo: foo
+			`),
+			src: heredoc.Doc(`
+				bar:
+				  baz: baz
+			`),
+			expected: heredoc.Doc(`
+				# Foo
+				foo: foo
+				bar:
+				  baz: baz
+			`),
+		},
+		{
+			test: "Override",
+			dst: heredoc.Doc(`
+				# Foo
+				foo: foo
+				bar: bar
+			`),
+			src: heredoc.Doc(`
+				foo:
+				  - baz
+			`),
+			expected: heredoc.Doc(`
+				# Foo
+				foo:
+				  - baz
+				bar: bar
+			`),
+		},
+		{
+			test: "Keep",
+			dst: heredoc.Doc(`
+				foo: foo
+			`),
+			src: heredoc.Doc(`
+				foo: bar
+			`),
+			strategies: map[string]yamlmapping.MergeStrategy{
+				"$.foo": yamlmapping.MergeKeep,
+			},
+			expected: heredoc.Doc(`
+				foo: foo
+			`),
+		},
+		{
+			test: "Deep",
+			dst: heredoc.Doc(`
+				foo:
+				    # Bar
+				    bar: bar
+				    baz: baz
+			`),
+			src: heredoc.Doc(`
+				foo:
+				  baz: qux
+				  quux: quux
+			`),
+			expected: heredoc.Doc(`
+				foo:
+				    # Bar
+				    bar: bar
+				    baz: qux
+				    quux: quux
+			`),
+		},
+		{
+			test: "AppendSequence",
+			dst: heredoc.Doc(`
+				foo:
+				  bar:
+				    - bar
+				    - baz
+			`),
+			src: heredoc.Doc(`
+				foo:
+				    bar:
+				    - baz
+				    - qux
+			`),
+			strategies: map[string]yamlmapping.MergeStrategy{
+				"$.foo.bar": yamlmapping.MergeAppend,
+			},
+			expected: heredoc.Doc(`
+				foo:
+				  bar:
+				    - bar
+				    - baz
+				    - qux
+			`),
+		},
+		{
+			test: "AppendScalar",
+			dst: heredoc.Doc(`
+				foo: foo
+			`),
+			src: heredoc.Doc(`
+				foo: bar
+			`),
+			strategies: map[string]yamlmapping.MergeStrategy{
+				"$.foo": yamlmapping.MergeAppend,
+			},
+			expected: heredoc.Doc(`
+				foo: bar
+			`),
+		},
+		{
+			test: "Anchors",
+			dst: heredoc.Doc(`
+				x-foo: &foo
+				  foo: foo
+				bar:
+				  <<: *foo
+			`),
+			src: heredoc.Doc(`
+				bar:
+				  baz: baz
+			`),
+			expected: heredoc.Doc(`
+				x-foo: &foo
+				  foo: foo
+				bar:
+				  <<: *foo
+				  baz: baz
+			`),
+		},
+		{
+			test: "Flow",
+			dst: heredoc.Doc(`
+				{"foo": "foo", "bar": {"baz": "baz"}}
+			`),
+			src: heredoc.Doc(`
+				{"bar": {"qux": "qux"}, "quux": ["quux"]}
+			`),
+			expected: heredoc.Doc(`
+				{"foo": "foo", "bar": {"baz": "baz", "qux": "qux"}, "quux": ["quux"]}
+			`),
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			dst, err := yamlparser.ParseRaw([]byte(test.dst))
+			s.Require().NoError(err)
+
+			src, err := yamlparser.ParseRaw([]byte(test.src))
+			s.Require().NoError(err)
+
+			yamlmapping.Merge(dst, src, func(path string) yamlmapping.MergeStrategy {
+				return test.strategies[path]
+			})
+
+			s.Equal(test.expected, dst.String()+"\n")
+		})
+	}
+}
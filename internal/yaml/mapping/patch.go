@@ -0,0 +1,269 @@
+package mapping
+
+import (
+	"bytes"
+	"strings"
+
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+
+	"github.com/goccy/go-yaml/ast"
+)
+
+// Patch returns content patched with the changes made to its edited mapping node, only changed entries lines
+// being rewritten, so that the rest of the content (document markers, blank lines, comments, anchors,...)
+// is left byte for byte untouched.
+func Patch(content []byte, edited *ast.MappingNode) ([]byte, error) {
+	src, err := yamlparser.ParseRaw(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Render edited mapping, parsed again to get its entries lines
+	rendered := []byte(edited.String() + "\n")
+
+	dst, err := yamlparser.ParseRaw(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	// Flow mappings entries do not stand on their own lines
+	if src.IsFlowStyle || dst.IsFlowStyle {
+		return rendered, nil
+	}
+
+	patcher := &patcher{
+		src: lines(content),
+		dst: lines(rendered),
+	}
+
+	buffer := &bytes.Buffer{}
+	patcher.mapping(buffer,
+		src, span{0, len(patcher.src)},
+		dst, span{0, len(patcher.dst)},
+		edited,
+	)
+
+	return buffer.Bytes(), nil
+}
+
+// span is a range of lines, from included, to excluded.
+type span struct {
+	from, to int
+}
+
+type patcher struct {
+	src []string
+	dst []string
+}
+
+// mapping writes src mapping lines, patched with dst mapping ones, in dst entries order.
+// Edited mapping, that dst one is rendered from, tells apart added entries own head comments.
+func (patcher *patcher) mapping(buffer *bytes.Buffer, src *ast.MappingNode, srcSpan span, dst *ast.MappingNode, dstSpan span, edited *ast.MappingNode) {
+	srcSpans := spans(patcher.src, src, srcSpan)
+	dstSpans := spans(patcher.dst, dst, dstSpan)
+
+	// Leading lines, before first entry
+	patcher.write(buffer, patcher.src, span{srcSpan.from, srcSpans[0].from})
+
+	for j, dstValue := range dst.Values {
+		i := index(src, dstValue.Key.GetToken().Value)
+
+		// Added entry, along with its own head comment only
+		if i == -1 {
+			position := dstValue.Key.GetToken().Position
+
+			from := position.Line - 1
+			if comment := edited.Values[j].GetComment(); comment != nil && len(comment.Comments) > 0 {
+				from = max(from-len(comment.Comments), dstSpans[j].from)
+			}
+
+			patcher.write(buffer, patcher.dst, span{from, trail(patcher.dst, span{position.Line, dstSpans[j].to}, position.Column-1)})
+
+			continue
+		}
+
+		patcher.entry(buffer, src.Values[i], srcSpans[i], dstValue, dstSpans[j], edited.Values[j])
+	}
+}
+
+// entry writes src entry lines, patched with dst entry ones.
+func (patcher *patcher) entry(buffer *bytes.Buffer, src *ast.MappingValueNode, srcSpan span, dst *ast.MappingValueNode, dstSpan span, edited *ast.MappingValueNode) {
+	// Unchanged entry
+	if src.String() == dst.String() {
+		patcher.write(buffer, patcher.src, srcSpan)
+
+		return
+	}
+
+	srcKey := src.Key.GetToken().Position.Line - 1
+	dstKey := dst.Key.GetToken().Position.Line - 1
+
+	// Head lines
+	patcher.write(buffer, patcher.src, span{srcSpan.from, srcKey})
+
+	// Both block mappings; go deeper
+	if srcMapping, ok := block(src); ok {
+		if dstMapping, ok := block(dst); ok {
+			patcher.write(buffer, patcher.src, span{srcKey, srcKey + 1})
+			patcher.mapping(buffer,
+				srcMapping, span{srcKey + 1, srcSpan.to},
+				dstMapping, span{dstKey + 1, dstSpan.to},
+				edited.Value.(*ast.MappingNode),
+			)
+
+			return
+		}
+	}
+
+	// Both inline values; replace value only, leaving key and comment untouched
+	if srcFrom, srcTo, ok := inline(patcher.src, src, srcSpan); ok {
+		if dstFrom, dstTo, ok := inline(patcher.dst, dst, dstSpan); ok {
+			srcLine := []rune(patcher.src[srcKey])
+			dstLine := []rune(patcher.dst[dstKey])
+
+			buffer.WriteString(string(srcLine[:srcFrom]) + string(dstLine[dstFrom:dstTo]) + string(srcLine[srcTo:]))
+			patcher.write(buffer, patcher.src, span{srcKey + 1, srcSpan.to})
+
+			return
+		}
+	}
+
+	// Replace entry, leaving trailing lines untouched
+	srcIndent := src.Key.GetToken().Position.Column - 1
+	dstIndent := dst.Key.GetToken().Position.Column - 1
+
+	patcher.write(buffer, patcher.dst, span{dstKey, trail(patcher.dst, span{dstKey + 1, dstSpan.to}, dstIndent)})
+	patcher.write(buffer, patcher.src, span{trail(patcher.src, span{srcKey + 1, srcSpan.to}, srcIndent), srcSpan.to})
+}
+
+func (patcher *patcher) write(buffer *bytes.Buffer, lines []string, span span) {
+	for _, line := range lines[span.from:span.to] {
+		buffer.WriteString(line)
+	}
+}
+
+// lines splits content into lines, each of them ending with a new line.
+func lines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n"
+
+	return lines
+}
+
+// spans returns mapping entries lines spans, each of them starting with its head blank and comments lines,
+// and ending right before next one.
+func spans(lines []string, mapping *ast.MappingNode, mappingSpan span) []span {
+	spans := make([]span, len(mapping.Values))
+
+	from := mappingSpan.from
+	for i, value := range mapping.Values {
+		position := value.Key.GetToken().Position
+
+		line := position.Line - 1
+		for line > from && head(lines[line-1], position.Column-1) {
+			line--
+		}
+
+		spans[i].from = line
+		if i > 0 {
+			spans[i-1].to = line
+		}
+
+		from = position.Line
+	}
+
+	spans[len(spans)-1].to = mappingSpan.to
+
+	return spans
+}
+
+// trail returns the line span trailing blank and comments lines start at.
+func trail(lines []string, span span, indent int) int {
+	line := span.to
+	for line > span.from && head(lines[line-1], indent) {
+		line--
+	}
+
+	return line
+}
+
+// head reports whether a line is either blank or a comment, not indented more than the given indent.
+func head(line string, indent int) bool {
+	trimmed := strings.TrimLeft(line, " \t")
+	if strings.TrimSpace(trimmed) == "" {
+		return true
+	}
+
+	return strings.HasPrefix(trimmed, "#") && len(line)-len(trimmed) <= indent
+}
+
+// block returns entry value as a block mapping, starting on its own line.
+func block(entry *ast.MappingValueNode) (*ast.MappingNode, bool) {
+	mapping, ok := entry.Value.(*ast.MappingNode)
+	if !ok || mapping.IsFlowStyle || len(mapping.Values) == 0 {
+		return nil, false
+	}
+
+	if mapping.Values[0].Key.GetToken().Position.Line <= entry.Key.GetToken().Position.Line {
+		return nil, false
+	}
+
+	return mapping, true
+}
+
+// inline returns entry scalar value columns range, when found alone on entry key line, its comment aside.
+func inline(lines []string, entry *ast.MappingValueNode, entrySpan span) (int, int, bool) {
+	switch entry.Value.(type) {
+	case *ast.MappingNode, *ast.SequenceNode, *ast.LiteralNode:
+		return 0, 0, false
+	}
+
+	key := entry.Key.GetToken().Position
+	value := entry.Value.GetToken().Position
+	if value.Line != key.Line {
+		return 0, 0, false
+	}
+
+	// Nothing but blank and comments lines must follow
+	for _, line := range lines[key.Line:entrySpan.to] {
+		if !head(line, len(line)) {
+			return 0, 0, false
+		}
+	}
+
+	line := []rune(strings.TrimRight(lines[key.Line-1], "\r\n"))
+	to := len(line)
+
+	// Stop at comment
+	if comment := entry.Value.GetComment(); comment != nil {
+		for _, c := range comment.Comments {
+			if position := c.GetToken().Position; position.Line == key.Line {
+				to = position.Column - 1
+
+				break
+			}
+		}
+	}
+
+	from := value.Column - 1
+	for to > from && (line[to-1] == ' ' || line[to-1] == '\t') {
+		to--
+	}
+
+	return from, to, true
+}
+
+// index returns the index of the mapping entry with the given key, or -1 if not found.
+func index(mapping *ast.MappingNode, key string) int {
+	for i, value := range mapping.Values {
+		if value.Key.GetToken().Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
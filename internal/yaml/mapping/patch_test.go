@@ -0,0 +1,208 @@
+package mapping_test
+
+import (
+	"testing"
+
+	"github.com/manala/manala/internal/testing/heredoc"
+	yamlmapping "github.com/manala/manala/internal/yaml/mapping"
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/stretchr/testify/suite"
+)
+
+type PatchSuite struct{ suite.Suite }
+
+func TestPatchSuite(t *testing.T) {
+	suite.Run(t, new(PatchSuite))
+}
+
+func (s *PatchSuite) TestPatch() {
+	tests := []struct {
+		test     string
+		content  string
+		edit     func(mapping *ast.MappingNode)
+		expected string
+	}{
+		{
+			test: "Unchanged",
+			content: heredoc.Doc(`
+				---
+				# Foo
+				foo:   foo  # Foo
+
+
+				bar: &bar
+				    baz: baz
+				qux:
+				  <<: *bar
+			`),
+			edit: func(*ast.MappingNode) {},
+			expected: heredoc.Doc(`
+				---
+				# Foo
+				foo:   foo  # Foo
+
+
+				bar: &bar
+				    baz: baz
+				qux:
+				  <<: *bar
+			`),
+		},
+		{
+			test: "Scalar",
+			content: heredoc.Doc(`
+				---
+				foo:   foo  # Foo
+
+				bar: bar
+			`),
+			edit: func(mapping *ast.MappingNode) {
+				_ = yamlmapping.Set(mapping, "/foo", "baz qux")
+			},
+			expected: heredoc.Doc(`
+				---
+				foo:   baz qux  # Foo
+
+				bar: bar
+			`),
+		},
+		{
+			test: "Deep",
+			content: heredoc.Doc(`
+				foo:
+				    # Bar
+				    bar: bar
+
+				    baz: &baz baz
+				qux: *baz
+			`),
+			edit: func(mapping *ast.MappingNode) {
+				_ = yamlmapping.Set(mapping, "/foo/bar", 123)
+			},
+			expected: heredoc.Doc(`
+				foo:
+				    # Bar
+				    bar: 123
+
+				    baz: &baz baz
+				qux: *baz
+			`),
+		},
+		{
+			test: "Added",
+			content: heredoc.Doc(`
+				foo:
+				  bar: bar
+
+				# Baz
+				baz: baz
+			`),
+			edit: func(mapping *ast.MappingNode) {
+				_ = yamlmapping.Set(mapping, "/foo/qux", "qux")
+				_ = yamlmapping.Set(mapping, "/quux/corge", true)
+			},
+			expected: heredoc.Doc(`
+				foo:
+				  bar: bar
+				  qux: qux
+
+				# Baz
+				baz: baz
+				quux:
+				  corge: true
+			`),
+		},
+		{
+			test: "Deleted",
+			content: heredoc.Doc(`
+				foo: foo
+
+				# Bar
+				bar: bar
+
+				baz: baz
+			`),
+			edit: func(mapping *ast.MappingNode) {
+				_, _ = yamlmapping.Delete(mapping, "/bar")
+			},
+			expected: heredoc.Doc(`
+				foo: foo
+
+				baz: baz
+			`),
+		},
+		{
+			test: "Replaced",
+			content: heredoc.Doc(`
+				foo: foo
+
+				bar:
+				  - bar
+				# Baz
+				baz: baz
+			`),
+			edit: func(mapping *ast.MappingNode) {
+				_ = yamlmapping.Set(mapping, "/foo", map[string]any{"qux": "qux"})
+				_ = yamlmapping.Set(mapping, "/bar", []any{"quux", "corge"})
+			},
+			expected: heredoc.Doc(`
+				foo:
+				  qux: qux
+
+				bar:
+				  - quux
+				  - corge
+				# Baz
+				baz: baz
+			`),
+		},
+		{
+			test: "Literal",
+			content: heredoc.Doc(`
+				foo: |
+				  # Foo
+				  foo
+				# Bar
+				bar: bar
+			`),
+			edit: func(mapping *ast.MappingNode) {
+				_ = yamlmapping.Set(mapping, "/bar", "baz")
+			},
+			expected: heredoc.Doc(`
+				foo: |
+				  # Foo
+				  foo
+				# Bar
+				bar: baz
+			`),
+		},
+		{
+			test: "Flow",
+			content: heredoc.Doc(`
+				{"foo": "foo", "bar": "bar"}
+			`),
+			edit: func(mapping *ast.MappingNode) {
+				_ = yamlmapping.Set(mapping, "/foo", "baz")
+			},
+			expected: heredoc.Doc(`
+				{"foo": baz, "bar": "bar"}
+			`),
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			mapping, err := yamlparser.ParseRaw([]byte(test.content))
+			s.Require().NoError(err)
+
+			test.edit(mapping)
+
+			content, err := yamlmapping.Patch([]byte(test.content), mapping)
+
+			s.Require().NoError(err)
+			s.Equal(test.expected, string(content))
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package mapping
+
+import (
+	"errors"
+	"slices"
+
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+	yamlpath "github.com/manala/manala/internal/yaml/path"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+)
+
+// Lookup returns the mapping entry found at the given json pointer.
+// Pointers only go through mappings, sequences indexes are not supported.
+func Lookup(mapping *ast.MappingNode, pointer string) (*ast.MappingValueNode, bool) {
+	parent, i := lookup(mapping, yamlpath.SplitJSONPointer(pointer))
+	if i == -1 {
+		return nil, false
+	}
+
+	return parent.Values[i], true
+}
+
+// Delete removes the mapping entry found at the given json pointer and returns it.
+func Delete(mapping *ast.MappingNode, pointer string) (*ast.MappingValueNode, bool) {
+	parent, i := lookup(mapping, yamlpath.SplitJSONPointer(pointer))
+	if i == -1 {
+		return nil, false
+	}
+
+	value := parent.Values[i]
+	parent.Values = slices.Delete(parent.Values, i, i+1)
+
+	return value, true
+}
+
+// Rename renames the key of the mapping entry found at the given json pointer,
+// leaving it in place along with its comments.
+func Rename(mapping *ast.MappingNode, pointer string, key string) (bool, error) {
+	parent, i := lookup(mapping, yamlpath.SplitJSONPointer(pointer))
+	if i == -1 {
+		return false, nil
+	}
+
+	if slices.ContainsFunc(parent.Values, func(v *ast.MappingValueNode) bool {
+		return v.Key.GetToken().Value == key
+	}) {
+		return false, errors.New("key already exists")
+	}
+
+	token := parent.Values[i].Key.GetToken()
+	token.Value = key
+	if node, ok := parent.Values[i].Key.(*ast.StringNode); ok {
+		node.Value = key
+	}
+
+	return true, nil
+}
+
+// Set sets a value at the given json pointer, creating missing intermediate mappings,
+// and overriding existing value, while keeping its comment.
+func Set(mapping *ast.MappingNode, pointer string, value any) error {
+	tokens := yamlpath.SplitJSONPointer(pointer)
+	if len(tokens) == 0 {
+		return errors.New("unable to set root value")
+	}
+
+	// Nest value under pointer tokens
+	for _, token := range slices.Backward(tokens) {
+		value = yaml.MapSlice{{Key: token, Value: value}}
+	}
+
+	content, err := yaml.MarshalWithOptions(value, yaml.IndentSequence(true))
+	if err != nil {
+		return err
+	}
+
+	src, err := yamlparser.ParseRaw(content)
+	if err != nil {
+		return err
+	}
+
+	// Overridden value comment, if any
+	var comment *ast.CommentGroupNode
+	if entry, ok := Lookup(mapping, pointer); ok {
+		comment = entry.Value.GetComment()
+	}
+
+	Merge(mapping, src, func(string) MergeStrategy { return MergeOverride })
+
+	// Carry comment over
+	if comment != nil {
+		if entry, ok := Lookup(mapping, pointer); ok && entry.Value.GetComment() == nil {
+			_ = entry.Value.SetComment(comment)
+		}
+	}
+
+	return nil
+}
+
+// Move moves the mapping entry value found at the from json pointer, to the given one,
+// carrying its comments over. Head comment goes to the outermost entry created.
+func Move(mapping *ast.MappingNode, from string, to string) (bool, error) {
+	entry, ok := Delete(mapping, from)
+	if !ok {
+		return false, nil
+	}
+
+	var value any
+	if err := yaml.NodeToValue(entry.Value, &value); err != nil {
+		return false, err
+	}
+
+	// Outermost entry to be created
+	tokens := yamlpath.SplitJSONPointer(to)
+	created := len(tokens)
+	for created > 1 {
+		if _, i := lookup(mapping, tokens[:created-1]); i == -1 {
+			created--
+
+			continue
+		}
+
+		break
+	}
+
+	if err := Set(mapping, to, value); err != nil {
+		return false, err
+	}
+
+	// Carry comments over
+	if comment := entry.GetComment(); comment != nil {
+		if moved, ok := Lookup(mapping, yamlpath.JoinJSONPointer(tokens[:created])); ok && moved.GetComment() == nil {
+			_ = moved.SetComment(comment)
+		}
+	}
+
+	if comment := entry.Value.GetComment(); comment != nil {
+		if moved, ok := Lookup(mapping, to); ok && moved.Value.GetComment() == nil {
+			_ = moved.Value.SetComment(comment)
+		}
+	}
+
+	return true, nil
+}
+
+// lookup returns the parent mapping of the entry found at the given pointer tokens, along with its index,
+// or -1 if not found.
+func lookup(mapping *ast.MappingNode, tokens []string) (*ast.MappingNode, int) {
+	if len(tokens) == 0 {
+		return nil, -1
+	}
+
+	for {
+		i := slices.IndexFunc(mapping.Values, func(v *ast.MappingValueNode) bool {
+			return v.Key.GetToken().Value == tokens[0]
+		})
+		if i == -1 {
+			return nil, -1
+		}
+
+		if len(tokens) == 1 {
+			return mapping, i
+		}
+
+		child, ok := mapping.Values[i].Value.(*ast.MappingNode)
+		if !ok {
+			return nil, -1
+		}
+
+		mapping, tokens = child, tokens[1:]
+	}
+}
@@ -0,0 +1,131 @@
+package mapping_test
+
+import (
+	"testing"
+
+	"github.com/manala/manala/internal/testing/heredoc"
+	yamlmapping "github.com/manala/manala/internal/yaml/mapping"
+	yamlparser "github.com/manala/manala/internal/yaml/parser"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PointerSuite struct{ suite.Suite }
+
+func TestPointerSuite(t *testing.T) {
+	suite.Run(t, new(PointerSuite))
+}
+
+const pointerSrc = `
+# Foo
+foo: foo
+bar:
+  # Baz
+  baz: baz # Baz
+  qux: qux
+`
+
+func (s *PointerSuite) TestLookup() {
+	node, _ := yamlparser.ParseRaw([]byte(pointerSrc))
+
+	entry, ok := yamlmapping.Lookup(node, "/bar/baz")
+	s.True(ok)
+	s.Equal("baz", entry.Value.GetToken().Value)
+
+	_, ok = yamlmapping.Lookup(node, "/bar/missing")
+	s.False(ok)
+
+	_, ok = yamlmapping.Lookup(node, "/foo/baz")
+	s.False(ok)
+}
+
+func (s *PointerSuite) TestDelete() {
+	node, _ := yamlparser.ParseRaw([]byte(pointerSrc))
+
+	_, ok := yamlmapping.Delete(node, "/bar/baz")
+	s.True(ok)
+
+	_, ok = yamlmapping.Delete(node, "/bar/missing")
+	s.False(ok)
+
+	heredoc.Equal(s.T(), `
+		# Foo
+		foo: foo
+		bar:
+		  qux: qux
+	`, node.String()+"\n")
+}
+
+func (s *PointerSuite) TestRename() {
+	node, _ := yamlparser.ParseRaw([]byte(pointerSrc))
+
+	ok, err := yamlmapping.Rename(node, "/bar/baz", "quux")
+	s.Require().NoError(err)
+	s.True(ok)
+
+	ok, err = yamlmapping.Rename(node, "/bar/missing", "quux")
+	s.Require().NoError(err)
+	s.False(ok)
+
+	_, err = yamlmapping.Rename(node, "/bar/quux", "qux")
+	s.EqualError(err, "key already exists")
+
+	heredoc.Equal(s.T(), `
+		# Foo
+		foo: foo
+		bar:
+		  # Baz
+		  quux: baz # Baz
+		  qux: qux
+	`, node.String()+"\n")
+}
+
+func (s *PointerSuite) TestSet() {
+	node, _ := yamlparser.ParseRaw([]byte(pointerSrc))
+
+	s.Require().NoError(yamlmapping.Set(node, "/bar/baz", "corge"))
+	s.Require().NoError(yamlmapping.Set(node, "/bar/qux", []any{"qux"}))
+	s.Require().NoError(yamlmapping.Set(node, "/bar/quux/corge", true))
+	s.Require().NoError(yamlmapping.Set(node, "/grault", 123))
+
+	s.EqualError(yamlmapping.Set(node, "", "root"), "unable to set root value")
+
+	heredoc.Equal(s.T(), `
+		# Foo
+		foo: foo
+		bar:
+		  # Baz
+		  baz: corge # Baz
+		  qux:
+		    - qux
+		  quux:
+		    corge: true
+		grault: 123
+	`, node.String()+"\n")
+}
+
+func (s *PointerSuite) TestMove() {
+	node, _ := yamlparser.ParseRaw([]byte(pointerSrc))
+
+	ok, err := yamlmapping.Move(node, "/foo", "/foo/version")
+	s.Require().NoError(err)
+	s.True(ok)
+
+	ok, err = yamlmapping.Move(node, "/bar/baz", "/baz")
+	s.Require().NoError(err)
+	s.True(ok)
+
+	ok, err = yamlmapping.Move(node, "/missing", "/baz")
+	s.Require().NoError(err)
+	s.False(ok)
+
+	heredoc.Equal(s.T(), `
+		bar:
+		  qux: qux
+		# Foo
+		foo:
+		  version: foo
+		# Baz
+		baz: baz # Baz
+	`, node.String()+"\n")
+}
@@ -11,7 +11,50 @@ import (
 
 // Parse parses YAML bytes into a validated and resolved MappingNode,
 // and returns an enhanced error with position information if parsing fails.
-func Parse(data []byte) (*ast.MappingNode, error) {
+func Parse(data []byte, opts ...ParseOption) (*ast.MappingNode, error) {
+	cfg := &parseConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	node, err := ParseRaw(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk
+	w := &walker{
+		anchors: map[string]ast.Node{},
+	}
+	ast.Walk(w, node)
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	// Resolve
+	if err := resolve(node, w.anchors, map[string]bool{}, cfg.tags); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+type parseConfig struct {
+	tags []string
+}
+
+type ParseOption func(cfg *parseConfig)
+
+// WithTags keeps given tags nodes, as in "!secret", instead of dropping them on resolution.
+func WithTags(tags ...string) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.tags = append(cfg.tags, tags...)
+	}
+}
+
+// ParseRaw parses YAML bytes into a MappingNode, leaving anchors, aliases and
+// merge keys untouched, so that the node could be safely written back.
+func ParseRaw(data []byte) (*ast.MappingNode, error) {
 	file, err := parser.ParseBytes(data, parser.ParseComments)
 	if err != nil {
 		return nil, yamlerrors.From(err)
@@ -42,19 +85,5 @@ func Parse(data []byte) (*ast.MappingNode, error) {
 		)
 	}
 
-	// Walk
-	w := &walker{
-		anchors: map[string]ast.Node{},
-	}
-	ast.Walk(w, node)
-	if w.err != nil {
-		return nil, w.err
-	}
-
-	// Resolve
-	if err := resolve(node, w.anchors, map[string]bool{}); err != nil {
-		return nil, err
-	}
-
 	return node, nil
 }
@@ -221,6 +221,26 @@ func (s *ParseSuite) TestTags() {
 	s.Equal("bar", value.String())
 }
 
+func (s *ParseSuite) TestTagsKept() {
+	node, err := yamlparser.Parse([]byte(heredoc.Doc(`
+		anchor: &anchor !secret foo
+		alias: *anchor
+		bar: !!str bar
+	`)), yamlparser.WithTags("!secret"))
+
+	s.Require().NoError(err)
+
+	s.Require().Len(node.Values, 3)
+
+	for _, valueNode := range node.Values[:2] {
+		s.Require().IsType((*ast.TagNode)(nil), valueNode.Value)
+		s.Equal("!secret", valueNode.Value.(*ast.TagNode).Start.Value)
+		s.Equal("foo", valueNode.Value.(*ast.TagNode).Value.String())
+	}
+
+	s.Require().IsType((*ast.StringNode)(nil), node.Values[2].Value)
+}
+
 func (s *ParseSuite) TestMapKeyExplicit() {
 	node, err := yamlparser.Parse([]byte(heredoc.Doc(`
 		? foo: bar
@@ -309,6 +329,25 @@ func (s *ParseSuite) TestComments() {
 	})
 }
 
+func (s *ParseSuite) TestRaw() {
+	node, err := yamlparser.ParseRaw([]byte(heredoc.Doc(`
+		anchor: &anchor
+		  foo: foo
+		alias:
+		  <<: *anchor
+	`)))
+	s.Require().NoError(err)
+	s.Require().Len(node.Values, 2)
+
+	anchorNode := node.Values[0]
+	s.Require().IsType((*ast.AnchorNode)(nil), anchorNode.Value)
+
+	aliasNode := node.Values[1]
+	s.Require().IsType((*ast.MappingNode)(nil), aliasNode.Value)
+	s.Require().Len(aliasNode.Value.(*ast.MappingNode).Values, 1)
+	s.Require().IsType((*ast.AliasNode)(nil), aliasNode.Value.(*ast.MappingNode).Values[0].Value)
+}
+
 func (s *ParseSuite) TestErrors() {
 	tests := []struct {
 		test     string
@@ -3,15 +3,16 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	yamlerrors "github.com/manala/manala/internal/yaml/errors"
 
 	"github.com/goccy/go-yaml/ast"
 )
 
-// resolve replaces aliases with their anchor values and deduplicates mapping keys.
+// resolve replaces aliases with their anchor values, drops tags, except kept ones, and deduplicates mapping keys.
 // visiting tracks anchor names currently being resolved to detect cycles.
-func resolve(node ast.Node, anchors map[string]ast.Node, visiting map[string]bool) error {
+func resolve(node ast.Node, anchors map[string]ast.Node, visiting map[string]bool, tags []string) error {
 	switch n := node.(type) {
 	case *ast.MappingNode:
 		deduplicatedValues := make([]*ast.MappingValueNode, 0)
@@ -23,7 +24,7 @@ func resolve(node ast.Node, anchors map[string]ast.Node, visiting map[string]boo
 			if _, ok := v.Key.(*ast.MergeKeyNode); ok {
 				switch vv := v.Value.(type) {
 				case *ast.AliasNode:
-					mn, err := resolveMergeAlias(vv, anchors, visiting)
+					mn, err := resolveMergeAlias(vv, anchors, visiting, tags)
 					if err != nil {
 						return err
 					}
@@ -40,7 +41,7 @@ func resolve(node ast.Node, anchors map[string]ast.Node, visiting map[string]boo
 								elt.GetToken(),
 							)
 						}
-						mn, err := resolveMergeAlias(alias, anchors, visiting)
+						mn, err := resolveMergeAlias(alias, anchors, visiting, tags)
 						if err != nil {
 							return err
 						}
@@ -72,7 +73,7 @@ func resolve(node ast.Node, anchors map[string]ast.Node, visiting map[string]boo
 				deduplicatedValues = append(deduplicatedValues, mv)
 
 				// Resolve
-				if err := resolveValue(&mv.Value, anchors, visiting); err != nil {
+				if err := resolveValue(&mv.Value, anchors, visiting, tags); err != nil {
 					return err
 				}
 			}
@@ -82,7 +83,7 @@ func resolve(node ast.Node, anchors map[string]ast.Node, visiting map[string]boo
 
 	case *ast.SequenceNode:
 		for idx := range n.Values {
-			if err := resolveValue(&n.Values[idx], anchors, visiting); err != nil {
+			if err := resolveValue(&n.Values[idx], anchors, visiting, tags); err != nil {
 				return err
 			}
 		}
@@ -91,7 +92,7 @@ func resolve(node ast.Node, anchors map[string]ast.Node, visiting map[string]boo
 	return nil
 }
 
-func resolveMergeAlias(alias *ast.AliasNode, anchors map[string]ast.Node, visiting map[string]bool) (*ast.MappingNode, error) {
+func resolveMergeAlias(alias *ast.AliasNode, anchors map[string]ast.Node, visiting map[string]bool, tags []string) (*ast.MappingNode, error) {
 	name := alias.Value.GetToken().Value
 
 	if visiting[name] {
@@ -120,14 +121,17 @@ func resolveMergeAlias(alias *ast.AliasNode, anchors map[string]ast.Node, visiti
 	return mn, nil
 }
 
-func resolveValue(node *ast.Node, anchors map[string]ast.Node, visiting map[string]bool) error {
+func resolveValue(node *ast.Node, anchors map[string]ast.Node, visiting map[string]bool, tags []string) error {
 	switch n := (*node).(type) {
 	case *ast.TagNode:
+		if slices.Contains(tags, n.Start.Value) {
+			return resolveValue(&n.Value, anchors, visiting, tags)
+		}
 		*node = n.Value
-		return resolveValue(node, anchors, visiting)
+		return resolveValue(node, anchors, visiting, tags)
 	case *ast.MappingKeyNode:
 		*node = n.Value
-		return resolveValue(node, anchors, visiting)
+		return resolveValue(node, anchors, visiting, tags)
 	case *ast.AliasNode:
 		name := n.Value.GetToken().Value
 
@@ -148,7 +152,7 @@ func resolveValue(node *ast.Node, anchors map[string]ast.Node, visiting map[stri
 
 		visiting[name] = true
 		*node = anchor
-		err := resolveValue(node, anchors, visiting)
+		err := resolveValue(node, anchors, visiting, tags)
 		delete(visiting, name)
 		return err
 	case *ast.AnchorNode:
@@ -163,10 +167,10 @@ func resolveValue(node *ast.Node, anchors map[string]ast.Node, visiting map[stri
 
 		visiting[name] = true
 		*node = n.Value
-		err := resolveValue(node, anchors, visiting)
+		err := resolveValue(node, anchors, visiting, tags)
 		delete(visiting, name)
 		return err
 	default:
-		return resolve(*node, anchors, visiting)
+		return resolve(*node, anchors, visiting, tags)
 	}
 }
@@ -22,7 +22,14 @@ func FromJSONPointer(pointer string) string {
 				b.WriteByte(']')
 			} else {
 				b.WriteByte('.')
-				b.WriteString(token)
+				// Quote selectors containing reserved characters
+				if strings.ContainsAny(token, ".*") {
+					b.WriteByte('\'')
+					b.WriteString(strings.ReplaceAll(token, `'`, `\'`))
+					b.WriteByte('\'')
+				} else {
+					b.WriteString(token)
+				}
 			}
 		}
 	}
@@ -33,9 +40,45 @@ func ToJSONPointer(path string) string {
 	if path == "" || path == "$" {
 		return ""
 	}
-	normalized := strings.ReplaceAll(strings.ReplaceAll(path[1:], "[", "."), "]", "")
 	var b strings.Builder
-	for token := range strings.SplitSeq(normalized, ".") {
+	for i := 1; i < len(path); {
+		var token string
+
+		switch path[i] {
+		case '[':
+			// Index
+			end := strings.IndexByte(path[i:], ']')
+			if end == -1 {
+				end = len(path) - i
+			}
+			token = path[i+1 : i+end]
+			i += end + 1
+		case '.':
+			i++
+			if i < len(path) && path[i] == '\'' {
+				// Quoted selector
+				var t strings.Builder
+				for i++; i < len(path) && path[i] != '\''; i++ {
+					if path[i] == '\\' && i+1 < len(path) && path[i+1] == '\'' {
+						i++
+					}
+					t.WriteByte(path[i])
+				}
+				token = t.String()
+				i++
+			} else {
+				// Selector
+				end := strings.IndexAny(path[i:], ".[")
+				if end == -1 {
+					end = len(path) - i
+				}
+				token = path[i : i+end]
+				i += end
+			}
+		default:
+			i++
+		}
+
 		if token == "" {
 			continue
 		}
@@ -44,3 +87,29 @@ func ToJSONPointer(path string) string {
 	}
 	return b.String()
 }
+
+// SplitJSONPointer splits a json pointer into its unescaped reference tokens.
+func SplitJSONPointer(pointer string) []string {
+	if pointer == "" || pointer == "/" {
+		return nil
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = fromJSONPointerReplacer.Replace(token)
+	}
+
+	return tokens
+}
+
+// JoinJSONPointer joins reference tokens into a json pointer, escaping them.
+func JoinJSONPointer(tokens []string) string {
+	var b strings.Builder
+
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(toJSONPointerReplacer.Replace(token))
+	}
+
+	return b.String()
+}
@@ -30,6 +30,9 @@ func (s *JSONPointerSuite) TestFromJSONPointer() {
 		{test: "Deep", pointer: "/a/b/c", expected: "$.a.b.c"},
 		{test: "PointerEscapeTilde", pointer: "/a~0b", expected: "$.a~b"},
 		{test: "PointerEscapeSlash", pointer: "/a~1b", expected: "$.a/b"},
+		{test: "QuoteDot", pointer: "/a.b/c", expected: "$.'a.b'.c"},
+		{test: "QuoteStar", pointer: "/a*b", expected: "$.'a*b'"},
+		{test: "QuoteSingleQuote", pointer: "/a'b.c", expected: `$.'a\'b.c'`},
 	}
 	for _, test := range tests {
 		s.Run(test.test, func() {
@@ -52,6 +55,10 @@ func (s *JSONPointerSuite) TestToJSONPointer() {
 		{test: "ArrayNested", path: "$.foo[0].bar", expected: "/foo/0/bar"},
 		{test: "Deep", path: "$.a.b.c", expected: "/a/b/c"},
 		{test: "PointerEscapeTilde", path: "$.a~b", expected: "/a~0b"},
+		{test: "PointerEscapeSlash", path: "$.a/b", expected: "/a~1b"},
+		{test: "Quoted", path: "$.'a.b'.c", expected: "/a.b/c"},
+		{test: "QuotedIndex", path: "$.'a.b'[0]", expected: "/a.b/0"},
+		{test: "QuotedSingleQuote", path: `$.'a\'b.c'`, expected: "/a'b.c"},
 	}
 	for _, test := range tests {
 		s.Run(test.test, func() {
@@ -59,3 +66,42 @@ func (s *JSONPointerSuite) TestToJSONPointer() {
 		})
 	}
 }
+
+func (s *JSONPointerSuite) TestSplitJSONPointer() {
+	tests := []struct {
+		test     string
+		pointer  string
+		expected []string
+	}{
+		{test: "Empty", pointer: "", expected: nil},
+		{test: "Root", pointer: "/", expected: nil},
+		{test: "Object", pointer: "/foo", expected: []string{"foo"}},
+		{test: "ObjectNested", pointer: "/foo/bar", expected: []string{"foo", "bar"}},
+		{test: "PointerEscapeTilde", pointer: "/a~0b", expected: []string{"a~b"}},
+		{test: "PointerEscapeSlash", pointer: "/a~1b/c", expected: []string{"a/b", "c"}},
+	}
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			s.Equal(test.expected, yamlpath.SplitJSONPointer(test.pointer))
+		})
+	}
+}
+
+func (s *JSONPointerSuite) TestJoinJSONPointer() {
+	tests := []struct {
+		test     string
+		tokens   []string
+		expected string
+	}{
+		{test: "Empty", tokens: nil, expected: ""},
+		{test: "Object", tokens: []string{"foo"}, expected: "/foo"},
+		{test: "ObjectNested", tokens: []string{"foo", "bar"}, expected: "/foo/bar"},
+		{test: "PointerEscapeTilde", tokens: []string{"a~b"}, expected: "/a~0b"},
+		{test: "PointerEscapeSlash", tokens: []string{"a/b", "c"}, expected: "/a~1b/c"},
+	}
+	for _, test := range tests {
+		s.Run(test.test, func() {
+			s.Equal(test.expected, yamlpath.JoinJSONPointer(test.tokens))
+		})
+	}
+}
@@ -1,18 +1,32 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"maps"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"syscall"
 
 	"github.com/manala/manala/app/api"
+	"github.com/manala/manala/app/repository/getter"
 	"github.com/manala/manala/cmd"
+	cmdCache "github.com/manala/manala/cmd/cache"
+	cmdConfigure "github.com/manala/manala/cmd/configure"
 	cmdDocs "github.com/manala/manala/cmd/docs"
+	cmdGet "github.com/manala/manala/cmd/get"
 	cmdInit "github.com/manala/manala/cmd/init"
 	cmdList "github.com/manala/manala/cmd/list"
 	cmdMascot "github.com/manala/manala/cmd/mascot"
+	cmdMigrate "github.com/manala/manala/cmd/migrate"
+	cmdRecipe "github.com/manala/manala/cmd/recipe"
+	cmdSet "github.com/manala/manala/cmd/set"
 	cmdUpdate "github.com/manala/manala/cmd/update"
 	cmdWatch "github.com/manala/manala/cmd/watch"
 	"github.com/manala/manala/internal/cache"
+	"github.com/manala/manala/internal/errors/serror"
 	"github.com/manala/manala/internal/log"
 	"github.com/manala/manala/internal/notify"
 	"github.com/manala/manala/internal/output"
@@ -48,15 +62,26 @@ func main() {
 	// Commands
 	command := cmd.NewCommand(version, stdin, stdout, stderr)
 	command.AddCommand(
+		cmdCache.NewCommand(logger, appApi, out),
+		cmdConfigure.NewCommand(logger, appApi, out),
+		cmdGet.NewCommand(logger, appApi, out),
 		cmdInit.NewCommand(logger, appApi, out),
 		cmdList.NewCommand(logger, appApi, out),
 		cmdMascot.NewCommand(stdin, stdout),
+		cmdMigrate.NewCommand(logger, appApi, out),
+		cmdRecipe.NewCommand(logger, appApi, out),
+		cmdSet.NewCommand(logger, appApi, out),
 		cmdUpdate.NewCommand(logger, appApi, out),
 		cmdWatch.NewCommand(logger, appApi, out, notifier),
 	)
 
 	// Commands persistent flags
+	command.PersistentFlags().String("config", "", "use config file")
 	command.PersistentFlags().StringP("cache-dir", "c", "", "use cache directory")
+	command.PersistentFlags().StringP("env", "e", "", "merge project .manala.<env>.yaml override manifest")
+	command.PersistentFlags().Duration("cache-ttl", 0, "skip repositories fetching while cache is fresher than ttl")
+	command.PersistentFlags().Bool("offline", false, "serve repositories strictly from cache")
+	command.PersistentFlags().Duration("timeout", 0, "abort repositories fetching after timeout")
 	command.PersistentFlags().CountP("verbose", "v", "more verbose output (repeatable)")
 
 	// Docs command only available in dev
@@ -64,11 +89,23 @@ func main() {
 		command.AddCommand(cmdDocs.NewCommand(command))
 	}
 
+	// Config error, reported once command run
+	var configErr error
+
+	command.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+		return configErr
+	}
+
 	cobra.OnInitialize(func() {
 		// Viper
 		v := viper.New()
 
+		_ = v.BindPFlag("config", command.PersistentFlags().Lookup("config"))
 		_ = v.BindPFlag("cache_dir", command.PersistentFlags().Lookup("cache-dir"))
+		_ = v.BindPFlag("cache_ttl", command.PersistentFlags().Lookup("cache-ttl"))
+		_ = v.BindPFlag("env", command.PersistentFlags().Lookup("env"))
+		_ = v.BindPFlag("offline", command.PersistentFlags().Lookup("offline"))
+		_ = v.BindPFlag("timeout", command.PersistentFlags().Lookup("timeout"))
 		_ = v.BindPFlag("verbose", command.PersistentFlags().Lookup("verbose"))
 		v.SetDefault("default_repository", defaultRepositoryURL)
 
@@ -76,28 +113,102 @@ func main() {
 		v.AutomaticEnv()
 		v.SetEnvPrefix("MANALA")
 
+		// Viper - Config file
+		if file := v.GetString("config"); file != "" {
+			v.SetConfigFile(file)
+		} else {
+			v.SetConfigName("config")
+			v.SetConfigType("yaml")
+			if dir, err := os.UserConfigDir(); err == nil {
+				v.AddConfigPath(filepath.Join(dir, "manala"))
+			}
+		}
+
+		if err := v.ReadInConfig(); err != nil {
+			if _, ok := errors.AsType[viper.ConfigFileNotFoundError](err); !ok {
+				configErr = serror.New("unable to read config file").
+					WithErr(err)
+			}
+		}
+
 		// Cache
 		cache := cache.New(v.GetString("cache_dir")).
 			WithUserDir("manala")
 
+		// Git auth
+		gitAuth := &getter.GitAuth{
+			Default: getter.GitCredentials{
+				SSHKey: v.GetString("git_ssh_key"),
+				Token:  v.GetString("git_token"),
+			},
+			Hosts: map[string]getter.GitCredentials{},
+		}
+
+		var gitHosts map[string]struct {
+			SSHKey   string `mapstructure:"ssh_key"`
+			Token    string `mapstructure:"token"`
+			Username string `mapstructure:"username"`
+		}
+		if err := v.UnmarshalKey("git_hosts", &gitHosts); err != nil && configErr == nil {
+			configErr = serror.New("invalid git hosts config").
+				WithErr(err)
+		}
+		for host, credentials := range gitHosts {
+			gitAuth.Hosts[host] = getter.GitCredentials{
+				SSHKey:   credentials.SSHKey,
+				Token:    credentials.Token,
+				Username: credentials.Username,
+			}
+		}
+
 		// Logger verbose mode
 		logger.Verbose(v.GetInt("verbose"))
 
 		// Deferred app api instantiation
 		*appApi = *api.New(logger, cache,
+			api.WithVersion(version),
 			api.WithDefaultRepositoryURL(v.GetString("default_repository")),
+			api.WithRepositoryAliases(v.GetStringMapString("repositories")),
+			api.WithOffline(v.GetBool("offline")),
+			api.WithCacheTTL(v.GetDuration("cache_ttl")),
+			api.WithTimeout(v.GetDuration("timeout")),
+			api.WithGitAuth(gitAuth),
+			api.WithGitShallow(v.GetBool("git_shallow")),
+			api.WithGitSparse(v.GetBool("git_sparse")),
+			api.WithAllowedSigners(v.GetString("allowed_signers")),
+			api.WithProjectEnv(v.GetString("env")),
+			api.WithSecretExec(v.GetBool("secret_exec")),
 		)
 
 		// Log config
 		logger.Debug("config",
+			"config_file", v.ConfigFileUsed(),
 			"default_repository", v.GetString("default_repository"),
+			"repositories", v.GetStringMapString("repositories"),
 			"cache_dir", v.GetString("cache_dir"),
+			"cache_ttl", v.GetDuration("cache_ttl"),
+			"offline", v.GetBool("offline"),
+			"env", v.GetString("env"),
+			"timeout", v.GetDuration("timeout"),
+			// Never log git secrets
+			"git_ssh_key", v.GetString("git_ssh_key"),
+			"git_hosts", slices.Sorted(maps.Keys(gitHosts)),
+			"git_shallow", v.GetBool("git_shallow"),
+			"git_sparse", v.GetBool("git_sparse"),
+			"allowed_signers", v.GetString("allowed_signers"),
+			"secret_exec", v.GetBool("secret_exec"),
 			"verbose", v.GetInt("verbose"),
 		)
 	})
 
+	// Context, cancelled on interruption
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+
 	// Execute command
-	if err := command.Execute(); err != nil {
+	err := command.ExecuteContext(ctx)
+	stop()
+
+	if err != nil {
 		if _, ok := errors.AsType[*cmd.CancelError](err); ok {
 			lipgloss.Fprintln(stdout, err.Error())
 			os.Exit(0)